@@ -1,7 +1,18 @@
 package domain
 
+// Caps of the rows and bytes read from a query result, past them the result
+// is truncated
+const (
+	DefaultMaxResultRows  = 100000
+	DefaultMaxResultBytes = 64 << 20
+)
+
 type Config struct {
 	openAIAPIKey string
+	// maxResultRows and maxResultBytes cap query results, the defaults
+	// apply when zero
+	maxResultRows  int
+	maxResultBytes int64
 }
 
 func NewConfig(openAIAPIKey string) (*Config, error) {
@@ -12,8 +23,12 @@ func NewConfig(openAIAPIKey string) (*Config, error) {
 
 func NewConfigFromMap(data map[string]interface{}) *Config {
 	openAIAPIKey, _ := data["openAIAPIKey"].(string)
+	maxResultRows, _ := data["maxResultRows"].(float64)
+	maxResultBytes, _ := data["maxResultBytes"].(float64)
 	return &Config{
-		openAIAPIKey: openAIAPIKey,
+		openAIAPIKey:   openAIAPIKey,
+		maxResultRows:  int(maxResultRows),
+		maxResultBytes: int64(maxResultBytes),
 	}
 }
 
@@ -25,8 +40,37 @@ func (c *Config) SetOpenAIAPIKey(key string) {
 	c.openAIAPIKey = key
 }
 
+// MaxResultRows returns how many rows of a query result are read at most
+func (c *Config) MaxResultRows() int {
+	if c == nil || c.maxResultRows <= 0 {
+		return DefaultMaxResultRows
+	}
+	return c.maxResultRows
+}
+
+// MaxResultBytes returns how many bytes of a query result are read at most
+func (c *Config) MaxResultBytes() int64 {
+	if c == nil || c.maxResultBytes <= 0 {
+		return DefaultMaxResultBytes
+	}
+	return c.maxResultBytes
+}
+
+// SetResultLimits sets the caps of query results, zero restores the default
+func (c *Config) SetResultLimits(maxRows int, maxBytes int64) {
+	c.maxResultRows = max(maxRows, 0)
+	c.maxResultBytes = max(maxBytes, 0)
+}
+
 func (c *Config) ToMap() map[string]any {
-	return map[string]any{
+	data := map[string]any{
 		"openAIAPIKey": c.openAIAPIKey,
 	}
+	if c.maxResultRows > 0 {
+		data["maxResultRows"] = c.maxResultRows
+	}
+	if c.maxResultBytes > 0 {
+		data["maxResultBytes"] = c.maxResultBytes
+	}
+	return data
 }
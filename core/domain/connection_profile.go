@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Environment labels a connection can carry
+const (
+	EnvironmentDevelopment = "dev"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "prod"
+)
+
+// Environments are the accepted environment labels, besides none
+var Environments = []string{EnvironmentDevelopment, EnvironmentStaging, EnvironmentProduction}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ConnectionProfile holds how a saved connection is presented and organized
+type ConnectionProfile struct {
+	// Name is the display name, the host is shown when empty
+	Name string
+	// Folder is a slash separated path, e.g. "clients/acme"
+	Folder string
+	// Tags are free-form labels
+	Tags []string
+	// Environment is one of Environments or empty
+	Environment string
+	// Color is a #rrggbb color or empty
+	Color string
+}
+
+// Normalize trims the fields, cleans the folder path and removes empty or
+// repeated tags
+func (p *ConnectionProfile) Normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Environment = strings.TrimSpace(p.Environment)
+	p.Color = strings.TrimSpace(p.Color)
+
+	var parts []string
+	for _, part := range strings.Split(p.Folder, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	p.Folder = strings.Join(parts, "/")
+
+	var tags []string
+	for _, tag := range p.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	p.Tags = tags
+}
+
+// Validate checks the environment label and color
+func (p *ConnectionProfile) Validate() error {
+	if p.Environment != "" && !slices.Contains(Environments, p.Environment) {
+		return fmt.Errorf("unsupported environment: %s", p.Environment)
+	}
+	if p.Color != "" && !colorPattern.MatchString(p.Color) {
+		return fmt.Errorf("color must be written as #rrggbb: %s", p.Color)
+	}
+	return nil
+}
+
+// InFolder reports whether the profile is in the folder or one of its subfolders
+func (p ConnectionProfile) InFolder(folder string) bool {
+	folder = strings.Trim(folder, "/")
+	return folder == "" || p.Folder == folder || strings.HasPrefix(p.Folder, folder+"/")
+}
+
+// HasTag reports whether the profile carries the tag
+func (p ConnectionProfile) HasTag(tag string) bool {
+	return slices.Contains(p.Tags, tag)
+}
+
+// Map returns the profile in its persisted form
+func (p ConnectionProfile) Map() map[string]interface{} {
+	tags := p.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return map[string]interface{}{
+		"name":        p.Name,
+		"folder":      p.Folder,
+		"tags":        tags,
+		"environment": p.Environment,
+		"color":       p.Color,
+	}
+}
+
+// NewConnectionProfileFromMap restores a profile persisted with Map
+func NewConnectionProfileFromMap(data map[string]interface{}) ConnectionProfile {
+	name, _ := data["name"].(string)
+	folder, _ := data["folder"].(string)
+	environment, _ := data["environment"].(string)
+	color, _ := data["color"].(string)
+
+	var tags []string
+	if values, ok := data["tags"].([]interface{}); ok {
+		for _, v := range values {
+			if tag, ok := v.(string); ok {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return ConnectionProfile{
+		Name:        name,
+		Folder:      folder,
+		Tags:        tags,
+		Environment: environment,
+		Color:       color,
+	}
+}
+
+// ConnectionFilter selects saved connections. Empty fields match everything.
+type ConnectionFilter struct {
+	// Folder matches the folder and its subfolders
+	Folder      string
+	Tag         string
+	Environment string
+	// Search matches the name, host or database, ignoring case
+	Search string
+}
+
+// Matches reports whether the connection passes the filter
+func (f ConnectionFilter) Matches(c *Connection) bool {
+	p := c.profile
+	if !p.InFolder(f.Folder) {
+		return false
+	}
+	if f.Tag != "" && !p.HasTag(f.Tag) {
+		return false
+	}
+	if f.Environment != "" && p.Environment != f.Environment {
+		return false
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(p.Name), search) &&
+			!strings.Contains(strings.ToLower(c.host), search) &&
+			!strings.Contains(strings.ToLower(c.database), search) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ImportedConnection is a connection found in the configuration of another tool
+type ImportedConnection struct {
+	// Connection has no ID until it is imported
+	Connection *Connection
+	// Origin tells where the connection was found, e.g. a file and line
+	Origin string
+}
+
+// ConnectionSource reads the connections saved by another tool
+type ConnectionSource interface {
+	// Kind identifies the source, e.g. "pgpass"
+	Kind() string
+	// Label is the human readable source name
+	Label() string
+	// DefaultPath returns where the tool saves its connections
+	DefaultPath() string
+	// Read parses the connections saved in the file at path. Entries that
+	// cannot be imported are left out and reported with a SkippedEntries
+	// error returned along with the others.
+	Read(path string) ([]ImportedConnection, error)
+}
+
+// SkippedEntries reports the entries a ConnectionSource left out
+type SkippedEntries struct {
+	// Reasons tell where each entry was found and why it was skipped
+	Reasons []string
+}
+
+func (e *SkippedEntries) Error() string {
+	return "skipped " + strings.Join(e.Reasons, "; ")
+}
+
+// Identity identifies the server, database and user a connection reaches,
+// so the same connection found twice can be told apart from a new one
+func (c *Connection) Identity() string {
+	return c.vendor + "|" + c.username + "@" + c.host + ":" + strconv.Itoa(c.port) + "/" + c.database
+}
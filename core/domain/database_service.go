@@ -1,8 +1,11 @@
 package domain
 
+import "context"
+
 // DatabaseService defines the interface for database operations
 type DatabaseService interface {
-	// Connection management
+	// Connection management. Connect opens or reuses the session for the
+	// connection's database; Disconnect closes every session of the connection.
 	Connect(c *Connection) error
 	Disconnect(c *Connection) error
 
@@ -13,13 +16,16 @@ type DatabaseService interface {
 	GetTableMetadata(c *Connection, tableName, schemaName string) (*TableMetadata, error)
 
 	// Query execution
-	ExecQuery(c *Connection, query string) (*QueryResult, error)
+	ExecQuery(ctx context.Context, c *Connection, query string) (*QueryResult, error)
+	// OpenSession takes a database session of its own, kept until closed
+	OpenSession(ctx context.Context, c *Connection) (QuerySession, error)
 }
 
 // QueryResult represents the result of a query execution
 type QueryResult struct {
 	Columns      []string
+	ColumnTypes  []ColumnType
 	Rows         [][]interface{}
 	RowsAffected int64
 	Duration     int64
-}
\ No newline at end of file
+}
@@ -0,0 +1,85 @@
+package domain
+
+// DestructiveStatement is a statement that drops, empties or rewrites data
+// or schema objects at once
+type DestructiveStatement struct {
+	// Keyword is DROP, TRUNCATE, ALTER, UPDATE or DELETE
+	Keyword string
+	// Statement is the statement as written
+	Statement string
+	// Risk explains what running the statement does
+	Risk string
+}
+
+// RowEstimator is implemented by database services that can estimate the rows
+// a statement touches, through EXPLAIN, without running it
+type RowEstimator interface {
+	EstimateRows(c *Connection, statement string) (int64, error)
+}
+
+// FindDestructiveStatements returns the statements of the script that drop,
+// truncate or alter objects, the updates and deletes without WHERE clause of
+// their own, and the WITH statements that update or delete rows
+func FindDestructiveStatements(script string, dialect SQLDialect) []DestructiveStatement {
+	var found []DestructiveStatement
+	for _, statement := range splitStatements(script, dialect) {
+		keyword := statement.keyword()
+		with := keyword == "WITH"
+		if with {
+			keyword = statement.withModifies()
+		}
+
+		var risk string
+		switch keyword {
+		case "DROP":
+			risk = "DROP removes the object together with the data it holds, it can only be recovered from a backup"
+		case "TRUNCATE":
+			risk = "TRUNCATE removes every row of the table at once"
+		case "ALTER":
+			risk = "ALTER changes the schema, it can drop columns and lock or rewrite the whole table"
+		case "UPDATE", "DELETE":
+			if with {
+				if keyword == "UPDATE" {
+					risk = "UPDATE inside WITH can change every row of the table"
+				} else {
+					risk = "DELETE inside WITH can remove every row of the table"
+				}
+				break
+			}
+			// A WHERE inside a subquery does not limit the rows changed
+			if statement.indexTop(statement.keywordIndex(), "WHERE") >= 0 {
+				continue
+			}
+			if keyword == "UPDATE" {
+				risk = "UPDATE without WHERE changes every row of the table"
+			} else {
+				risk = "DELETE without WHERE removes every row of the table"
+			}
+		default:
+			continue
+		}
+
+		found = append(found, DestructiveStatement{
+			Keyword:   keyword,
+			Statement: statement.text,
+			Risk:      risk,
+		})
+	}
+	return found
+}
+
+// withModifies returns UPDATE or DELETE when a WITH statement changes rows,
+// through one of its common table expressions or its main statement, and an
+// empty string otherwise. Both start right after a parenthesis, which tells
+// them from FOR UPDATE and ON DELETE.
+func (s sqlStatement) withModifies() string {
+	for i := 1; i < len(s.tokens); i++ {
+		switch s.tokens[i] {
+		case "UPDATE", "DELETE":
+			if previous := s.tokens[i-1]; previous == "(" || previous == ")" {
+				return s.tokens[i]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,382 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DryRun is how a data-modifying statement is previewed. The statements run
+// in a transaction that is rolled back once the rows are read.
+type DryRun struct {
+	// Keyword is UPDATE, DELETE or INSERT
+	Keyword string
+	// Statement is the statement to run, RETURNING * is appended when the
+	// dialect can return the rows it changes
+	Statement ScriptStatement
+	// Returning is set when Statement returns the rows it changes: the new
+	// rows of an UPDATE or INSERT and the removed rows of a DELETE
+	Returning bool
+	// Counted is set when Statement reads the rows it returns from a WITH
+	// clause, followed by a column holding their count, so the count is
+	// known without reading them all
+	Counted bool
+	// Before selects the rows an UPDATE or DELETE is about to change, when
+	// Statement does not return them
+	Before *ScriptStatement
+	// After selects again the rows an UPDATE changed, when Statement does not
+	// return them and its SET clause leaves the columns its WHERE, ORDER BY
+	// and LIMIT clauses read unchanged
+	After *ScriptStatement
+}
+
+// dryRunModifiers are the words that may follow UPDATE, DELETE and INSERT
+// before the table
+var dryRunModifiers = map[string]bool{
+	"ONLY":          true,
+	"LOW_PRIORITY":  true,
+	"HIGH_PRIORITY": true,
+	"DELAYED":       true,
+	"QUICK":         true,
+	"IGNORE":        true,
+}
+
+// TableName is a table named by a statement, Schema is empty when the name
+// is not qualified
+type TableName struct {
+	Schema string
+	Name   string
+}
+
+func (t TableName) String() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// RollbackChecker is implemented by database services with tables whose
+// changes a rollback does not undo, as MySQL tables of MyISAM or MEMORY
+// engines. CheckRollback fails when one of the tables is such a table, so
+// dry runs and analyzed plans are refused rather than kept.
+type RollbackChecker interface {
+	CheckRollback(c *Connection, tables []TableName) error
+}
+
+// PlanDryRun tells how to preview an UPDATE, DELETE or INSERT statement.
+// Without RETURNING, the rows an UPDATE or DELETE changes are read before
+// it runs by a SELECT built from its table and WHERE clause.
+func PlanDryRun(statement ScriptStatement, dialect SQLDialect) (*DryRun, error) {
+	statements := splitStatements(statement.Text, dialect)
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("dry run expects a single statement")
+	}
+	s := statements[0]
+
+	plan := &DryRun{Keyword: s.keyword(), Statement: statement}
+	switch plan.Keyword {
+	case "UPDATE", "DELETE", "INSERT":
+	default:
+		return nil, fmt.Errorf("dry run supports UPDATE, DELETE and INSERT statements only, not %s", s.keyword())
+	}
+
+	if dialect.Returning {
+		plan.Returning = true
+		returning := ""
+		if s.indexTop(0, "RETURNING") < 0 {
+			returning = " RETURNING *"
+		}
+		plan.Statement = s.compose(statement, [2]int{0, len(statement.Text)}, returning)
+		if dialect.ModifyingCTEs {
+			plan.Counted = true
+			plan.Statement = s.compose(statement,
+				"WITH changed AS (", [2]int{0, len(statement.Text)}, returning+") ",
+				"SELECT *, COUNT(*) OVER () FROM changed",
+			)
+		}
+		if plan.Keyword != "UPDATE" {
+			return plan, nil
+		}
+	}
+
+	var err error
+	switch plan.Keyword {
+	case "UPDATE":
+		err = s.planUpdate(plan, statement, dialect)
+	case "DELETE":
+		err = s.planDelete(plan, statement)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ChangedTables returns the tables an UPDATE, DELETE, INSERT or REPLACE
+// statement, or the one a WITH statement ends with, names before its SET,
+// WHERE or VALUES clause: the tables it changes along with the tables they
+// are joined with. Other statements change no table and return none.
+func ChangedTables(statement ScriptStatement, dialect SQLDialect) []TableName {
+	statements := splitStatements(statement.Text, dialect)
+	if len(statements) != 1 {
+		return nil
+	}
+	s := statements[0]
+
+	i := s.keywordIndex()
+	if i < len(s.tokens) && s.tokens[i] == "WITH" {
+		i = s.indexTop(i, "UPDATE", "DELETE", "INSERT", "REPLACE")
+		if i < 0 {
+			return nil
+		}
+	}
+	if i >= len(s.tokens) {
+		return nil
+	}
+
+	var end int
+	switch s.tokens[i] {
+	case "UPDATE":
+		end = s.indexTop(i, "SET")
+	case "DELETE":
+		end = s.indexTop(i, "WHERE", "ORDER", "LIMIT", "RETURNING")
+	case "INSERT", "REPLACE":
+		end = s.indexTop(i, "VALUES", "VALUE", "SET", "SELECT", "TABLE", "DEFAULT", "PARTITION", "ON")
+	default:
+		return nil
+	}
+	if end < 0 {
+		end = len(s.tokens)
+	}
+
+	// A table follows the keyword and its modifiers, and each FROM, USING,
+	// INTO, JOIN and comma outside parentheses
+	var tables []TableName
+	seen := make(map[TableName]bool)
+	expect := true
+	depth := 0
+	for i++; i < end; i++ {
+		switch s.tokens[i] {
+		case "(":
+			depth++
+			expect = false
+			continue
+		case ")":
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+
+		switch s.tokens[i] {
+		case ",", "FROM", "USING", "INTO", "JOIN":
+			expect = true
+			continue
+		}
+		if !expect || dryRunModifiers[s.tokens[i]] {
+			continue
+		}
+		expect = false
+		if table, next, ok := s.tableName(i); ok {
+			if !seen[table] {
+				seen[table] = true
+				tables = append(tables, table)
+			}
+			i = next - 1
+		}
+	}
+	return tables
+}
+
+// planUpdate builds the SELECT of the rows an UPDATE changes, and the one
+// reading them back when the dialect cannot return them:
+// UPDATE target SET ... [FROM list] [WHERE ...] [ORDER BY ...] [LIMIT ...]
+func (s sqlStatement) planUpdate(plan *DryRun, statement ScriptStatement, dialect SQLDialect) error {
+	target := s.skipModifiers(1)
+	set := s.indexTop(target, "SET")
+	if set <= target {
+		return fmt.Errorf("dry run could not find the table and SET clause of the UPDATE")
+	}
+	from := s.indexTop(set, "FROM")
+	tail := s.indexTop(set, "WHERE", "ORDER", "LIMIT")
+	end := s.indexTop(set, "RETURNING")
+	if end < 0 {
+		end = len(s.tokens)
+	}
+	setEnd := end
+	for _, i := range []int{from, tail} {
+		if i >= 0 && i < setEnd {
+			setEnd = i
+		}
+	}
+
+	parts := []interface{}{"SELECT * FROM ", s.between(target, set)}
+	if from >= 0 {
+		// The joined tables of UPDATE ... FROM only filter the rows
+		fromEnd := end
+		if tail >= 0 {
+			fromEnd = tail
+		}
+		parts = []interface{}{
+			"SELECT " + s.tokenText(set-1) + ".* FROM ", s.between(target, set),
+			", ", s.between(from+1, fromEnd),
+		}
+	}
+	if tail >= 0 {
+		parts = append(parts, " ", s.between(tail, end))
+	}
+	before := s.compose(statement, parts...)
+	plan.Before = &before
+
+	if !dialect.Returning && (tail < 0 || !s.setChanges(set+1, setEnd, tail, end)) {
+		plan.After = &before
+	}
+	return nil
+}
+
+// planDelete builds the SELECT of the rows a DELETE removes:
+// DELETE [targets] FROM tables [USING tables] [WHERE ...] [ORDER BY ...] [LIMIT ...]
+func (s sqlStatement) planDelete(plan *DryRun, statement ScriptStatement) error {
+	from := s.indexTop(1, "FROM")
+	if from < 0 || from+1 >= len(s.tokens) {
+		return fmt.Errorf("dry run could not find the table of the DELETE")
+	}
+	tables := from + 1
+	if using := s.indexTop(from, "USING"); using >= 0 && using+1 < len(s.tokens) {
+		tables = using + 1
+	}
+
+	before := s.compose(statement, "SELECT * FROM ", s.between(tables, len(s.tokens)))
+	plan.Before = &before
+	return nil
+}
+
+// skipModifiers returns the first token from i that is not a modifier of
+// UPDATE or DELETE
+func (s sqlStatement) skipModifiers(i int) int {
+	for i < len(s.tokens) && dryRunModifiers[s.tokens[i]] {
+		i++
+	}
+	return i
+}
+
+// between returns the byte range of the text from token i to the token
+// before end
+func (s sqlStatement) between(i, end int) [2]int {
+	return [2]int{s.spans[i][0], s.spans[end-1][1]}
+}
+
+// tokenText returns token i as written
+func (s sqlStatement) tokenText(i int) string {
+	return s.text[s.spans[i][0]-s.start : s.spans[i][1]-s.start]
+}
+
+// setChanges reports whether the SET clause, from token i to end, assigns a
+// column the tokens from read to readEnd mention. Quoted names are compared
+// without their quotes.
+func (s sqlStatement) setChanges(i, end, read, readEnd int) bool {
+	mentioned := make(map[string]bool)
+	for k := read; k < readEnd; k++ {
+		mentioned[s.name(k)] = true
+	}
+
+	depth := 0
+	assignment := true
+	for ; i < end; i++ {
+		switch s.tokens[i] {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case ",":
+			if depth == 0 {
+				assignment = true
+			}
+		case "=":
+			if depth == 0 && assignment {
+				assignment = false
+				if mentioned[s.name(i-1)] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// tableName reads the table name, qualified or not, at token i and returns
+// the token following it
+func (s sqlStatement) tableName(i int) (TableName, int, bool) {
+	name, ok := s.identifier(i)
+	if !ok {
+		return TableName{}, i, false
+	}
+	if i+2 < len(s.tokens) && s.tokens[i+1] == "." {
+		if qualified, ok := s.identifier(i + 2); ok {
+			return TableName{Schema: name, Name: qualified}, i + 3, true
+		}
+	}
+	return TableName{Name: name}, i + 1, true
+}
+
+// identifier returns token i as written when it is a word or a quoted
+// identifier, without its quotes
+func (s sqlStatement) identifier(i int) (string, bool) {
+	text := s.tokenText(i)
+	if s.tokens[i] == identifierToken {
+		if len(text) < 2 {
+			return "", false
+		}
+		closing := text[len(text)-1:]
+		return strings.ReplaceAll(text[1:len(text)-1], closing+closing, closing), true
+	}
+	r, _ := utf8.DecodeRuneInString(text)
+	if !isWordRune(r) || unicode.IsDigit(r) {
+		return "", false
+	}
+	return text, true
+}
+
+// name returns token i as a name, upper-cased and unquoted
+func (s sqlStatement) name(i int) string {
+	if s.tokens[i] != identifierToken {
+		return s.tokens[i]
+	}
+	text := s.tokenText(i)
+	if len(text) < 2 {
+		return text
+	}
+	return strings.ToUpper(text[1 : len(text)-1])
+}
+
+// compose builds a statement from text and byte ranges of the statement s
+// was lexed from, keeping the placeholders of the ranges
+func (s sqlStatement) compose(statement ScriptStatement, parts ...interface{}) ScriptStatement {
+	var text strings.Builder
+	var parameters []ScriptParameter
+	for _, part := range parts {
+		switch p := part.(type) {
+		case string:
+			text.WriteString(p)
+		case [2]int:
+			offset := text.Len() - p[0]
+			for _, param := range statement.Parameters {
+				if param.Start >= p[0] && param.End <= p[1] {
+					param.Start += offset
+					param.End += offset
+					parameters = append(parameters, param)
+				}
+			}
+			text.WriteString(statement.Text[p[0]:p[1]])
+		}
+	}
+
+	return ScriptStatement{
+		Text:       text.String(),
+		Start:      statement.Start,
+		End:        statement.End,
+		Parameters: parameters,
+	}
+}
@@ -0,0 +1,66 @@
+package domain_test
+
+import (
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestPlanDryRunStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		dialect   domain.SQLDialect
+		want      string
+		counted   bool
+	}{
+		{
+			name:      "counted from a WITH clause",
+			statement: "DELETE FROM t WHERE id = :id",
+			dialect:   postgresDialect,
+			want:      "WITH changed AS (DELETE FROM t WHERE id = :id RETURNING *) SELECT *, COUNT(*) OVER () FROM changed",
+			counted:   true,
+		},
+		{
+			name:      "own RETURNING clause",
+			statement: "INSERT INTO t (a) VALUES (1) RETURNING id",
+			dialect:   postgresDialect,
+			want:      "WITH changed AS (INSERT INTO t (a) VALUES (1) RETURNING id) SELECT *, COUNT(*) OVER () FROM changed",
+			counted:   true,
+		},
+		{
+			name:      "returning without WITH clauses",
+			statement: "DELETE FROM t WHERE id = ?",
+			dialect:   sqliteDialect,
+			want:      "DELETE FROM t WHERE id = ? RETURNING *",
+		},
+		{
+			name:      "not returning",
+			statement: "DELETE FROM t WHERE id = ?",
+			dialect:   mysqlDialect,
+			want:      "DELETE FROM t WHERE id = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := domain.SplitScript(tt.statement, tt.dialect)
+			plan, err := domain.PlanDryRun(statements[0], tt.dialect)
+			if err != nil {
+				t.Fatalf("PlanDryRun() error = %v", err)
+			}
+			if plan.Statement.Text != tt.want || plan.Counted != tt.counted {
+				t.Errorf("PlanDryRun() = %q counted %v, want %q counted %v", plan.Statement.Text, plan.Counted, tt.want, tt.counted)
+			}
+
+			// The placeholders keep their place in the statement
+			for i, param := range plan.Statement.Parameters {
+				original := statements[0].Parameters[i]
+				got := plan.Statement.Text[param.Start:param.End]
+				if want := tt.statement[original.Start:original.End]; got != want {
+					t.Errorf("parameter %d = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
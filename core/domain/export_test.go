@@ -0,0 +1,19 @@
+package domain
+
+// Markers standing for the literals, quoted identifiers and placeholders
+// among the tokens of a statement
+const (
+	StringToken     = stringToken
+	IdentifierToken = identifierToken
+	ParameterToken  = parameterToken
+)
+
+// StatementTokens returns the tokens of each statement of script, for the
+// tests of package domain_test, which import the vendors for their dialects
+func StatementTokens(script string, dialect SQLDialect) [][]string {
+	var tokens [][]string
+	for _, statement := range splitStatements(script, dialect) {
+		tokens = append(tokens, statement.tokens)
+	}
+	return tokens
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -21,7 +22,6 @@ func (s *MetadataFactory) NewConnectionMetadata(conn *Connection) (*ConnectionMe
 	if err := dbService.Connect(conn); err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	defer dbService.Disconnect(conn)
 
 	metadata := NewConnectionMetadata(conn.ID(), conn.Host(), conn.Port())
 
@@ -56,7 +56,6 @@ func (s *MetadataFactory) analyzeDatabaseMetadata(conn *Connection, databaseName
 	if err := dbService.Connect(cpy); err != nil {
 		return nil, fmt.Errorf("failed to connect to database %s: %w", databaseName, err)
 	}
-	defer dbService.Disconnect(cpy)
 
 	metadata := NewDatabaseMetadata(databaseName)
 
@@ -87,29 +86,12 @@ type TableInfo struct {
 
 // getTableList retrieves all tables in a database
 func (s *MetadataFactory) getTableList(conn *Connection, dbService DatabaseService) ([]TableInfo, error) {
-	var query string
-	switch conn.Vendor() {
-	case "postgresql":
-		query = `
-			SELECT table_name, table_schema
-			FROM information_schema.tables 
-			WHERE table_schema = 'public' 
-			AND table_type = 'BASE TABLE'
-			ORDER BY table_name
-		`
-	case "mysql":
-		query = `
-			SELECT table_name, table_schema
-			FROM information_schema.tables 
-			WHERE table_schema = DATABASE()
-			AND table_type = 'BASE TABLE'
-			ORDER BY table_name
-		`
-	default:
-		return nil, fmt.Errorf("unsupported vendor: %s", conn.Vendor())
+	v, err := LookupVendor(conn.Vendor())
+	if err != nil {
+		return nil, err
 	}
 
-	data, err := dbService.ExecQuery(conn, query)
+	data, err := dbService.ExecQuery(context.Background(), conn, v.TablesQuery(conn))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tables: %w", err)
 	}
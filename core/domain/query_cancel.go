@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+type cancelHookKey struct{}
+
+// CancelHook cancels a running query. Canceling the context only stops the
+// client from waiting, so database services register with OnCancel how the
+// server cancels the query once they know the session running it.
+type CancelHook struct {
+	mu            sync.Mutex
+	cancelContext context.CancelFunc
+	cancelServer  func() error
+	canceled      bool
+}
+
+// WithCancelHook returns a copy of ctx that the returned hook cancels
+func WithCancelHook(parent context.Context) (context.Context, *CancelHook) {
+	ctx, cancel := context.WithCancel(parent)
+	hook := &CancelHook{cancelContext: cancel}
+	return context.WithValue(ctx, cancelHookKey{}, hook), hook
+}
+
+// Cancel cancels the context and then the query on the server, when a
+// service registered how
+func (h *CancelHook) Cancel() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.canceled = true
+	h.cancelContext()
+	if h.cancelServer == nil {
+		return nil
+	}
+	return h.cancelServer()
+}
+
+// OnCancel registers how the server cancels the query run under ctx, when ctx
+// carries a CancelHook. The returned function unregisters it and must be
+// called before the session running the query goes back to the pool. When
+// ctx ran out meanwhile, it cancels the query on the server first. Without a
+// hook, the query is canceled on the server as soon as ctx is done, so a
+// deadline stops statements the server would keep running.
+func OnCancel(ctx context.Context, cancelServer func() error) func() {
+	hook, ok := ctx.Value(cancelHookKey{}).(*CancelHook)
+	if !ok {
+		canceled := make(chan struct{})
+		stop := context.AfterFunc(ctx, func() {
+			defer close(canceled)
+			_ = cancelServer()
+		})
+		return func() {
+			// A cancel under way has to land before the session is reused
+			if !stop() {
+				<-canceled
+			}
+		}
+	}
+
+	hook.mu.Lock()
+	hook.cancelServer = cancelServer
+	hook.mu.Unlock()
+
+	return func() {
+		hook.mu.Lock()
+		defer hook.mu.Unlock()
+
+		if ctx.Err() != nil && !hook.canceled {
+			_ = cancelServer()
+		}
+		hook.cancelServer = nil
+	}
+}
@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"seagle/core/domain"
+)
+
+func TestOnCancelWithoutHook(t *testing.T) {
+	var canceled atomic.Int32
+	cancelServer := func() error {
+		canceled.Add(1)
+		return nil
+	}
+
+	// Done before its deadline, the query is left alone
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	domain.OnCancel(ctx, cancelServer)()
+	cancel()
+	if n := canceled.Load(); n != 0 {
+		t.Errorf("canceled %d times before the deadline", n)
+	}
+
+	// Past its deadline, the query is canceled on the server before the
+	// session is given back
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	unregister := domain.OnCancel(ctx, cancelServer)
+	<-ctx.Done()
+	unregister()
+	if n := canceled.Load(); n != 1 {
+		t.Errorf("canceled %d times past the deadline, want 1", n)
+	}
+}
+
+func TestOnCancelWithHook(t *testing.T) {
+	var canceled atomic.Int32
+	ctx, hook := domain.WithCancelHook(context.Background())
+	unregister := domain.OnCancel(ctx, func() error {
+		canceled.Add(1)
+		return nil
+	})
+
+	hook.Cancel()
+	unregister()
+	if n := canceled.Load(); n != 1 {
+		t.Errorf("canceled %d times, want 1", n)
+	}
+}
@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// countedStatements change rows and return their count rather than rows,
+// unless they have a RETURNING clause
+var countedStatements = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "REPLACE": true,
+}
+
+// QueryCursor reads the result of a query page by page, so results of any
+// size can be browsed without loading them whole
+type QueryCursor interface {
+	// Columns returns the result columns, none for statements returning no rows
+	Columns() []ColumnType
+	// RowsAffected returns the rows changed by a statement returning no rows
+	RowsAffected() int64
+	// Fetch returns up to n rows and whether more follow
+	Fetch(n int) ([][]interface{}, bool, error)
+	// Close releases the session held by the cursor
+	Close() error
+}
+
+// Queryer runs statements, it is implemented by *sql.DB and *sql.Conn
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// countsRows reports whether the last statement of the query changes rows
+// and returns their count only, so it is executed to read the count. Every
+// other statement is queried, the columns it returns telling whether it has
+// rows.
+func countsRows(query string, dialect SQLDialect) bool {
+	statements := splitStatements(query, dialect)
+	if len(statements) == 0 {
+		return false
+	}
+	last := statements[len(statements)-1]
+	if _, returning := last.has("RETURNING"); returning {
+		return false
+	}
+
+	i := last.keywordIndex()
+	if i < len(last.tokens) && last.tokens[i] == "WITH" {
+		// The statement the common table expressions are for
+		i = last.indexTop(i, "SELECT", "VALUES", "TABLE", "INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE")
+	}
+	return i >= 0 && i < len(last.tokens) && countedStatements[last.tokens[i]]
+}
+
+// PageableSelect returns the text of a query made of a single SELECT, which
+// can be wrapped in another one reading a page of its rows. A SELECT INTO
+// creates a table and is not one.
+func PageableSelect(query string, dialect SQLDialect) (string, bool) {
+	statements := splitStatements(query, dialect)
+	if len(statements) != 1 {
+		return "", false
+	}
+
+	statement := statements[0]
+	if _, into := statement.has("INTO"); into {
+		return "", false
+	}
+	switch statement.keyword() {
+	case "SELECT", "VALUES":
+		return statement.text, true
+	case "WITH":
+		if _, writes := statement.has("INSERT", "UPDATE", "DELETE"); !writes {
+			return statement.text, true
+		}
+	}
+	return "", false
+}
+
+// OpenCursor runs the query with the values bound to its placeholders and
+// returns a cursor over its rows. Queries that return no rows are done at
+// once and release is called right away, otherwise it is called once the
+// rows are read or the cursor is closed.
+func OpenCursor(ctx context.Context, q Queryer, query string, dialect SQLDialect, release func(), args ...interface{}) (QueryCursor, error) {
+	if countsRows(query, dialect) {
+		defer release()
+
+		result, err := q.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute query: %w", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		return NewStaticCursor(nil, nil, rowsAffected), nil
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	// Statements returning no rows, as DDL, have no columns
+	if columns, err := rows.Columns(); err == nil && len(columns) == 0 {
+		defer release()
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to execute query: %w", err)
+		}
+		return NewStaticCursor(nil, nil, 0), nil
+	}
+	return NewRowsCursor(rows, release)
+}
+
+// NewRowsCursor returns a cursor reading rows, release is called once they
+// are read or the cursor is closed
+func NewRowsCursor(rows *sql.Rows, release func()) (QueryCursor, error) {
+	columns, err := readColumnTypes(rows)
+	if err != nil {
+		rows.Close()
+		release()
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	return &rowsCursor{rows: rows, columns: columns, release: release}, nil
+}
+
+// rowsCursor reads rows from the session running the query, one row ahead
+// so it can tell whether more follow
+type rowsCursor struct {
+	rows    *sql.Rows
+	columns []ColumnType
+	release func()
+	// next is the row read ahead, if any
+	next   []interface{}
+	closed bool
+}
+
+func (c *rowsCursor) Columns() []ColumnType {
+	return c.columns
+}
+
+func (c *rowsCursor) RowsAffected() int64 {
+	return 0
+}
+
+func (c *rowsCursor) Fetch(n int) ([][]interface{}, bool, error) {
+	var page [][]interface{}
+	if c.next != nil {
+		page = append(page, c.next)
+		c.next = nil
+	}
+
+	for !c.closed && len(page) <= n {
+		row, err := c.scan()
+		if err != nil {
+			c.Close()
+			return nil, false, err
+		}
+		if row == nil {
+			break
+		}
+		page = append(page, row)
+	}
+
+	if len(page) > n {
+		c.next = page[n]
+		return page[:n], true, nil
+	}
+	return page, false, nil
+}
+
+// scan reads the next row, nil once the rows are exhausted, in which case
+// the session is released
+func (c *rowsCursor) scan() ([]interface{}, error) {
+	if !c.rows.Next() {
+		if err := c.rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating rows: %w", err)
+		}
+		return nil, c.Close()
+	}
+
+	values := make([]interface{}, len(c.columns))
+	valuePtrs := make([]interface{}, len(c.columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+	if err := c.rows.Scan(valuePtrs...); err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	for i, val := range values {
+		values[i] = EncodeValue(c.columns[i].DatabaseType, val)
+	}
+	return values, nil
+}
+
+func (c *rowsCursor) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	err := c.rows.Close()
+	c.release()
+	return err
+}
+
+// staticCursor is a result already held in memory
+type staticCursor struct {
+	columns      []ColumnType
+	rows         [][]interface{}
+	rowsAffected int64
+}
+
+// NewStaticCursor returns a cursor over rows already read
+func NewStaticCursor(columns []ColumnType, rows [][]interface{}, rowsAffected int64) QueryCursor {
+	if columns == nil {
+		columns = []ColumnType{}
+	}
+	return &staticCursor{columns: columns, rows: rows, rowsAffected: rowsAffected}
+}
+
+func (c *staticCursor) Columns() []ColumnType {
+	return c.columns
+}
+
+func (c *staticCursor) RowsAffected() int64 {
+	return c.rowsAffected
+}
+
+func (c *staticCursor) Fetch(n int) ([][]interface{}, bool, error) {
+	if n > len(c.rows) {
+		n = len(c.rows)
+	}
+	page := c.rows[:n]
+	c.rows = c.rows[n:]
+	return page, len(c.rows) > 0, nil
+}
+
+func (c *staticCursor) Close() error {
+	c.rows = nil
+	return nil
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// HistoryEntry is a query that ran through a connection. The SQL text is
+// kept as written but for the passwords it sets, which are redacted; the
+// values bound to its placeholders are not kept.
+type HistoryEntry struct {
+	id           string
+	connectionID string
+	database     string
+	query        string
+	startedAt    time.Time
+	duration     time.Duration
+	// rowCount sums the rows the statements returned or changed
+	rowCount int64
+	// err is the error of the query, empty when every statement succeeded
+	err    string
+	dryRun bool
+}
+
+func NewHistoryEntry(
+	id string,
+	connectionID string,
+	database string,
+	query string,
+	startedAt time.Time,
+	duration time.Duration,
+	rowCount int64,
+	err string,
+	dryRun bool,
+) *HistoryEntry {
+	return &HistoryEntry{
+		id:           id,
+		connectionID: connectionID,
+		database:     database,
+		query:        query,
+		startedAt:    startedAt,
+		duration:     duration,
+		rowCount:     rowCount,
+		err:          err,
+		dryRun:       dryRun,
+	}
+}
+
+func (e *HistoryEntry) ID() string {
+	return e.id
+}
+
+func (e *HistoryEntry) ConnectionID() string {
+	return e.connectionID
+}
+
+func (e *HistoryEntry) Database() string {
+	return e.database
+}
+
+func (e *HistoryEntry) Query() string {
+	return e.query
+}
+
+func (e *HistoryEntry) StartedAt() time.Time {
+	return e.startedAt
+}
+
+func (e *HistoryEntry) Duration() time.Duration {
+	return e.duration
+}
+
+func (e *HistoryEntry) RowCount() int64 {
+	return e.rowCount
+}
+
+// Error returns the error of the query, empty when it succeeded
+func (e *HistoryEntry) Error() string {
+	return e.err
+}
+
+// DryRun reports whether the query ran as a dry run, its changes rolled back
+func (e *HistoryEntry) DryRun() bool {
+	return e.dryRun
+}
+
+// HistoryFilter selects entries of the query history. Empty fields match
+// every entry.
+type HistoryFilter struct {
+	// Text is searched in the SQL text, ignoring case
+	Text         string
+	ConnectionID string
+	// From and To bound the start time of the entries, To excluded
+	From time.Time
+	To   time.Time
+	// Limit caps the entries returned, newest first
+	Limit int
+}
+
+// Matches reports whether the entry passes the filter
+func (f HistoryFilter) Matches(entry *HistoryEntry) bool {
+	switch {
+	case f.ConnectionID != "" && entry.connectionID != f.ConnectionID:
+		return false
+	case !f.From.IsZero() && entry.startedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !entry.startedAt.Before(f.To):
+		return false
+	case f.Text != "" && !strings.Contains(strings.ToLower(entry.query), strings.ToLower(f.Text)):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// QueryHistoryRepo stores the queries that ran, oldest first
+type QueryHistoryRepo interface {
+	NextID() string
+
+	// Append records an entry at the end of the history, dropping the oldest
+	// entries once the history outgrows the size it is capped at, if any
+	Append(entry *HistoryEntry) error
+
+	// Search returns the entries passing the filter, newest first
+	Search(filter HistoryFilter) ([]*HistoryEntry, error)
+
+	// FindByID returns the entry, nil when there is none
+	FindByID(id string) (*HistoryEntry, error)
+
+	// Prune removes the entries started before olderThan, when not zero, and
+	// the oldest entries until the history takes at most maxBytes, when
+	// positive. It returns how many entries were removed.
+	Prune(olderThan time.Time, maxBytes int64) (int, error)
+}
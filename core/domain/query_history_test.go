@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"seagle/core/domain"
+)
+
+func TestHistoryFilterMatches(t *testing.T) {
+	startedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	entry := domain.NewHistoryEntry("1", "shop-db", "shop", "SELECT * FROM Orders", startedAt, time.Second, 3, "", false)
+
+	tests := []struct {
+		name   string
+		filter domain.HistoryFilter
+		want   bool
+	}{
+		{name: "empty", filter: domain.HistoryFilter{}, want: true},
+		{name: "text ignoring case", filter: domain.HistoryFilter{Text: "from orders"}, want: true},
+		{name: "other text", filter: domain.HistoryFilter{Text: "customers"}},
+		{name: "connection", filter: domain.HistoryFilter{ConnectionID: "shop-db"}, want: true},
+		{name: "other connection", filter: domain.HistoryFilter{ConnectionID: "crm-db"}},
+		{name: "from, included", filter: domain.HistoryFilter{From: startedAt}, want: true},
+		{name: "from later", filter: domain.HistoryFilter{From: startedAt.Add(time.Second)}},
+		{name: "to, excluded", filter: domain.HistoryFilter{To: startedAt}},
+		{name: "to later", filter: domain.HistoryFilter{To: startedAt.Add(time.Second)}, want: true},
+		{name: "every field", filter: domain.HistoryFilter{Text: "orders", ConnectionID: "shop-db", From: startedAt.Add(-time.Hour), To: startedAt.Add(time.Hour)}, want: true},
+		{name: "one field off", filter: domain.HistoryFilter{Text: "orders", ConnectionID: "crm-db", From: startedAt.Add(-time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(entry); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
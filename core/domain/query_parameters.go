@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Styles of query placeholders
+const (
+	// NamedParameter is written :name
+	NamedParameter = "named"
+	// NumberedParameter is written $1, $2...
+	NumberedParameter = "numbered"
+	// PositionalParameter is written ? and numbered by its position in the
+	// script
+	PositionalParameter = "positional"
+)
+
+// Types of the values given to parameters
+const (
+	TextParameter      = "text"
+	IntegerParameter   = "integer"
+	NumberParameter    = "number"
+	BooleanParameter   = "boolean"
+	DateParameter      = "date"
+	TimestampParameter = "timestamp"
+	NullParameter      = "null"
+)
+
+// timestampLayouts are the layouts accepted for timestamp values, the
+// second one is what datetime inputs send
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04", "2006-01-02 15:04:05"}
+
+// QueryParameter is a placeholder of a query. The values bound to it are
+// looked up by name, so a name used twice takes the same value.
+type QueryParameter struct {
+	// Name is the name of a :name placeholder, or the number of $1 and ?
+	// placeholders
+	Name  string
+	Style string
+}
+
+// Placeholder returns the parameter as written in the query
+func (p QueryParameter) Placeholder() string {
+	switch p.Style {
+	case NamedParameter:
+		return ":" + p.Name
+	case NumberedParameter:
+		return "$" + p.Name
+	}
+	return "?"
+}
+
+// ScriptParameter is a placeholder of a statement of a script
+type ScriptParameter struct {
+	QueryParameter
+	// Start and End are the byte range of the placeholder in the statement
+	Start, End int
+}
+
+// ParameterValue is the value given to a parameter, as text of its type
+type ParameterValue struct {
+	Name string
+	// Type is one of the parameter types, text when empty
+	Type  string
+	Value string
+}
+
+// ScriptParameters returns the parameters of the statements, each once and
+// in the order they first appear. Placeholders of different styles cannot
+// be mixed, they would not tell which value goes where.
+func ScriptParameters(statements []ScriptStatement) ([]QueryParameter, error) {
+	var parameters []QueryParameter
+	seen := make(map[string]bool)
+	for _, statement := range statements {
+		for _, p := range statement.Parameters {
+			if len(parameters) > 0 && parameters[0].Style != p.Style {
+				return nil, fmt.Errorf("placeholders %s and %s cannot be mixed in the same query", parameters[0].Placeholder(), p.Placeholder())
+			}
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				parameters = append(parameters, p.QueryParameter)
+			}
+		}
+	}
+	return parameters, nil
+}
+
+// BindValues converts the values given to parameters to their type, by
+// parameter name
+func BindValues(values []ParameterValue) (map[string]interface{}, error) {
+	bound := make(map[string]interface{}, len(values))
+	for _, v := range values {
+		value, err := v.bind()
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %s: %w", v.Name, err)
+		}
+		bound[v.Name] = value
+	}
+	return bound, nil
+}
+
+func (v ParameterValue) bind() (interface{}, error) {
+	switch v.Type {
+	case "", TextParameter:
+		return v.Value, nil
+	case IntegerParameter:
+		return strconv.ParseInt(strings.TrimSpace(v.Value), 10, 64)
+	case NumberParameter:
+		// Numbers are sent as text so decimals keep their precision, the
+		// database converts them
+		text := strings.TrimSpace(v.Value)
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, err
+		}
+		return text, nil
+	case BooleanParameter:
+		return strconv.ParseBool(strings.TrimSpace(v.Value))
+	case DateParameter:
+		return time.Parse(time.DateOnly, strings.TrimSpace(v.Value))
+	case TimestampParameter:
+		var err error
+		for _, layout := range timestampLayouts {
+			var t time.Time
+			if t, err = time.Parse(layout, strings.TrimSpace(v.Value)); err == nil {
+				return t, nil
+			}
+		}
+		return nil, err
+	case NullParameter:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown type %s", v.Type)
+}
+
+// BindStatement rewrites the placeholders of the statement into the ones the
+// driver binds, $1, $2... with NumberedPlaceholders and ? otherwise, and
+// returns the values to bind in their order. Values are never written into
+// the statement.
+func BindStatement(statement ScriptStatement, dialect SQLDialect, values map[string]interface{}) (string, []interface{}, error) {
+	if len(statement.Parameters) == 0 {
+		return statement.Text, nil, nil
+	}
+
+	var text strings.Builder
+	var args []interface{}
+	// numbers are the driver placeholders given to each name
+	numbers := make(map[string]int)
+	last := 0
+	for _, p := range statement.Parameters {
+		value, ok := values[p.Name]
+		if !ok {
+			return "", nil, fmt.Errorf("no value given for parameter %s", p.Placeholder())
+		}
+
+		text.WriteString(statement.Text[last:p.Start])
+		if dialect.NumberedPlaceholders {
+			number, ok := numbers[p.Name]
+			if !ok {
+				args = append(args, value)
+				number = len(args)
+				numbers[p.Name] = number
+			}
+			text.WriteString("$" + strconv.Itoa(number))
+		} else {
+			args = append(args, value)
+			text.WriteString("?")
+		}
+		last = p.End
+	}
+	text.WriteString(statement.Text[last:])
+
+	return text.String(), args, nil
+}
@@ -0,0 +1,177 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestBindStatement(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		dialect  domain.SQLDialect
+		values   map[string]interface{}
+		wantText string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "no placeholders",
+			script:   "SELECT 1",
+			dialect:  postgresDialect,
+			wantText: "SELECT 1",
+		},
+		{
+			name:     "named to numbered",
+			script:   "SELECT * FROM t WHERE a = :a AND b = :b",
+			dialect:  postgresDialect,
+			values:   map[string]interface{}{"a": int64(1), "b": "x"},
+			wantText: "SELECT * FROM t WHERE a = $1 AND b = $2",
+			wantArgs: []interface{}{int64(1), "x"},
+		},
+		{
+			name:     "repeated name bound once when numbered",
+			script:   "SELECT :a, :b, :a",
+			dialect:  postgresDialect,
+			values:   map[string]interface{}{"a": int64(1), "b": int64(2)},
+			wantText: "SELECT $1, $2, $1",
+			wantArgs: []interface{}{int64(1), int64(2)},
+		},
+		{
+			name:     "numbered renumbered in order of use",
+			script:   "SELECT $2, $1",
+			dialect:  postgresDialect,
+			values:   map[string]interface{}{"1": "one", "2": "two"},
+			wantText: "SELECT $1, $2",
+			wantArgs: []interface{}{"two", "one"},
+		},
+		{
+			name:     "named to question marks",
+			script:   "SELECT :a, :b, :a",
+			dialect:  mysqlDialect,
+			values:   map[string]interface{}{"a": int64(1), "b": int64(2)},
+			wantText: "SELECT ?, ?, ?",
+			wantArgs: []interface{}{int64(1), int64(2), int64(1)},
+		},
+		{
+			name:     "question marks kept",
+			script:   "UPDATE t SET a = ? WHERE b = ?",
+			dialect:  sqliteDialect,
+			values:   map[string]interface{}{"1": "x", "2": nil},
+			wantText: "UPDATE t SET a = ? WHERE b = ?",
+			wantArgs: []interface{}{"x", nil},
+		},
+		{
+			name:     "cast after a placeholder",
+			script:   "SELECT :a::int",
+			dialect:  postgresDialect,
+			values:   map[string]interface{}{"a": "1"},
+			wantText: "SELECT $1::int",
+			wantArgs: []interface{}{"1"},
+		},
+		{
+			name:     "values never written into the text",
+			script:   "SELECT :a, ':a'",
+			dialect:  postgresDialect,
+			values:   map[string]interface{}{"a": "'; DROP TABLE t; --"},
+			wantText: "SELECT $1, ':a'",
+			wantArgs: []interface{}{"'; DROP TABLE t; --"},
+		},
+		{
+			name:     "PREPARE ... AS left to the server",
+			script:   "PREPARE p(int) AS SELECT $1",
+			dialect:  postgresDialect,
+			wantText: "PREPARE p(int) AS SELECT $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := domain.SplitScript(tt.script, tt.dialect)
+			if len(statements) != 1 {
+				t.Fatalf("SplitScript(%q) returned %d statements, want 1", tt.script, len(statements))
+			}
+
+			text, args, err := domain.BindStatement(statements[0], tt.dialect, tt.values)
+			if err != nil {
+				t.Fatalf("BindStatement(%q) error: %v", tt.script, err)
+			}
+			if text != tt.wantText {
+				t.Errorf("BindStatement(%q) text = %q, want %q", tt.script, text, tt.wantText)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("BindStatement(%q) args = %#v, want %#v", tt.script, args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBindStatementMissingValue(t *testing.T) {
+	statements := domain.SplitScript("SELECT :a, :b", postgresDialect)
+	if _, _, err := domain.BindStatement(statements[0], postgresDialect, map[string]interface{}{"a": 1}); err == nil {
+		t.Error("BindStatement() succeeded without a value for :b, want an error")
+	}
+}
+
+func TestScriptParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    []domain.QueryParameter
+		wantErr bool
+	}{
+		{
+			name:    "each name once in order",
+			script:  "SELECT :b, :a; SELECT :a, :c",
+			dialect: postgresDialect,
+			want: []domain.QueryParameter{
+				{Name: "b", Style: domain.NamedParameter},
+				{Name: "a", Style: domain.NamedParameter},
+				{Name: "c", Style: domain.NamedParameter},
+			},
+		},
+		{
+			name:    "question marks across statements",
+			script:  "SELECT ?; SELECT ?",
+			dialect: mysqlDialect,
+			want: []domain.QueryParameter{
+				{Name: "1", Style: domain.PositionalParameter},
+				{Name: "2", Style: domain.PositionalParameter},
+			},
+		},
+		{
+			name:    "array slices",
+			script:  "SELECT arr[:n] FROM t WHERE id = :id",
+			dialect: postgresDialect,
+			want: []domain.QueryParameter{
+				{Name: "id", Style: domain.NamedParameter},
+			},
+		},
+		{
+			name:    "mixed styles",
+			script:  "SELECT :a, $1",
+			dialect: postgresDialect,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ScriptParameters(domain.SplitScript(tt.script, tt.dialect))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ScriptParameters(%q) succeeded, want an error", tt.script)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ScriptParameters(%q) error: %v", tt.script, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScriptParameters(%q) = %+v, want %+v", tt.script, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Kinds of plan hotspots
+const (
+	// SelfTimeHotspot is a node taking a large share of the execution time
+	SelfTimeHotspot = "self-time"
+	// RowEstimateHotspot is a node whose row estimate was far off
+	RowEstimateHotspot = "row-estimate"
+	// FullScanHotspot is a node reading a whole big table
+	FullScanHotspot = "full-scan"
+)
+
+const (
+	// maxHotspots bounds the hotspots of each kind
+	maxHotspots = 3
+	// minSelfTimeShare is the share of the execution time a node takes on
+	// its own to be a hotspot
+	minSelfTimeShare = 0.1
+	// minEstimateMiss is how many times the rows may be off their estimate
+	// before the node is a hotspot
+	minEstimateMiss = 10
+	// bigTableRows is the rows a full scan reads to be a hotspot
+	bigTableRows = 10000
+)
+
+// ExplainOptions tell how a statement is explained
+type ExplainOptions struct {
+	// Analyze runs the statement to measure its rows and time
+	Analyze bool
+	// Buffers reports the blocks read and written, PostgreSQL only
+	Buffers bool
+}
+
+// QueryExplainer is implemented by database services that can tell how they
+// run a statement. With Analyze the statement runs on the session, the
+// caller rolls back what it changes.
+type QueryExplainer interface {
+	ExplainQuery(ctx context.Context, session QuerySession, statement string, args []interface{}, options ExplainOptions) (*QueryPlan, error)
+}
+
+// QueryPlan is how the database runs a statement, in a tree the same for
+// every vendor
+type QueryPlan struct {
+	Root *PlanNode
+	// Analyzed is set when the statement ran and the actual rows and times
+	// are known
+	Analyzed bool
+	// PlanningTime and ExecutionTime are in milliseconds, nil when the
+	// database does not tell
+	PlanningTime  *float64
+	ExecutionTime *float64
+	Hotspots      []PlanHotspot
+	// Raw is the plan as the database printed it
+	Raw string
+}
+
+// PlanNode is a step of a plan. Rows are per loop, times are in
+// milliseconds over all loops. Values the database does not tell are nil.
+type PlanNode struct {
+	// ID numbers the nodes of the plan in depth-first order
+	ID       int
+	NodeType string
+	Relation string
+	Index    string
+	// Detail holds the conditions and keys of the node
+	Detail string
+	// FullScan is set for nodes reading a whole table
+	FullScan      bool
+	EstimatedRows *float64
+	ActualRows    *float64
+	Loops         *float64
+	// RowsScanned are the rows read from the relation over all loops
+	RowsScanned *float64
+	StartupCost *float64
+	TotalCost   *float64
+	// ActualTime includes the time of the children, SelfTime does not
+	ActualTime *float64
+	SelfTime   *float64
+	Buffers    *PlanBuffers
+	Children   []*PlanNode
+}
+
+// PlanBuffers counts the blocks a node hit in cache, read, dirtied and
+// wrote, including its children
+type PlanBuffers struct {
+	SharedHit     int64
+	SharedRead    int64
+	SharedDirtied int64
+	SharedWritten int64
+	TempRead      int64
+	TempWritten   int64
+}
+
+// PlanHotspot is a node of a plan worth looking at first
+type PlanHotspot struct {
+	Kind   string
+	NodeID int
+	// Value is the self time in milliseconds, the factor the rows were off
+	// their estimate, or the rows a full scan read
+	Value   float64
+	Message string
+}
+
+// label names the node for messages, e.g. Seq Scan on orders
+func (n *PlanNode) label() string {
+	if n.Relation == "" {
+		return n.NodeType
+	}
+	return n.NodeType + " on " + n.Relation
+}
+
+// nodes returns the node and its descendants in depth-first order
+func (n *PlanNode) nodes() []*PlanNode {
+	nodes := []*PlanNode{n}
+	for _, child := range n.Children {
+		nodes = append(nodes, child.nodes()...)
+	}
+	return nodes
+}
+
+// FinishPlan numbers the nodes of a parsed plan, works out their self time
+// and finds its hotspots
+func FinishPlan(plan *QueryPlan) *QueryPlan {
+	if plan.Root == nil {
+		return plan
+	}
+
+	nodes := plan.Root.nodes()
+	for i, node := range nodes {
+		node.ID = i
+		if node.ActualTime == nil {
+			continue
+		}
+		self := *node.ActualTime
+		for _, child := range node.Children {
+			if child.ActualTime != nil {
+				self -= *child.ActualTime
+			}
+		}
+		self = math.Max(self, 0)
+		node.SelfTime = &self
+	}
+
+	plan.Hotspots = append(plan.Hotspots, selfTimeHotspots(plan, nodes)...)
+	plan.Hotspots = append(plan.Hotspots, estimateHotspots(nodes)...)
+	plan.Hotspots = append(plan.Hotspots, fullScanHotspots(nodes)...)
+	return plan
+}
+
+// selfTimeHotspots returns the nodes taking the largest share of the
+// execution time on their own
+func selfTimeHotspots(plan *QueryPlan, nodes []*PlanNode) []PlanHotspot {
+	total := plan.ExecutionTime
+	if total == nil {
+		total = plan.Root.ActualTime
+	}
+	if total == nil || *total <= 0 {
+		return nil
+	}
+
+	var hotspots []PlanHotspot
+	for _, node := range nodes {
+		if node.SelfTime == nil || *node.SelfTime < *total*minSelfTimeShare {
+			continue
+		}
+		hotspots = append(hotspots, PlanHotspot{
+			Kind:    SelfTimeHotspot,
+			NodeID:  node.ID,
+			Value:   *node.SelfTime,
+			Message: fmt.Sprintf("%s takes %.3f ms, %.0f%% of the execution time", node.label(), *node.SelfTime, *node.SelfTime / *total * 100),
+		})
+	}
+	return topHotspots(hotspots)
+}
+
+// estimateHotspots returns the nodes whose rows were furthest off their
+// estimate, which misleads the planner into a bad plan
+func estimateHotspots(nodes []*PlanNode) []PlanHotspot {
+	var hotspots []PlanHotspot
+	for _, node := range nodes {
+		// Nodes that never ran tell nothing about the estimate
+		if node.ActualRows == nil || node.EstimatedRows == nil || (node.Loops != nil && *node.Loops == 0) {
+			continue
+		}
+		actual, estimated := *node.ActualRows, *node.EstimatedRows
+		miss := math.Max(actual, estimated) / math.Max(math.Min(actual, estimated), 1)
+		if miss < minEstimateMiss {
+			continue
+		}
+
+		direction := "more"
+		if actual < estimated {
+			direction = "fewer"
+		}
+		hotspots = append(hotspots, PlanHotspot{
+			Kind:    RowEstimateHotspot,
+			NodeID:  node.ID,
+			Value:   miss,
+			Message: fmt.Sprintf("%s returned %.0f rows where %.0f were estimated, %.0fx %s", node.label(), actual, estimated, miss, direction),
+		})
+	}
+	return topHotspots(hotspots)
+}
+
+// fullScanHotspots returns the full scans of big tables, which an index on
+// the filtered columns may avoid
+func fullScanHotspots(nodes []*PlanNode) []PlanHotspot {
+	var hotspots []PlanHotspot
+	for _, node := range nodes {
+		if !node.FullScan {
+			continue
+		}
+		rows := node.RowsScanned
+		if rows == nil {
+			rows = node.EstimatedRows
+		}
+		if rows == nil || *rows < bigTableRows {
+			continue
+		}
+		hotspots = append(hotspots, PlanHotspot{
+			Kind:    FullScanHotspot,
+			NodeID:  node.ID,
+			Value:   *rows,
+			Message: fmt.Sprintf("%s reads %.0f rows, an index on the filtered columns may avoid it", node.label(), *rows),
+		})
+	}
+	return topHotspots(hotspots)
+}
+
+// topHotspots keeps the hotspots with the largest values
+func topHotspots(hotspots []PlanHotspot) []PlanHotspot {
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		return hotspots[i].Value > hotspots[j].Value
+	})
+	if len(hotspots) > maxHotspots {
+		hotspots = hotspots[:maxHotspots]
+	}
+	return hotspots
+}
+
+// ReadPlanRows runs an EXPLAIN statement on the session and returns its rows
+func ReadPlanRows(ctx context.Context, session QuerySession, statement string, args []interface{}) ([][]interface{}, error) {
+	cursor, err := session.Query(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain statement: %w", err)
+	}
+	defer cursor.Close()
+
+	rows, _, err := cursor.Fetch(math.MaxInt32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	return rows, nil
+}
+
+// ReadPlanText runs an EXPLAIN statement on the session and returns the
+// plan it prints, the first column of its rows one per line
+func ReadPlanText(ctx context.Context, session QuerySession, statement string, args []interface{}) (string, error) {
+	rows, err := ReadPlanRows(ctx, session, statement, args)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		switch v := row[0].(type) {
+		case TaggedValue:
+			// JSON plans come parsed
+			raw, err := json.MarshalIndent(v.Value, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to read plan: %w", err)
+			}
+			lines = append(lines, string(raw))
+		case string:
+			lines = append(lines, v)
+		default:
+			lines = append(lines, fmt.Sprint(v))
+		}
+	}
+	if len(lines) == 0 {
+		return "", fmt.Errorf("empty plan")
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,210 @@
+package domain_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func float(f float64) *float64 {
+	return &f
+}
+
+// hotspot is what a test expects of a hotspot
+type hotspot struct {
+	kind   string
+	nodeID int
+}
+
+func hotspotsOf(plan *domain.QueryPlan) []hotspot {
+	var hotspots []hotspot
+	for _, h := range plan.Hotspots {
+		hotspots = append(hotspots, hotspot{h.Kind, h.NodeID})
+	}
+	return hotspots
+}
+
+func TestFinishPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     *domain.QueryPlan
+		selfTime map[int]float64
+		hotspots []hotspot
+	}{
+		{
+			name: "self time leaves out the children",
+			plan: &domain.QueryPlan{
+				Root: &domain.PlanNode{NodeType: "Hash Join", ActualTime: float(10), Children: []*domain.PlanNode{
+					{NodeType: "Seq Scan", ActualTime: float(6)},
+					{NodeType: "Hash", ActualTime: float(1), Children: []*domain.PlanNode{
+						{NodeType: "Seq Scan", ActualTime: float(0.5)},
+					}},
+				}},
+			},
+			selfTime: map[int]float64{0: 3, 1: 6, 2: 0.5, 3: 0.5},
+			hotspots: []hotspot{{domain.SelfTimeHotspot, 1}, {domain.SelfTimeHotspot, 0}},
+		},
+		{
+			name: "children measured over more loops never make the self time negative",
+			plan: &domain.QueryPlan{
+				Root: &domain.PlanNode{NodeType: "Nested Loop", ActualTime: float(2), Children: []*domain.PlanNode{
+					{NodeType: "Index Scan", ActualTime: float(3)},
+				}},
+			},
+			selfTime: map[int]float64{0: 0, 1: 3},
+			hotspots: []hotspot{{domain.SelfTimeHotspot, 1}},
+		},
+		{
+			name: "shares are of the execution time when it is known",
+			plan: &domain.QueryPlan{
+				ExecutionTime: float(100),
+				Root:          &domain.PlanNode{NodeType: "Seq Scan", ActualTime: float(5)},
+			},
+			selfTime: map[int]float64{0: 5},
+		},
+		{
+			name: "rows off their estimate either way",
+			plan: &domain.QueryPlan{
+				Root: &domain.PlanNode{NodeType: "Nested Loop", EstimatedRows: float(10), ActualRows: float(5000), Children: []*domain.PlanNode{
+					{NodeType: "Index Scan", EstimatedRows: float(800), ActualRows: float(2)},
+					{NodeType: "Index Scan", EstimatedRows: float(50), ActualRows: float(90)},
+					{NodeType: "Seq Scan", EstimatedRows: float(900), ActualRows: float(0), Loops: float(0)},
+				}},
+			},
+			hotspots: []hotspot{{domain.RowEstimateHotspot, 0}, {domain.RowEstimateHotspot, 1}},
+		},
+		{
+			name: "full scans of big tables, by rows read when measured",
+			plan: &domain.QueryPlan{
+				Root: &domain.PlanNode{NodeType: "Append", Children: []*domain.PlanNode{
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(50000)},
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(100), RowsScanned: float(20000)},
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(20000), RowsScanned: float(100)},
+					{NodeType: "Index Scan", EstimatedRows: float(90000)},
+				}},
+			},
+			hotspots: []hotspot{{domain.FullScanHotspot, 1}, {domain.FullScanHotspot, 2}},
+		},
+		{
+			name: "at most three hotspots of a kind",
+			plan: &domain.QueryPlan{
+				Root: &domain.PlanNode{NodeType: "Append", Children: []*domain.PlanNode{
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(10000)},
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(40000)},
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(20000)},
+					{NodeType: "Seq Scan", FullScan: true, EstimatedRows: float(30000)},
+				}},
+			},
+			hotspots: []hotspot{{domain.FullScanHotspot, 2}, {domain.FullScanHotspot, 4}, {domain.FullScanHotspot, 3}},
+		},
+		{
+			name: "no root",
+			plan: &domain.QueryPlan{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := domain.FinishPlan(tt.plan)
+
+			if plan.Root != nil {
+				var walk func(n *domain.PlanNode, id *int)
+				walk = func(n *domain.PlanNode, id *int) {
+					if n.ID != *id {
+						t.Errorf("%s numbered %d, want %d", n.NodeType, n.ID, *id)
+					}
+					*id++
+					if want, ok := tt.selfTime[n.ID]; ok {
+						if n.SelfTime == nil || math.Abs(*n.SelfTime-want) > 1e-9 {
+							t.Errorf("self time of node %d = %v, want %v", n.ID, n.SelfTime, want)
+						}
+					} else if n.SelfTime != nil && n.ActualTime == nil {
+						t.Errorf("node %d has a self time without an actual time", n.ID)
+					}
+					for _, child := range n.Children {
+						walk(child, id)
+					}
+				}
+				walk(plan.Root, new(int))
+			}
+
+			if got := hotspotsOf(plan); !reflect.DeepEqual(got, tt.hotspots) {
+				t.Errorf("hotspots = %v, want %v", got, tt.hotspots)
+			}
+		})
+	}
+}
+
+func TestFinishPlanMessages(t *testing.T) {
+	plan := domain.FinishPlan(&domain.QueryPlan{
+		ExecutionTime: float(20),
+		Root: &domain.PlanNode{NodeType: "Seq Scan", Relation: "orders", FullScan: true,
+			EstimatedRows: float(2000), ActualRows: float(100), Loops: float(1), RowsScanned: float(50000), ActualTime: float(10)},
+	})
+
+	want := []string{
+		"Seq Scan on orders takes 10.000 ms, 50% of the execution time",
+		"Seq Scan on orders returned 100 rows where 2000 were estimated, 20x fewer",
+		"Seq Scan on orders reads 50000 rows, an index on the filtered columns may avoid it",
+	}
+	var got []string
+	for _, h := range plan.Hotspots {
+		got = append(got, h.Message)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
+
+// planSession answers every statement with the given rows
+type planSession struct {
+	rows [][]interface{}
+}
+
+func (s *planSession) Query(ctx context.Context, statement string, args ...interface{}) (domain.QueryCursor, error) {
+	return domain.NewStaticCursor([]domain.ColumnType{{Name: "QUERY PLAN"}}, s.rows, 0), nil
+}
+
+func (s *planSession) Close() error {
+	return nil
+}
+
+func TestReadPlanText(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    [][]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "text lines",
+			rows: [][]interface{}{{"Seq Scan on t  (cost=0.00..35.50 rows=2550 width=4)"}, {"  Filter: (a > 1)"}},
+			want: "Seq Scan on t  (cost=0.00..35.50 rows=2550 width=4)\n  Filter: (a > 1)",
+		},
+		{
+			name: "parsed JSON",
+			rows: [][]interface{}{{domain.TaggedValue{Type: "json", Value: []interface{}{map[string]interface{}{"Plan": map[string]interface{}{"Node Type": "Result"}}}}}},
+			want: "[\n  {\n    \"Plan\": {\n      \"Node Type\": \"Result\"\n    }\n  }\n]",
+		},
+		{
+			name:    "no rows",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &planSession{rows: tt.rows}
+			got, err := domain.ReadPlanText(context.Background(), session, "EXPLAIN SELECT 1", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadPlanText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadPlanText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"time"
+)
+
+// QuerySession runs statements one after the other on the same database
+// session, so settings, temporary tables and transactions carry over
+type QuerySession interface {
+	// Query runs a statement with the values bound to its placeholders and
+	// returns a cursor over its rows. The cursor has to be closed before the
+	// next statement runs.
+	Query(ctx context.Context, statement string, args ...interface{}) (QueryCursor, error)
+	// Close gives the session back to the pool
+	Close() error
+}
+
+// connSession is a session on a connection taken out of the pool
+type connSession struct {
+	conn         *sql.Conn
+	dialect      SQLDialect
+	cancelServer func() error
+	release      func()
+}
+
+// NewConnSession returns a session running statements on conn. cancelServer
+// cancels the statement running on the server, it is registered with
+// OnCancel for every statement. release is called once the session is
+// closed, to give back the pool acquired from the SessionManager.
+func NewConnSession(conn *sql.Conn, dialect SQLDialect, cancelServer func() error, release func()) QuerySession {
+	return &connSession{conn: conn, dialect: dialect, cancelServer: cancelServer, release: release}
+}
+
+func (s *connSession) Query(ctx context.Context, statement string, args ...interface{}) (QueryCursor, error) {
+	unregister := OnCancel(ctx, s.cancelServer)
+	return OpenCursor(ctx, s.conn, statement, s.dialect, unregister, args...)
+}
+
+func (s *connSession) Close() error {
+	defer s.release()
+	return s.conn.Close()
+}
+
+// RunQuery runs the query on the session and reads all its rows
+func RunQuery(ctx context.Context, session QuerySession, query string) (*QueryResult, error) {
+	start := time.Now()
+
+	cursor, err := session.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	rows, _, err := cursor.Fetch(math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{
+		Columns:      ColumnNames(cursor.Columns()),
+		ColumnTypes:  cursor.Columns(),
+		Rows:         rows,
+		RowsAffected: cursor.RowsAffected(),
+		Duration:     time.Since(start).Milliseconds(),
+	}
+	if len(result.Columns) > 0 {
+		result.RowsAffected = int64(len(rows))
+	}
+	if result.Rows == nil {
+		result.Rows = [][]interface{}{}
+	}
+	return result, nil
+}
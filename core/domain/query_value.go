@@ -0,0 +1,371 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Types of the tagged values of query results
+const (
+	// BinaryValue holds bytes, base64 encoded
+	BinaryValue = "binary"
+	// DecimalValue holds an exact decimal number as written by the database
+	DecimalValue = "decimal"
+	// IntegerValue holds an integer too large for a JSON number, in decimal
+	IntegerValue = "integer"
+	// FloatValue holds NaN, Infinity or -Infinity
+	FloatValue = "float"
+	// TimestampValue holds a date and time, or a time of day, in RFC 3339
+	// with nanoseconds, with an offset only for the types with a time zone
+	TimestampValue = "timestamp"
+	// DateValue holds a date as YYYY-MM-DD
+	DateValue = "date"
+	// JSONValue holds a parsed JSON document, numbers kept as written
+	JSONValue = "json"
+	// ArrayValue holds the elements of a PostgreSQL array, arrays nest
+	ArrayValue = "array"
+)
+
+// maxExactInteger is the largest integer a JSON number carries exactly
+const maxExactInteger = 1 << 53
+
+// localDateTime formats the date and time of the types without a time zone,
+// as TIMESTAMP and DATETIME, which drivers read as UTC although they stand
+// for no zone at all
+const localDateTime = "2006-01-02T15:04:05.999999999"
+
+// timeLayouts format the types with a time zone, which keep their offset,
+// and the times of day, which drivers read on January 1st of year 0
+var timeLayouts = map[string]string{
+	"TIMESTAMPTZ": time.RFC3339Nano,
+	"TIME":        "15:04:05.999999999",
+	"TIMETZ":      "15:04:05.999999999Z07:00",
+}
+
+// textTimeLayouts read the dates and times MySQL sends as text, the driver
+// only parses them with parseTime. Values out of their range, as zero dates
+// or TIME intervals over a day, are kept as text.
+var textTimeLayouts = map[string]string{
+	"DATE":      time.DateOnly,
+	"DATETIME":  "2006-01-02 15:04:05.999999999",
+	"TIMESTAMP": "2006-01-02 15:04:05.999999999",
+	"TIME":      "15:04:05.999999999",
+}
+
+// ColumnType describes a column of a query result. The sizes are nil when
+// the driver does not know them.
+type ColumnType struct {
+	Name string
+	// DatabaseType is the type name reported by the driver, e.g. VARCHAR,
+	// NUMERIC, or _INT4 for an array of int4
+	DatabaseType string
+	Nullable     *bool
+	Length       *int64
+	Precision    *int64
+	Scale        *int64
+}
+
+// TaggedValue is a value of a query result that JSON cannot carry as is,
+// encoded without loss and tagged with its type
+type TaggedValue struct {
+	Type  string
+	Value interface{}
+}
+
+// ColumnNames returns the names of the columns
+func ColumnNames(columns []ColumnType) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// readColumnTypes returns the columns of the rows with what the driver
+// tells about their type
+func readColumnTypes(rows *sql.Rows) ([]ColumnType, error) {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]ColumnType, len(types))
+	for i, t := range types {
+		column := ColumnType{
+			Name:         t.Name(),
+			DatabaseType: strings.ToUpper(t.DatabaseTypeName()),
+		}
+		if nullable, ok := t.Nullable(); ok {
+			column.Nullable = &nullable
+		}
+		if length, ok := t.Length(); ok {
+			column.Length = &length
+		}
+		if precision, scale, ok := t.DecimalSize(); ok {
+			column.Precision, column.Scale = &precision, &scale
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+// EncodeValue turns a value scanned from a column of the database type into
+// one JSON carries without loss. Strings, booleans, floats and integers JSON
+// numbers hold exactly are kept, other values are tagged.
+func EncodeValue(databaseType string, value interface{}) interface{} {
+	// SQLite reports the declared type, sizes included, e.g. NUMERIC(10,2)
+	if i := strings.IndexByte(databaseType, '('); i >= 0 {
+		databaseType = strings.TrimSpace(databaseType[:i])
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return encodeBytes(databaseType, v)
+	case string:
+		if isJSONType(databaseType) {
+			return encodeJSON(v)
+		}
+		return v
+	case int64:
+		return encodeInteger(v)
+	case uint64:
+		if v > maxExactInteger {
+			return TaggedValue{Type: IntegerValue, Value: strconv.FormatUint(v, 10)}
+		}
+		return int64(v)
+	case float32:
+		// The shortest text of the float32 keeps 0.1 from turning into
+		// 0.10000000149011612
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		return encodeFloat(f)
+	case float64:
+		return encodeFloat(v)
+	case time.Time:
+		switch {
+		case databaseType == "DATE":
+			return TaggedValue{Type: DateValue, Value: v.Format(time.DateOnly)}
+		case timeLayouts[databaseType] != "":
+			return TaggedValue{Type: TimestampValue, Value: v.Format(timeLayouts[databaseType])}
+		}
+		return TaggedValue{Type: TimestampValue, Value: v.Format(localDateTime)}
+	default:
+		return v
+	}
+}
+
+// encodeBytes encodes the raw value of a column, which drivers return for
+// binary columns and for the types they leave to the application
+func encodeBytes(databaseType string, b []byte) interface{} {
+	switch {
+	case isBinaryType(databaseType):
+		return TaggedValue{Type: BinaryValue, Value: b}
+	case !utf8.Valid(b):
+		return TaggedValue{Type: BinaryValue, Value: b}
+	case strings.HasPrefix(databaseType, "_"):
+		elements, err := parseArray(string(b))
+		if err != nil {
+			return string(b)
+		}
+		return TaggedValue{Type: ArrayValue, Value: encodeElements(databaseType[1:], elements)}
+	}
+	return encodeText(databaseType, string(b))
+}
+
+// encodeText encodes a value the database sent as text
+func encodeText(databaseType, text string) interface{} {
+	switch {
+	case isJSONType(databaseType):
+		return encodeJSON(text)
+	case databaseType == "NUMERIC" || databaseType == "DECIMAL":
+		return TaggedValue{Type: DecimalValue, Value: text}
+	case databaseType == "BYTEA":
+		// bytea elements of arrays are in the hex format
+		b, err := hex.DecodeString(strings.TrimPrefix(text, `\x`))
+		if err != nil {
+			return text
+		}
+		return TaggedValue{Type: BinaryValue, Value: b}
+	case databaseType == "BOOL":
+		return text == "t"
+	case isIntegerType(databaseType):
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return encodeInteger(i)
+		}
+		if u, err := strconv.ParseUint(text, 10, 64); err == nil {
+			return EncodeValue(databaseType, u)
+		}
+	case databaseType == "FLOAT4" || databaseType == "FLOAT8":
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return encodeFloat(f)
+		}
+	case textTimeLayouts[databaseType] != "":
+		if t, err := time.Parse(textTimeLayouts[databaseType], text); err == nil {
+			return EncodeValue(databaseType, t)
+		}
+	}
+	return text
+}
+
+// encodeElements encodes the elements of a PostgreSQL array of the type
+func encodeElements(elementType string, elements []interface{}) []interface{} {
+	encoded := make([]interface{}, len(elements))
+	for i, element := range elements {
+		switch e := element.(type) {
+		case string:
+			encoded[i] = encodeText(elementType, e)
+		case []interface{}:
+			encoded[i] = encodeElements(elementType, e)
+		default:
+			encoded[i] = nil
+		}
+	}
+	return encoded
+}
+
+func encodeInteger(i int64) interface{} {
+	if i > maxExactInteger || i < -maxExactInteger {
+		return TaggedValue{Type: IntegerValue, Value: strconv.FormatInt(i, 10)}
+	}
+	return i
+}
+
+func encodeFloat(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return TaggedValue{Type: FloatValue, Value: "NaN"}
+	case math.IsInf(f, 1):
+		return TaggedValue{Type: FloatValue, Value: "Infinity"}
+	case math.IsInf(f, -1):
+		return TaggedValue{Type: FloatValue, Value: "-Infinity"}
+	}
+	return f
+}
+
+// encodeJSON parses a JSON document, keeping its numbers as written. Text
+// that is not valid JSON is kept as is.
+func encodeJSON(text string) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return text
+	}
+	return TaggedValue{Type: JSONValue, Value: document}
+}
+
+// parseArray parses the text of a PostgreSQL array such as
+// {1,NULL,"a,b",{2,3}}. Elements are strings, nil for NULL, and slices for
+// nested arrays.
+func parseArray(text string) ([]interface{}, error) {
+	// Arrays with other lower bounds than 1 start with their dimensions,
+	// e.g. [0:1]={1,2}
+	if strings.HasPrefix(text, "[") {
+		if i := strings.Index(text, "="); i >= 0 {
+			text = text[i+1:]
+		}
+	}
+
+	elements, rest, err := parseArrayLevel(text)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected %q after array", rest)
+	}
+	return elements, nil
+}
+
+// parseArrayLevel parses the array at the start of text and returns what
+// follows it
+func parseArrayLevel(text string) ([]interface{}, string, error) {
+	if !strings.HasPrefix(text, "{") {
+		return nil, "", fmt.Errorf("array must start with {")
+	}
+	text = text[1:]
+
+	elements := []interface{}{}
+	if strings.HasPrefix(text, "}") {
+		return elements, text[1:], nil
+	}
+
+	for {
+		switch {
+		case strings.HasPrefix(text, "{"):
+			nested, rest, err := parseArrayLevel(text)
+			if err != nil {
+				return nil, "", err
+			}
+			elements = append(elements, nested)
+			text = rest
+
+		case strings.HasPrefix(text, `"`):
+			var element bytes.Buffer
+			i := 1
+			for ; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' && i+1 < len(text) {
+					i++
+				}
+				element.WriteByte(text[i])
+			}
+			if i >= len(text) {
+				return nil, "", fmt.Errorf("unterminated quoted element")
+			}
+			elements = append(elements, element.String())
+			text = text[i+1:]
+
+		default:
+			end := strings.IndexAny(text, ",}")
+			if end < 0 {
+				return nil, "", fmt.Errorf("unterminated array")
+			}
+			if element := strings.TrimSpace(text[:end]); element == "NULL" {
+				elements = append(elements, nil)
+			} else {
+				elements = append(elements, element)
+			}
+			text = text[end:]
+		}
+
+		if text == "" {
+			return nil, "", fmt.Errorf("unterminated array")
+		}
+		if text[0] == '}' {
+			return elements, text[1:], nil
+		}
+		if text[0] != ',' {
+			return nil, "", fmt.Errorf("unexpected %q in array", text[0])
+		}
+		text = text[1:]
+	}
+}
+
+func isJSONType(databaseType string) bool {
+	return databaseType == "JSON" || databaseType == "JSONB"
+}
+
+func isBinaryType(databaseType string) bool {
+	switch databaseType {
+	case "BYTEA", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "GEOMETRY":
+		return true
+	}
+	return false
+}
+
+func isIntegerType(databaseType string) bool {
+	switch strings.TrimPrefix(databaseType, "UNSIGNED ") {
+	case "INT2", "INT4", "INT8", "OID", "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "YEAR":
+		return true
+	}
+	return false
+}
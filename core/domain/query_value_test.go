@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseArray(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []interface{}
+	}{
+		{name: "empty", text: "{}", want: []interface{}{}},
+		{name: "integers", text: "{1,2,3}", want: []interface{}{"1", "2", "3"}},
+		{name: "NULL", text: "{1,NULL,3}", want: []interface{}{"1", nil, "3"}},
+		{name: "quoted NULL is a string", text: `{"NULL"}`, want: []interface{}{"NULL"}},
+		{name: "quoted comma", text: `{"a,b",c}`, want: []interface{}{"a,b", "c"}},
+		{name: "escaped quote and backslash", text: `{"say \"hi\"","C:\\data"}`, want: []interface{}{`say "hi"`, `C:\data`}},
+		{name: "empty string", text: `{""}`, want: []interface{}{""}},
+		{name: "spaces around elements", text: "{ a , b }", want: []interface{}{"a", "b"}},
+		{name: "quoted spaces kept", text: `{" a "}`, want: []interface{}{" a "}},
+		{
+			name: "nested",
+			text: "{{1,2},{3,NULL}}",
+			want: []interface{}{
+				[]interface{}{"1", "2"},
+				[]interface{}{"3", nil},
+			},
+		},
+		{name: "nested empty", text: "{{},{}}", want: []interface{}{[]interface{}{}, []interface{}{}}},
+		{name: "lower bound", text: "[0:1]={7,8}", want: []interface{}{"7", "8"}},
+		{
+			name: "lower bounds of two dimensions",
+			text: "[0:1][1:1]={{7},{8}}",
+			want: []interface{}{[]interface{}{"7"}, []interface{}{"8"}},
+		},
+		{name: "unicode", text: `{"é,ü",日本}`, want: []interface{}{"é,ü", "日本"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseArray(tt.text)
+			if err != nil {
+				t.Fatalf("parseArray(%q) error: %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseArray(%q) = %#v, want %#v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArrayErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "no braces", text: "1,2"},
+		{name: "unterminated", text: "{1,2"},
+		{name: "unterminated quote", text: `{"a`},
+		{name: "unterminated nested", text: "{{1,2}"},
+		{name: "text after the array", text: "{1}x"},
+		{name: "text after a quoted element", text: `{"a"b}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := parseArray(tt.text); err == nil {
+				t.Errorf("parseArray(%q) = %#v, want an error", tt.text, got)
+			}
+		})
+	}
+}
+
+func TestEncodeValueTimes(t *testing.T) {
+	tests := []struct {
+		name         string
+		databaseType string
+		value        interface{}
+		want         interface{}
+	}{
+		{
+			name:         "MySQL DATETIME text",
+			databaseType: "DATETIME",
+			value:        []byte("2024-01-02 03:04:05"),
+			want:         TaggedValue{Type: TimestampValue, Value: "2024-01-02T03:04:05"},
+		},
+		{
+			name:         "MySQL TIMESTAMP text with fraction",
+			databaseType: "TIMESTAMP",
+			value:        []byte("2024-01-02 03:04:05.123456"),
+			want:         TaggedValue{Type: TimestampValue, Value: "2024-01-02T03:04:05.123456"},
+		},
+		{
+			name:         "MySQL DATE text",
+			databaseType: "DATE",
+			value:        []byte("2024-01-02"),
+			want:         TaggedValue{Type: DateValue, Value: "2024-01-02"},
+		},
+		{
+			name:         "MySQL TIME text",
+			databaseType: "TIME",
+			value:        []byte("13:14:15"),
+			want:         TaggedValue{Type: TimestampValue, Value: "13:14:15"},
+		},
+		{
+			name:         "MySQL zero date kept as text",
+			databaseType: "DATETIME",
+			value:        []byte("0000-00-00 00:00:00"),
+			want:         "0000-00-00 00:00:00",
+		},
+		{
+			name:         "MySQL TIME interval kept as text",
+			databaseType: "TIME",
+			value:        []byte("-838:59:59"),
+			want:         "-838:59:59",
+		},
+		{
+			name:         "timestamp without time zone",
+			databaseType: "TIMESTAMP",
+			value:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			want:         TaggedValue{Type: TimestampValue, Value: "2024-01-02T03:04:05"},
+		},
+		{
+			name:         "timestamp with time zone",
+			databaseType: "TIMESTAMPTZ",
+			value:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)),
+			want:         TaggedValue{Type: TimestampValue, Value: "2024-01-02T03:04:05+01:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EncodeValue(tt.databaseType, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EncodeValue(%q, %v) = %#v, want %#v", tt.databaseType, tt.value, got, tt.want)
+			}
+		})
+	}
+}
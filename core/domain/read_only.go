@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrReadOnly is returned for statements that could write through a
+// read-only connection
+var ErrReadOnly = errors.New("connection is read-only")
+
+// readStatements are the statements allowed on read-only connections. Any
+// other statement, including SET and transaction control that could turn
+// the server-side read-only mode off, is rejected.
+var readStatements = map[string]bool{
+	"SELECT":   true,
+	"WITH":     true,
+	"VALUES":   true,
+	"TABLE":    true,
+	"SHOW":     true,
+	"EXPLAIN":  true,
+	"DESCRIBE": true,
+	"DESC":     true,
+}
+
+// writeKeywords make a read statement write, as in data-modifying CTEs,
+// EXPLAIN ANALYZE of an UPDATE or SELECT ... INTO
+var writeKeywords = []string{"INSERT", "UPDATE", "DELETE", "MERGE", "UPSERT", "TRUNCATE", "DROP", "ALTER", "CREATE", "GRANT", "REVOKE", "INTO", "CALL"}
+
+// describeStatements read metadata only, the names they take are not
+// scanned for writeKeywords, so SHOW CREATE TABLE is allowed
+var describeStatements = map[string]bool{"SHOW": true, "DESCRIBE": true, "DESC": true}
+
+// explainWords follow DESCRIBE and DESC when they explain a statement as
+// EXPLAIN does, instead of describing a table
+var explainWords = []string{"ANALYZE", "FORMAT", "EXTENDED", "PARTITIONS", "FOR", "SELECT", "WITH", "VALUES", "TABLE", "INSERT", "UPDATE", "DELETE", "REPLACE"}
+
+// settingFunctions change settings from a read statement, as set_config
+// turning default_transaction_read_only off. They are matched on the last
+// part of their name, so pg_catalog.set_config is rejected as well.
+var settingFunctions = map[string]bool{"set_config": true}
+
+// CheckReadOnly returns an error wrapping ErrReadOnly when a statement of the
+// script may write. The server enforces read-only sessions as well, this
+// check rejects writes before they are sent.
+func CheckReadOnly(script string, dialect SQLDialect) error {
+	for _, statement := range splitStatements(script, dialect) {
+		keyword := statement.keyword()
+		if !readStatements[keyword] {
+			return fmt.Errorf("%w: %s statements are not allowed", ErrReadOnly, keyword)
+		}
+		if statement.describes() {
+			continue
+		}
+		if word, ok := statement.has(writeKeywords...); ok {
+			return fmt.Errorf("%w: %s is not allowed in %s statements", ErrReadOnly, word, keyword)
+		}
+		if name, ok := statement.callsSettingFunction(); ok {
+			return fmt.Errorf("%w: %s is not allowed", ErrReadOnly, name)
+		}
+	}
+	return nil
+}
+
+// describes reports whether the statement only reads metadata
+func (s sqlStatement) describes() bool {
+	i := s.keywordIndex()
+	if i == len(s.tokens) || !describeStatements[s.tokens[i]] {
+		return false
+	}
+	if s.tokens[i] == "SHOW" || i+1 == len(s.tokens) {
+		return true
+	}
+	for _, word := range explainWords {
+		if s.tokens[i+1] == word {
+			return false
+		}
+	}
+	return true
+}
+
+// callsSettingFunction returns the setting function the statement names,
+// written as a word or as a quoted identifier
+func (s sqlStatement) callsSettingFunction() (string, bool) {
+	for i, token := range s.tokens {
+		name := strings.ToLower(token)
+		if token == identifierToken {
+			// Quoted names keep their case
+			quoted := s.tokenText(i)
+			name = strings.ReplaceAll(quoted[1:len(quoted)-1], quoted[:1]+quoted[:1], quoted[:1])
+		}
+		if settingFunctions[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// readOnlySession runs every statement in a read-only transaction of its
+// own, so the server rejects writes whatever the statement calls
+type readOnlySession struct {
+	QuerySession
+	begin string
+}
+
+// NewReadOnlySession returns a session running each statement of session in
+// a transaction begun with begin and rolled back once its cursor is closed
+func NewReadOnlySession(session QuerySession, begin string) QuerySession {
+	return &readOnlySession{QuerySession: session, begin: begin}
+}
+
+func (s *readOnlySession) Query(ctx context.Context, statement string, args ...interface{}) (QueryCursor, error) {
+	if err := s.command(s.begin); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.QuerySession.Query(ctx, statement, args...)
+	if err != nil {
+		return nil, errors.Join(err, s.command("ROLLBACK"))
+	}
+	return &readOnlyCursor{QueryCursor: cursor, session: s}, nil
+}
+
+// command runs a transaction control statement. It does not take the
+// context of the query, so the transaction ends even when it was canceled.
+func (s *readOnlySession) command(statement string) error {
+	cursor, err := s.QuerySession.Query(context.Background(), statement)
+	if err != nil {
+		return err
+	}
+	return cursor.Close()
+}
+
+// readOnlyCursor ends the transaction of its statement when closed
+type readOnlyCursor struct {
+	QueryCursor
+	session *readOnlySession
+	closed  bool
+}
+
+func (c *readOnlyCursor) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return errors.Join(c.QueryCursor.Close(), c.session.command("ROLLBACK"))
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// redactedLiteral replaces the passwords written in a script
+const redactedLiteral = "'***'"
+
+// RedactCredentials replaces the passwords the statements of a script set,
+// as in ALTER USER ... PASSWORD '...', IDENTIFIED BY '...' or SET PASSWORD,
+// with '***', so the script can be kept without them
+func RedactCredentials(script string, dialect SQLDialect) string {
+	var b strings.Builder
+	last := 0
+	for _, statement := range splitStatements(script, dialect) {
+		for i, token := range statement.tokens {
+			// MySQL also quotes text with double quotes, which are read
+			// as quoted identifiers
+			if token != stringToken && token != identifierToken || !statement.credentialAt(i) {
+				continue
+			}
+			b.WriteString(script[last:statement.spans[i][0]])
+			b.WriteString(redactedLiteral)
+			last = statement.spans[i][1]
+		}
+	}
+	if last == 0 {
+		return script
+	}
+	b.WriteString(script[last:])
+	return b.String()
+}
+
+// literalPrefix reports whether the word, written against a literal, is its
+// escape, national or character set prefix
+func literalPrefix(word string) bool {
+	return word == "E" || word == "N" || strings.HasPrefix(word, "_")
+}
+
+// credentialAt reports whether the literal at i is a password: it is
+// assigned in SET PASSWORD, or follows PASSWORD, PASSWORD(, IDENTIFIED [WITH
+// plugin] BY or AS, or the REPLACE of the current password. A prefix like E
+// or _utf8mb4 written against the literal is skipped. Columns named password
+// are assigned with an equal sign, which the PASSWORD clauses do without.
+func (s sqlStatement) credentialAt(i int) bool {
+	if len(s.tokens) > 1 && s.tokens[0] == "SET" && s.tokens[1] == "PASSWORD" &&
+		slices.Contains(s.tokens[:i], "=") {
+		return true
+	}
+
+	j := i - 1
+	if j >= 0 && s.spans[j][1] == s.spans[i][0] && literalPrefix(s.tokens[j]) {
+		j--
+	}
+	if j >= 0 && s.tokens[j] == "(" {
+		j--
+	}
+	if j < 0 {
+		return false
+	}
+
+	switch s.tokens[j] {
+	case "PASSWORD":
+		return true
+	case "BY", "AS":
+		return slices.Contains(s.tokens[max(0, j-3):j], "IDENTIFIED")
+	case "REPLACE":
+		return slices.Contains(s.tokens[:j], "IDENTIFIED")
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package domain_test
+
+import (
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestRedactCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    string
+	}{
+		{
+			name:    "ALTER USER PASSWORD",
+			script:  "ALTER USER app WITH PASSWORD 's3cret'",
+			dialect: postgresDialect,
+			want:    "ALTER USER app WITH PASSWORD '***'",
+		},
+		{
+			name:    "CREATE ROLE ENCRYPTED PASSWORD",
+			script:  "CREATE ROLE app LOGIN ENCRYPTED PASSWORD 'it''s' VALID UNTIL '2027-01-01'",
+			dialect: postgresDialect,
+			want:    "CREATE ROLE app LOGIN ENCRYPTED PASSWORD '***' VALID UNTIL '2027-01-01'",
+		},
+		{
+			name:    "escape string",
+			script:  `ALTER ROLE app PASSWORD E'a\'b'`,
+			dialect: postgresDialect,
+			want:    "ALTER ROLE app PASSWORD E'***'",
+		},
+		{
+			name:    "dollar-quoted",
+			script:  "ALTER ROLE app PASSWORD $$s3cret$$",
+			dialect: postgresDialect,
+			want:    "ALTER ROLE app PASSWORD '***'",
+		},
+		{
+			name:    "IDENTIFIED BY",
+			script:  "CREATE USER 'app'@'%' IDENTIFIED BY 's3cret'",
+			dialect: mysqlDialect,
+			want:    "CREATE USER 'app'@'%' IDENTIFIED BY '***'",
+		},
+		{
+			name:    "IDENTIFIED WITH plugin BY and REPLACE",
+			script:  `ALTER USER 'app'@'%' IDENTIFIED WITH caching_sha2_password BY "new\"one" REPLACE 'old'`,
+			dialect: mysqlDialect,
+			want:    `ALTER USER 'app'@'%' IDENTIFIED WITH caching_sha2_password BY '***' REPLACE '***'`,
+		},
+		{
+			name:    "IDENTIFIED WITH plugin AS hash",
+			script:  "CREATE USER app IDENTIFIED WITH mysql_native_password AS '*6BB4837EB74329105EE4568DDA7DC67ED2CA2AD9'",
+			dialect: mysqlDialect,
+			want:    "CREATE USER app IDENTIFIED WITH mysql_native_password AS '***'",
+		},
+		{
+			name:    "several users",
+			script:  "CREATE USER 'a'@'%' IDENTIFIED BY 'one', 'b'@'%' IDENTIFIED BY _utf8mb4'two'",
+			dialect: mysqlDialect,
+			want:    "CREATE USER 'a'@'%' IDENTIFIED BY '***', 'b'@'%' IDENTIFIED BY _utf8mb4'***'",
+		},
+		{
+			name:    "SET PASSWORD",
+			script:  "SET PASSWORD = 's3cret'; SET PASSWORD FOR 'app'@'%' = 'other'",
+			dialect: mysqlDialect,
+			want:    "SET PASSWORD = '***'; SET PASSWORD FOR 'app'@'%' = '***'",
+		},
+		{
+			name:    "PASSWORD function",
+			script:  "SET PASSWORD FOR app = PASSWORD('s3cret')",
+			dialect: mysqlDialect,
+			want:    "SET PASSWORD FOR app = PASSWORD('***')",
+		},
+		{
+			name:    "among other statements",
+			script:  "SELECT 'PASSWORD ''x'''; ALTER USER app PASSWORD 'y'; SELECT password FROM users WHERE name = 'z'",
+			dialect: postgresDialect,
+			want:    "SELECT 'PASSWORD ''x'''; ALTER USER app PASSWORD '***'; SELECT password FROM users WHERE name = 'z'",
+		},
+		{
+			name:    "comments and columns left alone",
+			script:  "-- PASSWORD 'x'\nUPDATE users SET password = 'hash' WHERE id = 1",
+			dialect: postgresDialect,
+			want:    "-- PASSWORD 'x'\nUPDATE users SET password = 'hash' WHERE id = 1",
+		},
+		{
+			name:    "no credentials",
+			script:  "SELECT * FROM orders",
+			dialect: postgresDialect,
+			want:    "SELECT * FROM orders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.RedactCredentials(tt.script, tt.dialect); got != tt.want {
+				t.Errorf("RedactCredentials(%q) = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
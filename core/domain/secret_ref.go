@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// FileRefPrefix starts the values read from a file, e.g. file:/run/secrets/db
+const FileRefPrefix = "file:"
+
+// passCommandTimeout bounds a password command, which may wait for a PIN entry
+const passCommandTimeout = time.Minute
+
+// envRefPattern matches values read from an environment variable, e.g. ${env:PGPASSWORD}
+var envRefPattern = regexp.MustCompile(`^\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
+// ValidateSecretRef checks that the value is a reference to an environment
+// variable or a file
+func ValidateSecretRef(ref string) error {
+	if envRefPattern.MatchString(ref) || strings.HasPrefix(ref, FileRefPrefix) {
+		return nil
+	}
+	return fmt.Errorf("secret reference %q is neither ${env:NAME} nor file:path", ref)
+}
+
+// ResolveSecretRef reads the secret an environment variable or file
+// reference points to. References are kept in fields of their own, never in
+// the secret fields, so literal secrets are not mistaken for references.
+func ResolveSecretRef(ref string) (string, error) {
+	if match := envRefPattern.FindStringSubmatch(ref); match != nil {
+		secret, ok := os.LookupEnv(match[1])
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", match[1])
+		}
+		return secret, nil
+	}
+
+	path, ok := strings.CutPrefix(ref, FileRefPrefix)
+	if !ok {
+		return "", ValidateSecretRef(ref)
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// runPassCommand runs a shell command and returns the first line it prints
+func runPassCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), passCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("password command failed: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("password command failed: %w", err)
+	}
+
+	password, _, _ := strings.Cut(string(output), "\n")
+	password = strings.TrimRight(password, "\r")
+	if password == "" {
+		return "", fmt.Errorf("password command printed nothing")
+	}
+
+	return password, nil
+}
@@ -3,21 +3,23 @@ package domain
 import "fmt"
 
 // ServiceFactory creates appropriate database services based on vendor
-type ServiceFactory struct{}
+type ServiceFactory struct {
+	sessions *SessionManager
+}
 
-// NewServiceFactory creates a new service factory
-func NewServiceFactory() *ServiceFactory {
-	return &ServiceFactory{}
+// NewServiceFactory creates a new service factory whose services share the given sessions
+func NewServiceFactory(sessions *SessionManager) *ServiceFactory {
+	return &ServiceFactory{
+		sessions: sessions,
+	}
 }
 
 // NewDatabaseService creates the appropriate database service for the given connection
 func (f *ServiceFactory) NewDatabaseService(c *Connection) (DatabaseService, error) {
-	switch c.Vendor() {
-	case "postgresql":
-		return NewPostgreSQLService(), nil
-	case "mysql":
-		return NewMySQLService(), nil
-	default:
+	v, err := LookupVendor(c.Vendor())
+	if err != nil {
 		return nil, fmt.Errorf("unsupported database vendor: %s", c.Vendor())
 	}
+
+	return v.NewService(f.sessions), nil
 }
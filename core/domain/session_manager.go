@@ -0,0 +1,418 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionOptions configures the pools owned by a SessionManager
+type SessionOptions struct {
+	// MaxOpenConns limits the open connections of every session pool
+	MaxOpenConns int
+	// MaxIdleConns limits the idle connections kept by every session pool
+	MaxIdleConns int
+	// ConnMaxIdleTime closes pooled connections that stay idle for longer
+	ConnMaxIdleTime time.Duration
+	// IdleTimeout closes a whole session when it has not been used for longer
+	IdleTimeout time.Duration
+	// ReapInterval is how often idle sessions are looked for
+	ReapInterval time.Duration
+}
+
+// DefaultSessionOptions returns the options used by the application
+func DefaultSessionOptions() SessionOptions {
+	return SessionOptions{
+		MaxOpenConns:    10,
+		MaxIdleConns:    2,
+		ConnMaxIdleTime: 5 * time.Minute,
+		IdleTimeout:     30 * time.Minute,
+		ReapInterval:    time.Minute,
+	}
+}
+
+// cancelTimeout bounds how long canceling a query on the server may take,
+// connecting included
+const cancelTimeout = 5 * time.Second
+
+// session holds a pool opened for a connection and database, together with
+// the SSH tunnel it is reached through, if any
+type session struct {
+	db          *sql.DB
+	tunnel      *sshTunnel
+	target      *Connection
+	endpoint    string
+	fingerprint string
+	lastUsed    time.Time
+	// inUse counts the sessions taken out of the pool with Acquire, which
+	// keep it from being reaped
+	inUse int
+	// retired is set when the pool was replaced while in use, it is closed
+	// once the last session taken out of it is released
+	retired bool
+}
+
+// alive reports whether the session can still be used
+func (s *session) alive() bool {
+	return s.tunnel == nil || s.tunnel.Alive()
+}
+
+// close closes the pool and then the tunnel it goes through
+func (s *session) close() error {
+	err := s.db.Close()
+	if s.tunnel != nil {
+		if tunnelErr := s.tunnel.Close(); err == nil {
+			err = tunnelErr
+		}
+	}
+	return err
+}
+
+// SessionManager owns one *sql.DB per connection and database for the
+// whole process, so consecutive operations reuse already authenticated
+// connections instead of opening a new pool each time.
+type SessionManager struct {
+	mu       sync.Mutex
+	options  SessionOptions
+	secrets  SecretResolver
+	sessions map[string]*session
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSessionManager creates a new SessionManager and starts reaping idle
+// sessions. Secret references in the connections are resolved through
+// secrets when a session is opened.
+func NewSessionManager(options SessionOptions, secrets SecretResolver) *SessionManager {
+	m := &SessionManager{
+		options:  options,
+		secrets:  secrets,
+		sessions: make(map[string]*session),
+		stop:     make(chan struct{}),
+	}
+
+	if options.IdleTimeout > 0 && options.ReapInterval > 0 {
+		go m.reap()
+	}
+
+	return m
+}
+
+func sessionKey(c *Connection) string {
+	return c.ID() + "/" + c.database
+}
+
+// sessionFingerprint identifies the settings a session was opened with, so a
+// session is reopened when the connection is edited. Secret references are
+// compared as written, not resolved.
+func sessionFingerprint(c *Connection, buildDSN func(*Connection) string) string {
+	fingerprint := buildDSN(c)
+	for _, endpoint := range c.failoverHosts {
+		fingerprint += "|failover:" + endpoint.String()
+	}
+	if c.passCommand != "" {
+		fingerprint += "|passCommand:" + c.passCommand
+	}
+	if c.passwordRef != "" {
+		fingerprint += "|passwordRef:" + c.passwordRef
+	}
+	if c.readOnly {
+		fingerprint += "|readOnly"
+	}
+	if c.statementTimeout > 0 {
+		fingerprint += "|statementTimeout:" + c.statementTimeout.String()
+	}
+	if t := c.tls; t != nil {
+		fingerprint += fmt.Sprintf("|tls:%s:%s:%s:%s:%s", t.Mode, t.CAFile, t.CertFile, t.KeyFile, t.ServerName)
+	}
+	if t := c.sshTunnel; t != nil {
+		fingerprint += fmt.Sprintf("|ssh:%s@%s:%s:%s:%s:%s", t.User, t.address(), t.PrivateKeyFile, t.agentSocket(), t.KnownHostsFile, t.KeepAliveInterval)
+		fingerprint += "|" + t.Password + "|" + t.Passphrase + "|" + t.PasswordRef + "|" + t.PassphraseRef
+	}
+	return fingerprint
+}
+
+// Open returns the session pool for the connection, opening and pinging a new
+// one when none exists or when the connection settings changed since it was
+// opened. Connections with an SSH tunnel are reached through a local port
+// forwarded by the tunnel, which lives as long as the session.
+func (m *SessionManager) Open(c *Connection, v *Vendor) (*sql.DB, error) {
+	key := sessionKey(c)
+	fingerprint := sessionFingerprint(c, v.BuildDSN)
+
+	m.mu.Lock()
+	if s, exists := m.sessions[key]; exists {
+		if s.fingerprint == fingerprint && s.alive() {
+			s.lastUsed = time.Now()
+			m.mu.Unlock()
+			return s.db, nil
+		}
+		// Connection settings changed or the tunnel dropped, the old pool is
+		// stale. A pool still in use by a transaction or a result is closed
+		// when they are done with it.
+		delete(m.sessions, key)
+		stale := s.inUse == 0
+		s.retired = !stale
+		m.mu.Unlock()
+		if stale {
+			s.close()
+		}
+	} else {
+		m.mu.Unlock()
+	}
+
+	// Secrets are resolved only when a pool is opened, so password commands
+	// do not run for every operation
+	resolved, err := c.WithSecrets(m.secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failover hosts are tried in order until one answers
+	var s *session
+	var errs []string
+	for _, endpoint := range resolved.Endpoints() {
+		opened, err := m.openSession(resolved, v, endpoint)
+		if err == nil {
+			s = opened
+			break
+		}
+		errs = append(errs, err.Error())
+	}
+	if s == nil {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	s.fingerprint = fingerprint
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Another caller may have opened the same session while we were pinging
+	if existing, exists := m.sessions[key]; exists && existing.fingerprint == fingerprint && existing.alive() {
+		s.close()
+		existing.lastUsed = time.Now()
+		return existing.db, nil
+	}
+
+	m.sessions[key] = s
+
+	return s.db, nil
+}
+
+// openSession opens and pings a pool reaching the database at the given
+// endpoint, through a new SSH tunnel when the connection has one
+func (m *SessionManager) openSession(c *Connection, v *Vendor, endpoint Endpoint) (*session, error) {
+	target := c.withEndpoint(endpoint.Host, endpoint.Port)
+	var tunnel *sshTunnel
+	if c.sshTunnel != nil {
+		t, err := openSSHTunnel(c.sshTunnel, endpoint.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open SSH tunnel: %w", err)
+		}
+		tunnel = t
+		target = c.withEndpoint("127.0.0.1", t.LocalPort())
+		if c.tls != nil && c.tls.ServerName == "" {
+			// The certificate is still checked against the host behind the tunnel
+			tlsConfig := *c.tls
+			tlsConfig.ServerName = endpoint.Host
+			target.tls = &tlsConfig
+		}
+	}
+
+	db, err := openDB(target, v)
+	if err != nil {
+		if tunnel != nil {
+			tunnel.Close()
+		}
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(m.options.MaxOpenConns)
+	db.SetMaxIdleConns(m.options.MaxIdleConns)
+	db.SetConnMaxIdleTime(m.options.ConnMaxIdleTime)
+
+	s := &session{
+		db:       db,
+		tunnel:   tunnel,
+		target:   target,
+		endpoint: net.JoinHostPort(target.host, strconv.Itoa(target.port)),
+		lastUsed: time.Now(),
+	}
+
+	if err := db.Ping(); err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return s, nil
+}
+
+// openDB opens the pool through the vendor connector when it has one
+func openDB(c *Connection, v *Vendor) (*sql.DB, error) {
+	if v.OpenConnector == nil {
+		return sql.Open(v.DriverName, v.BuildDSN(c))
+	}
+
+	connector, err := v.OpenConnector(c)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// Endpoint returns the host:port the open session of the connection dials,
+// which is a local port when the session goes through an SSH tunnel
+func (m *SessionManager) Endpoint(c *Connection) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, exists := m.sessions[sessionKey(c)]
+	if !exists {
+		return "", false
+	}
+	return s.endpoint, true
+}
+
+// Canceler returns a function running a statement that cancels a query of
+// the open session of the connection. The statement runs on a connection of
+// its own, opened to the host the session pool dials and closed right after,
+// so it neither waits for a free pooled connection nor reaches another
+// failover host. It returns nil when the connection has no open session.
+func (m *SessionManager) Canceler(c *Connection, v *Vendor) func(statement string, args ...interface{}) error {
+	m.mu.Lock()
+	s, exists := m.sessions[sessionKey(c)]
+	m.mu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	return func(statement string, args ...interface{}) error {
+		ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+		defer cancel()
+
+		db, err := openDB(s.target, v)
+		if err != nil {
+			return fmt.Errorf("failed to open cancel connection: %w", err)
+		}
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		_, err = db.ExecContext(ctx, statement, args...)
+		return err
+	}
+}
+
+// Get returns the open session pool for the connection, or nil if there is none
+func (m *SessionManager) Get(c *Connection) *sql.DB {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, exists := m.sessions[sessionKey(c)]
+	if !exists {
+		return nil
+	}
+
+	s.lastUsed = time.Now()
+	return s.db
+}
+
+// Acquire returns the open session pool for the connection, like Get, and
+// marks it in use until release is called. Transactions and results holding
+// a session taken out of the pool acquire it, so it is neither reaped nor
+// closed when the connection settings change while they are open. The pool
+// is nil when there is none.
+func (m *SessionManager) Acquire(c *Connection) (db *sql.DB, release func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, exists := m.sessions[sessionKey(c)]
+	if !exists {
+		return nil, func() {}
+	}
+
+	s.inUse++
+	s.lastUsed = time.Now()
+	var once sync.Once
+	return s.db, func() {
+		once.Do(func() {
+			m.mu.Lock()
+			s.inUse--
+			s.lastUsed = time.Now()
+			retired := s.retired && s.inUse == 0
+			m.mu.Unlock()
+			if retired {
+				s.close()
+			}
+		})
+	}
+}
+
+// Close closes every session opened for the connection, whatever its database
+func (m *SessionManager) Close(c *Connection) error {
+	m.mu.Lock()
+	var closing []*session
+	prefix := c.ID() + "/"
+	for key, s := range m.sessions {
+		if strings.HasPrefix(key, prefix) {
+			closing = append(closing, s)
+			delete(m.sessions, key)
+		}
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, s := range closing {
+		if err := s.close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close database connection: %w", err)
+		}
+	}
+
+	return firstErr
+}
+
+// Shutdown stops reaping and closes every open session
+func (m *SessionManager) Shutdown() {
+	m.stopOnce.Do(func() { close(m.stop) })
+
+	m.mu.Lock()
+	sessions := m.sessions
+	m.sessions = make(map[string]*session)
+	m.mu.Unlock()
+
+	for _, s := range sessions {
+		s.close()
+	}
+}
+
+// reap periodically closes sessions that exceeded the idle timeout and that
+// no transaction or result is using
+func (m *SessionManager) reap() {
+	ticker := time.NewTicker(m.options.ReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			var idle []*session
+			for key, s := range m.sessions {
+				if s.inUse == 0 && now.Sub(s.lastUsed) > m.options.IdleTimeout {
+					idle = append(idle, s)
+					delete(m.sessions, key)
+				}
+			}
+			m.mu.Unlock()
+
+			for _, s := range idle {
+				s.close()
+			}
+		}
+	}
+}
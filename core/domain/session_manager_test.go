@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"seagle/core/domain"
+	"seagle/core/domain/vendors/sqlite"
+)
+
+// plainSecrets resolves every value to itself
+type plainSecrets struct{}
+
+func (plainSecrets) Resolve(value string) (string, error) {
+	return value, nil
+}
+
+// reapedWithin waits for the reaper to close the session of the connection.
+// Endpoint is polled since Get marks the session used.
+func reapedWithin(m *domain.SessionManager, c *domain.Connection, wait time.Duration) bool {
+	deadline := time.Now().Add(wait)
+	for time.Now().Before(deadline) {
+		if _, open := m.Endpoint(c); !open {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+func TestSessionManagerKeepsAcquiredSessions(t *testing.T) {
+	m := domain.NewSessionManager(domain.SessionOptions{
+		MaxOpenConns: 1,
+		IdleTimeout:  10 * time.Millisecond,
+		ReapInterval: 5 * time.Millisecond,
+	}, plainSecrets{})
+	defer m.Shutdown()
+
+	c, err := domain.NewConnection("id", "sqlite", filepath.Join(t.TempDir(), "app.db"), 0, "", "", "", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Open(c, sqlite.Vendor); err != nil {
+		t.Fatal(err)
+	}
+
+	db, release := m.Acquire(c)
+	if db == nil {
+		t.Fatal("Acquire() returned no pool for an open session")
+	}
+	if reapedWithin(m, c, 100*time.Millisecond) {
+		t.Fatal("the session was reaped while in use")
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("the pool in use was closed: %v", err)
+	}
+
+	release()
+	if !reapedWithin(m, c, time.Second) {
+		t.Error("the session was not reaped once released")
+	}
+}
+
+func TestSessionManagerClosesReplacedSessionsOnRelease(t *testing.T) {
+	m := domain.NewSessionManager(domain.SessionOptions{MaxOpenConns: 1}, plainSecrets{})
+	defer m.Shutdown()
+
+	path := filepath.Join(t.TempDir(), "app.db")
+	c, err := domain.NewConnection("id", "sqlite", path, 0, "", "", "", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Open(c, sqlite.Vendor); err != nil {
+		t.Fatal(err)
+	}
+	db, release := m.Acquire(c)
+
+	// A changed setting replaces the pool
+	c.SetReadOnly(true)
+	replacement, err := m.Open(c, sqlite.Vendor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replacement == db {
+		t.Fatal("Open() kept the pool of the old settings")
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("the replaced pool was closed while in use: %v", err)
+	}
+
+	release()
+	if err := db.Ping(); err == nil {
+		t.Error("the replaced pool is still open once released")
+	}
+}
@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// snippetVariable matches the ${name} placeholders of a snippet
+var snippetVariable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Snippet is a saved query, global or scoped to a connection and filed in a
+// folder. Its ${name} variables are bound to values when it runs.
+type Snippet struct {
+	id          string
+	name        string
+	description string
+	// folder is a path of folder names separated by /, empty at the root
+	folder string
+	// connectionID scopes the snippet to a connection, empty for a global one
+	connectionID string
+	query        string
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func NewSnippet(id, name, description, folder, connectionID, query string) (*Snippet, error) {
+	now := time.Now()
+	s := &Snippet{id: id, createdAt: now}
+	if err := s.Update(name, description, folder, connectionID, query); err != nil {
+		return nil, err
+	}
+	s.updatedAt = now
+	return s, nil
+}
+
+func NewSnippetFromMap(data map[string]interface{}) *Snippet {
+	s := &Snippet{}
+	s.id, _ = data["id"].(string)
+	s.name, _ = data["name"].(string)
+	s.description, _ = data["description"].(string)
+	s.folder, _ = data["folder"].(string)
+	s.connectionID, _ = data["connectionId"].(string)
+	s.query, _ = data["query"].(string)
+	if createdAt, ok := data["createdAt"].(string); ok {
+		s.createdAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	}
+	if updatedAt, ok := data["updatedAt"].(string); ok {
+		s.updatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
+	}
+	return s
+}
+
+func (s *Snippet) ID() string {
+	return s.id
+}
+
+func (s *Snippet) Name() string {
+	return s.name
+}
+
+func (s *Snippet) Description() string {
+	return s.description
+}
+
+func (s *Snippet) Folder() string {
+	return s.folder
+}
+
+// ConnectionID returns the connection the snippet is scoped to, empty for a
+// global snippet
+func (s *Snippet) ConnectionID() string {
+	return s.connectionID
+}
+
+// Global reports whether the snippet can run on every connection
+func (s *Snippet) Global() bool {
+	return s.connectionID == ""
+}
+
+func (s *Snippet) Query() string {
+	return s.query
+}
+
+func (s *Snippet) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Snippet) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// Update replaces the fields of the snippet. The name and query are
+// required, the folder is cleaned of empty names and extra slashes.
+func (s *Snippet) Update(name, description, folder, connectionID, query string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("snippet name cannot be empty")
+	}
+	if strings.TrimSpace(query) == "" {
+		return errors.New("snippet query cannot be empty")
+	}
+
+	s.name = name
+	s.description = strings.TrimSpace(description)
+	s.folder = CleanSnippetFolder(folder)
+	s.connectionID = connectionID
+	s.query = query
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// Variables returns the names of the ${name} variables of the query, each
+// once in order of appearance. Variables in literals and comments, as the
+// dialect reads them, are text and not counted.
+func (s *Snippet) Variables(dialect SQLDialect) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range s.variables(dialect) {
+		name := s.query[match[2]:match[3]]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Bind rewrites the ${name} variables of the query into :name placeholders,
+// so their values are bound by the driver like the other query parameters
+// and never written into the statement. It returns the query and the names
+// of its variables.
+func (s *Snippet) Bind(dialect SQLDialect) (string, []string) {
+	var query strings.Builder
+	last := 0
+	for _, match := range s.variables(dialect) {
+		query.WriteString(s.query[last:match[0]])
+		// A placeholder right after a word or another placeholder would be
+		// read as part of it
+		if before, _ := utf8.DecodeLastRuneInString(query.String()); before == ':' || before == '$' || isWordRune(before) {
+			query.WriteByte(' ')
+		}
+		query.WriteString(":" + s.query[match[2]:match[3]])
+		last = match[1]
+	}
+	query.WriteString(s.query[last:])
+
+	return query.String(), s.Variables(dialect)
+}
+
+// variables returns the submatch indexes of the ${name} variables outside
+// literals and comments. Those start with a $ token of the statements, the
+// $ of literals and comments is no token.
+func (s *Snippet) variables(dialect SQLDialect) [][]int {
+	code := make(map[int]bool)
+	for _, statement := range splitStatements(s.query, dialect) {
+		for i, token := range statement.tokens {
+			if token == "$" {
+				code[statement.spans[i][0]] = true
+			}
+		}
+	}
+
+	var found [][]int
+	for _, match := range snippetVariable.FindAllStringSubmatchIndex(s.query, -1) {
+		if code[match[0]] {
+			found = append(found, match)
+		}
+	}
+	return found
+}
+
+func (s *Snippet) Map() map[string]interface{} {
+	data := map[string]interface{}{
+		"id":        s.id,
+		"name":      s.name,
+		"folder":    s.folder,
+		"query":     s.query,
+		"createdAt": s.createdAt.Format(time.RFC3339Nano),
+		"updatedAt": s.updatedAt.Format(time.RFC3339Nano),
+	}
+	if s.description != "" {
+		data["description"] = s.description
+	}
+	if s.connectionID != "" {
+		data["connectionId"] = s.connectionID
+	}
+	return data
+}
+
+// CleanSnippetFolder returns the folder path without empty names and with
+// the names trimmed, e.g. " diagnostics//locks/ " becomes diagnostics/locks
+func CleanSnippetFolder(folder string) string {
+	var names []string
+	for _, name := range strings.Split(folder, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// SnippetFilter selects saved snippets. Empty fields match every snippet.
+type SnippetFilter struct {
+	// Text is searched in the name, description, folder and query, ignoring
+	// case
+	Text string
+	// ConnectionID keeps the global snippets and those of the connection
+	ConnectionID string
+	// Folder keeps the snippets of the folder and its subfolders
+	Folder string
+}
+
+// Matches reports whether the snippet passes the filter
+func (f SnippetFilter) Matches(s *Snippet) bool {
+	if f.ConnectionID != "" && !s.Global() && s.connectionID != f.ConnectionID {
+		return false
+	}
+
+	if folder := CleanSnippetFolder(f.Folder); folder != "" {
+		if s.folder != folder && !strings.HasPrefix(s.folder, folder+"/") {
+			return false
+		}
+	}
+
+	if f.Text != "" {
+		text := strings.ToLower(f.Text)
+		found := false
+		for _, field := range []string{s.name, s.description, s.folder, s.query} {
+			if strings.Contains(strings.ToLower(field), text) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
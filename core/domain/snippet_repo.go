@@ -0,0 +1,11 @@
+package domain
+
+type SnippetRepo interface {
+	NextID() string
+	Save(snippet *Snippet) error
+	List() ([]*Snippet, error)
+	FindByID(id string) (*Snippet, error)
+	DeleteByID(id string) error
+	// DeleteByConnectionID removes the snippets scoped to the connection
+	DeleteByConnectionID(connectionID string) error
+}
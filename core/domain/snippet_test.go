@@ -0,0 +1,117 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func newSnippet(t *testing.T, query string) *domain.Snippet {
+	t.Helper()
+	snippet, err := domain.NewSnippet("1", "snippet", "", "", "", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snippet
+}
+
+func TestSnippetBind(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		dialect   domain.SQLDialect
+		want      string
+		variables []string
+	}{
+		{
+			name:      "variables become placeholders",
+			query:     "SELECT * FROM orders WHERE customer_id = ${customer} AND status = ${status}",
+			dialect:   postgresDialect,
+			want:      "SELECT * FROM orders WHERE customer_id = :customer AND status = :status",
+			variables: []string{"customer", "status"},
+		},
+		{
+			name:      "a variable used twice is named once",
+			query:     "SELECT ${id}, ${id} + 1",
+			dialect:   postgresDialect,
+			want:      "SELECT :id, :id + 1",
+			variables: []string{"id"},
+		},
+		{
+			name:      "placeholders kept apart from words and other placeholders",
+			query:     "SELECT x${a}, ${a}${b}",
+			dialect:   postgresDialect,
+			want:      "SELECT x :a, :a :b",
+			variables: []string{"a", "b"},
+		},
+		{
+			name:      "literals and comments left alone",
+			query:     "SELECT '${a}', \"${b}\" -- ${c}\nFROM t /* ${d} */ WHERE x = ${e}",
+			dialect:   postgresDialect,
+			want:      "SELECT '${a}', \"${b}\" -- ${c}\nFROM t /* ${d} */ WHERE x = :e",
+			variables: []string{"e"},
+		},
+		{
+			name:      "dollar-quoted bodies left alone",
+			query:     "DO $$BEGIN RAISE NOTICE '${a}'; END$$; SELECT ${b}",
+			dialect:   postgresDialect,
+			want:      "DO $$BEGIN RAISE NOTICE '${a}'; END$$; SELECT :b",
+			variables: []string{"b"},
+		},
+		{
+			name:      "hash comments of MySQL",
+			query:     "SELECT ${a} # ${b}\nFROM t",
+			dialect:   mysqlDialect,
+			want:      "SELECT :a # ${b}\nFROM t",
+			variables: []string{"a"},
+		},
+		{
+			name:      "other placeholders kept",
+			query:     "SELECT * FROM t WHERE a = $1 AND b = ${b}",
+			dialect:   postgresDialect,
+			want:      "SELECT * FROM t WHERE a = $1 AND b = :b",
+			variables: []string{"b"},
+		},
+		{
+			name:    "no variables",
+			query:   "SELECT 1",
+			dialect: sqliteDialect,
+			want:    "SELECT 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippet := newSnippet(t, tt.query)
+
+			query, variables := snippet.Bind(tt.dialect)
+			if query != tt.want {
+				t.Errorf("Bind() query = %q, want %q", query, tt.want)
+			}
+			if !reflect.DeepEqual(variables, tt.variables) {
+				t.Errorf("Bind() variables = %q, want %q", variables, tt.variables)
+			}
+			if got := snippet.Variables(tt.dialect); !reflect.DeepEqual(got, tt.variables) {
+				t.Errorf("Variables() = %q, want %q", got, tt.variables)
+			}
+		})
+	}
+}
+
+func TestSnippetBoundQueryParameters(t *testing.T) {
+	snippet := newSnippet(t, "SELECT * FROM orders WHERE customer_id = ${customer} AND placed > ${since}")
+	query, _ := snippet.Bind(postgresDialect)
+
+	parameters, err := domain.ScriptParameters(domain.SplitScript(query, postgresDialect))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range parameters {
+		names = append(names, p.Name)
+	}
+	if want := []string{"customer", "since"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("parameters of the bound query = %q, want %q", names, want)
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import "strconv"
+
+// SQLDialect tells how the scripts of a vendor are split into statements.
+// The zero value lexes plain SQL: quoted text, -- and /* */ comments and
+// statements ending at semicolons.
+type SQLDialect struct {
+	// DollarQuoting reads $tag$...$tag$ as string literals (PostgreSQL)
+	DollarQuoting bool
+	// EscapeStrings lets a backslash escape the next character in E'...'
+	// string literals (PostgreSQL)
+	EscapeStrings bool
+	// NestedComments lets block comments nest (PostgreSQL)
+	NestedComments bool
+	// BackslashEscapes lets a backslash escape the next character in every
+	// quoted string (MySQL)
+	BackslashEscapes bool
+	// HashComments starts line comments with # (MySQL)
+	HashComments bool
+	// SpacedDashComments starts a -- comment only when whitespace or a
+	// control character follows, so a--1 subtracts a negative (MySQL)
+	SpacedDashComments bool
+	// DelimiterCommand accepts the DELIMITER command of the mysql client,
+	// which changes the statement terminator (MySQL)
+	DelimiterCommand bool
+	// BracketIdentifiers reads [name] as a quoted identifier (SQLite)
+	BracketIdentifiers bool
+	// BlockBodies keeps the BEGIN ... END body of a trigger or routine in
+	// its CREATE statement (SQLite, PostgreSQL BEGIN ATOMIC)
+	BlockBodies bool
+	// QuestionPlaceholders reads ? as a placeholder, PostgreSQL has ?
+	// operators instead (MySQL, SQLite)
+	QuestionPlaceholders bool
+	// NumberedPlaceholders binds values to $1, $2... rather than to ?
+	// (PostgreSQL)
+	NumberedPlaceholders bool
+	// Returning lets UPDATE, DELETE and INSERT return the rows they change
+	// with a RETURNING clause (PostgreSQL, SQLite)
+	Returning bool
+	// ModifyingCTEs lets WITH clauses hold UPDATE, DELETE and INSERT
+	// statements returning rows (PostgreSQL)
+	ModifyingCTEs bool
+	// ImplicitCommits commits the open transaction before DDL, LOCK TABLES,
+	// SET autocommit and some administrative statements (MySQL)
+	ImplicitCommits bool
+	// ReadOnlyBegin starts a read-only transaction, empty when the vendor
+	// has none (SQLite, whose read-only connections open the file in ro mode)
+	ReadOnlyBegin string
+}
+
+// ScriptStatement is a statement of a script
+type ScriptStatement struct {
+	// Text is the statement as written, without its terminator
+	Text string
+	// Start and End are the byte range of Text in the script
+	Start, End int
+	// Parameters are the placeholders of the statement, in order
+	Parameters []ScriptParameter
+}
+
+// SplitScript breaks a script into its statements, leaving out empty ones,
+// comments and DELIMITER commands. The ? placeholders are numbered across
+// the script.
+func SplitScript(script string, dialect SQLDialect) []ScriptStatement {
+	statements := splitStatements(script, dialect)
+	split := make([]ScriptStatement, len(statements))
+	positional := 0
+	for i, statement := range statements {
+		split[i] = ScriptStatement{
+			Text:  statement.text,
+			Start: statement.start,
+			End:   statement.end,
+		}
+		for _, p := range statement.params {
+			name := p.name
+			if p.style == PositionalParameter {
+				positional++
+				name = strconv.Itoa(positional)
+			}
+			split[i].Parameters = append(split[i].Parameters, ScriptParameter{
+				QueryParameter: QueryParameter{Name: name, Style: p.style},
+				Start:          p.start - statement.start,
+				End:            p.end - statement.start,
+			})
+		}
+	}
+	return split
+}
+
+// Dialect returns the SQL dialect of the connection's vendor
+func (c *Connection) Dialect() SQLDialect {
+	v, err := LookupVendor(c.vendor)
+	if err != nil {
+		return SQLDialect{}
+	}
+	return v.Dialect
+}
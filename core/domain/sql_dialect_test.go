@@ -0,0 +1,107 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestSplitScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    []domain.ScriptStatement
+	}{
+		{
+			name:    "ranges in the script",
+			script:  "SELECT 1;\n  SELECT 2 ;",
+			dialect: postgresDialect,
+			want: []domain.ScriptStatement{
+				{Text: "SELECT 1", Start: 0, End: 8},
+				{Text: "SELECT 2", Start: 12, End: 20},
+			},
+		},
+		{
+			name:    "numbered placeholders",
+			script:  "SELECT $1; SELECT $2, $1",
+			dialect: postgresDialect,
+			want: []domain.ScriptStatement{
+				{Text: "SELECT $1", Start: 0, End: 9, Parameters: []domain.ScriptParameter{
+					{QueryParameter: domain.QueryParameter{Name: "1", Style: domain.NumberedParameter}, Start: 7, End: 9},
+				}},
+				{Text: "SELECT $2, $1", Start: 11, End: 24, Parameters: []domain.ScriptParameter{
+					{QueryParameter: domain.QueryParameter{Name: "2", Style: domain.NumberedParameter}, Start: 7, End: 9},
+					{QueryParameter: domain.QueryParameter{Name: "1", Style: domain.NumberedParameter}, Start: 11, End: 13},
+				}},
+			},
+		},
+		{
+			name:    "named placeholders",
+			script:  "SELECT :id, :name",
+			dialect: sqliteDialect,
+			want: []domain.ScriptStatement{
+				{Text: "SELECT :id, :name", Start: 0, End: 17, Parameters: []domain.ScriptParameter{
+					{QueryParameter: domain.QueryParameter{Name: "id", Style: domain.NamedParameter}, Start: 7, End: 10},
+					{QueryParameter: domain.QueryParameter{Name: "name", Style: domain.NamedParameter}, Start: 12, End: 17},
+				}},
+			},
+		},
+		{
+			name:    "question marks numbered across the script",
+			script:  "SELECT ?, ?; SELECT ?",
+			dialect: mysqlDialect,
+			want: []domain.ScriptStatement{
+				{Text: "SELECT ?, ?", Start: 0, End: 11, Parameters: []domain.ScriptParameter{
+					{QueryParameter: domain.QueryParameter{Name: "1", Style: domain.PositionalParameter}, Start: 7, End: 8},
+					{QueryParameter: domain.QueryParameter{Name: "2", Style: domain.PositionalParameter}, Start: 10, End: 11},
+				}},
+				{Text: "SELECT ?", Start: 13, End: 21, Parameters: []domain.ScriptParameter{
+					{QueryParameter: domain.QueryParameter{Name: "3", Style: domain.PositionalParameter}, Start: 7, End: 8},
+				}},
+			},
+		},
+		{
+			name:    "DELIMITER commands left out",
+			script:  "DELIMITER $$\nSELECT 1; SELECT 2$$\nDELIMITER ;\nSELECT 3;",
+			dialect: mysqlDialect,
+			want: []domain.ScriptStatement{
+				{Text: "SELECT 1; SELECT 2", Start: 13, End: 31},
+				{Text: "SELECT 3", Start: 46, End: 54},
+			},
+		},
+		{
+			name:    "DELIMITER is a word in other dialects",
+			script:  "DELIMITER $$\nSELECT 1",
+			dialect: sqliteDialect,
+			want: []domain.ScriptStatement{
+				{Text: "DELIMITER $$\nSELECT 1", Start: 0, End: 21},
+			},
+		},
+		{
+			name:    "hash comments only in MySQL",
+			script:  "SELECT 1 # note;\nFROM t",
+			dialect: postgresDialect,
+			want: []domain.ScriptStatement{
+				{Text: "SELECT 1 # note", Start: 0, End: 15},
+				{Text: "FROM t", Start: 17, End: 23},
+			},
+		},
+		{
+			name:    "empty statements left out",
+			script:  ";; -- nothing\n;",
+			dialect: postgresDialect,
+			want:    []domain.ScriptStatement{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := domain.SplitScript(tt.script, tt.dialect)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitScript(%q) = %+v, want %+v", tt.script, got, tt.want)
+			}
+		})
+	}
+}
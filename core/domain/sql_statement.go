@@ -0,0 +1,441 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Markers standing for the literals, quoted identifiers and placeholders of
+// a statement
+const (
+	stringToken     = "'"
+	identifierToken = `"`
+	parameterToken  = "?"
+)
+
+// sqlStatement is a statement of a SQL script reduced to its tokens. Words
+// are upper-cased, comments are dropped and literals and quoted identifiers
+// are replaced by markers, so keywords can be looked for safely.
+type sqlStatement struct {
+	tokens []string
+	// spans are the byte ranges of the tokens in the script
+	spans [][2]int
+	// text is the statement as written, without the trailing semicolon
+	text string
+	// start and end are the byte range of text in the script
+	start, end int
+	// params are the placeholders of the statement, in order
+	params []scriptParam
+}
+
+// scriptParam is a placeholder found in a script
+type scriptParam struct {
+	// name is the name of a :name placeholder or the number of a $1 one, and
+	// empty for ? placeholders, which are numbered across the script
+	name  string
+	style string
+	// start and end are the byte range of the placeholder in the script
+	start, end int
+}
+
+// splitStatements breaks a script into statements on the terminators outside
+// comments, literals, quoted identifiers and, depending on the dialect,
+// dollar-quoted strings and trigger or routine bodies. Empty statements are
+// left out.
+func splitStatements(script string, dialect SQLDialect) []sqlStatement {
+	var statements []sqlStatement
+	var current []string
+	var spans [][2]int
+	var params []scriptParam
+	start, end := -1, -1
+	delimiter := ";"
+	// depth counts the BEGIN and CASE blocks left open in a body
+	depth := 0
+
+	add := func(token string, from, to int) {
+		if start < 0 {
+			start = from
+		}
+		end = to
+		current = append(current, token)
+		spans = append(spans, [2]int{from, to})
+	}
+	flush := func() {
+		if len(current) > 0 {
+			statements = append(statements, sqlStatement{
+				tokens: current,
+				spans:  spans,
+				text:   script[start:end],
+				start:  start,
+				end:    end,
+				params: params,
+			})
+		}
+		current = nil
+		spans = nil
+		params = nil
+		start, end = -1, -1
+		depth = 0
+	}
+
+	for i := 0; i < len(script); {
+		if dialect.DelimiterCommand && len(current) == 0 {
+			if next, ok := delimiterCommand(script, i); ok {
+				delimiter = strings.TrimSpace(script[i+len("DELIMITER") : next])
+				if delimiter == "" {
+					delimiter = ";"
+				}
+				i = next
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(script[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case dashComment(script, i, dialect) || (dialect.HashComments && r == '#'):
+			if newline := strings.IndexByte(script[i:], '\n'); newline >= 0 {
+				i += newline
+			} else {
+				i = len(script)
+			}
+
+		case strings.HasPrefix(script[i:], "/*"):
+			i = skipBlockComment(script, i, dialect.NestedComments)
+
+		case strings.HasPrefix(script[i:], delimiter):
+			if depth > 0 {
+				add(delimiter, i, i+len(delimiter))
+			} else {
+				flush()
+			}
+			i += len(delimiter)
+
+		case r == '\'' || r == '"' || r == '`':
+			escapes := dialect.BackslashEscapes && r != '`' ||
+				dialect.EscapeStrings && r == '\'' && escapeStringPrefix(script, i)
+			next := skipQuoted(script, i, byte(r), escapes)
+			if r == '\'' {
+				add(stringToken, i, next)
+			} else {
+				add(identifierToken, i, next)
+			}
+			i = next
+
+		case r == '[' && dialect.BracketIdentifiers:
+			next := len(script)
+			if closing := strings.IndexByte(script[i:], ']'); closing >= 0 {
+				next = i + closing + 1
+			}
+			add(identifierToken, i, next)
+			i = next
+
+		case isParameterStart(script, i, dialect) && bindsParameters(current) && !(r == ':' && inSubscript(current)):
+			next := i + 1
+			if r != '?' {
+				for next < len(script) {
+					r, size := utf8.DecodeRuneInString(script[next:])
+					if !isWordRune(r) {
+						break
+					}
+					next += size
+				}
+			}
+			param := scriptParam{name: script[i+1 : next], start: i, end: next}
+			switch r {
+			case ':':
+				param.style = NamedParameter
+			case '$':
+				param.style = NumberedParameter
+			default:
+				param.style = PositionalParameter
+			}
+			add(parameterToken, i, next)
+			params = append(params, param)
+			i = next
+
+		case r == '$' && dialect.DollarQuoting:
+			if next, ok := skipDollarQuoted(script, i); ok {
+				add(stringToken, i, next)
+				i = next
+			} else {
+				add("$", i, i+1)
+				i++
+			}
+
+		case isWordRune(r):
+			next := i
+			for next < len(script) {
+				r, size := utf8.DecodeRuneInString(script[next:])
+				if !isWordRune(r) {
+					break
+				}
+				next += size
+			}
+			word := strings.ToUpper(script[i:next])
+			add(word, i, next)
+			if dialect.BlockBodies {
+				depth = blockDepth(current, word, depth)
+			}
+			i = next
+
+		default:
+			add(string(r), i, i+size)
+			i += size
+		}
+	}
+	flush()
+
+	return statements
+}
+
+// dashComment reports whether a -- line comment starts at i
+func dashComment(script string, i int, dialect SQLDialect) bool {
+	if !strings.HasPrefix(script[i:], "--") {
+		return false
+	}
+	if !dialect.SpacedDashComments || i+2 == len(script) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(script[i+2:])
+	return unicode.IsSpace(r) || unicode.IsControl(r)
+}
+
+// delimiterCommand returns the end of the line when a DELIMITER command
+// starts at i
+func delimiterCommand(script string, i int) (int, bool) {
+	const command = "DELIMITER"
+	if len(script) <= i+len(command) || !strings.EqualFold(script[i:i+len(command)], command) {
+		return 0, false
+	}
+	if c := script[i+len(command)]; c != ' ' && c != '\t' {
+		return 0, false
+	}
+	if newline := strings.IndexByte(script[i:], '\n'); newline >= 0 {
+		return i + newline, true
+	}
+	return len(script), true
+}
+
+// blockDepth tracks the BEGIN ... END blocks in the body of a trigger or
+// routine, where semicolons do not end the statement. CASE expressions end
+// with END too, so they are counted as blocks.
+func blockDepth(tokens []string, word string, depth int) int {
+	if tokens[0] != "CREATE" {
+		return depth
+	}
+	switch word {
+	case "BEGIN", "CASE":
+		if slices.Contains(tokens, "TRIGGER") || slices.Contains(tokens, "FUNCTION") || slices.Contains(tokens, "PROCEDURE") {
+			return depth + 1
+		}
+	case "END":
+		return max(depth-1, 0)
+	}
+	return depth
+}
+
+// skipBlockComment returns the position after the block comment starting at i
+func skipBlockComment(script string, i int, nested bool) int {
+	level := 0
+	for i < len(script) {
+		switch {
+		case strings.HasPrefix(script[i:], "/*"):
+			if level == 0 || nested {
+				level++
+			}
+			i += 2
+		case strings.HasPrefix(script[i:], "*/"):
+			level--
+			i += 2
+			if level == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+	return len(script)
+}
+
+// skipQuoted returns the position after the quoted text starting at i, where
+// a doubled quote stands for the quote itself and, with escapes, a backslash
+// escapes the next character
+func skipQuoted(script string, i int, quote byte, escapes bool) int {
+	for i++; i < len(script); i++ {
+		if escapes && script[i] == '\\' {
+			i++
+			continue
+		}
+		if script[i] == quote {
+			if i+1 < len(script) && script[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(script)
+}
+
+// escapeStringPrefix reports whether the string literal at i is an E'...'
+// escape string
+func escapeStringPrefix(script string, i int) bool {
+	if i == 0 || (script[i-1] != 'E' && script[i-1] != 'e') {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(script[:i-1])
+	return i == 1 || !isWordRune(before)
+}
+
+// skipDollarQuoted returns the position after the $tag$...$tag$ string
+// starting at i, if there is one there
+func skipDollarQuoted(script string, i int) (int, bool) {
+	end := i + 1
+	for end < len(script) && script[end] != '$' {
+		r, size := utf8.DecodeRuneInString(script[end:])
+		if !isWordRune(r) || (unicode.IsDigit(r) && end == i+1) {
+			return 0, false
+		}
+		end += size
+	}
+	if end >= len(script) {
+		return 0, false
+	}
+
+	tag := script[i : end+1]
+	closing := strings.Index(script[end+1:], tag)
+	if closing < 0 {
+		return len(script), true
+	}
+	return end + 1 + closing + len(tag), true
+}
+
+// isParameterStart reports whether a placeholder starts at i: :name, $1 or,
+// with QuestionPlaceholders, ?. A colon or dollar sign right after a word or
+// another colon is part of something else, such as a :: cast or an array
+// slice.
+func isParameterStart(script string, i int, dialect SQLDialect) bool {
+	if script[i] == '?' {
+		return dialect.QuestionPlaceholders
+	}
+	if script[i] != ':' && script[i] != '$' {
+		return false
+	}
+	if i > 0 {
+		before, _ := utf8.DecodeLastRuneInString(script[:i])
+		if before == ':' || before == '$' || isWordRune(before) {
+			return false
+		}
+	}
+
+	after, _ := utf8.DecodeRuneInString(script[i+1:])
+	if script[i] == ':' {
+		return after == '_' || unicode.IsLetter(after)
+	}
+	return unicode.IsDigit(after)
+}
+
+// inSubscript reports whether the statement begun with tokens is within the
+// brackets of an array subscript, as a[:n], where a colon starts a slice
+// bound. The brackets of an ARRAY[...] constructor hold values and are not.
+func inSubscript(tokens []string) bool {
+	var subscripts []bool
+	for i, token := range tokens {
+		switch token {
+		case "[":
+			subscript := false
+			if i > 0 {
+				before := tokens[i-1]
+				r, _ := utf8.DecodeRuneInString(before)
+				subscript = before != "ARRAY" && (isWordRune(r) || before == identifierToken || before == parameterToken || before == ")" || before == "]")
+			}
+			subscripts = append(subscripts, subscript)
+		case "]":
+			if len(subscripts) > 0 {
+				subscripts = subscripts[:len(subscripts)-1]
+			}
+		}
+	}
+	return len(subscripts) > 0 && subscripts[len(subscripts)-1]
+}
+
+// bindsParameters reports whether the placeholders of the statement begun
+// with tokens are bound when it runs. In PREPARE ... AS and in the definition
+// of a routine or trigger they stand for the arguments it is called with.
+func bindsParameters(tokens []string) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	switch tokens[0] {
+	case "PREPARE":
+		return !slices.Contains(tokens, "AS")
+	case "CREATE":
+		return !slices.Contains(tokens, "FUNCTION") && !slices.Contains(tokens, "PROCEDURE") && !slices.Contains(tokens, "TRIGGER")
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// keyword returns the first word of the statement, past any opening parenthesis
+func (s sqlStatement) keyword() string {
+	for _, token := range s.tokens {
+		if token != "(" {
+			return token
+		}
+	}
+	return ""
+}
+
+// keywordIndex returns the index of the first word of the statement, past
+// any opening parenthesis
+func (s sqlStatement) keywordIndex() int {
+	for i, token := range s.tokens {
+		if token != "(" {
+			return i
+		}
+	}
+	return len(s.tokens)
+}
+
+// has reports whether the statement contains any of the words
+func (s sqlStatement) has(words ...string) (string, bool) {
+	for _, token := range s.tokens {
+		for _, word := range words {
+			if token == word {
+				return word, true
+			}
+		}
+	}
+	return "", false
+}
+
+// indexTop returns the first token from i, outside parentheses, that is one
+// of the words, -1 when there is none
+func (s sqlStatement) indexTop(i int, words ...string) int {
+	depth := 0
+	for ; i < len(s.tokens); i++ {
+		switch token := s.tokens[i]; token {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		default:
+			if depth == 0 {
+				for _, word := range words {
+					if token == word {
+						return i
+					}
+				}
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,324 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+	"seagle/core/domain/vendors/mysql"
+	"seagle/core/domain/vendors/postgresql"
+	"seagle/core/domain/vendors/sqlite"
+)
+
+// The dialects the vendors register
+var (
+	postgresDialect = postgresql.Vendor.Dialect
+	mysqlDialect    = mysql.Vendor.Dialect
+	sqliteDialect   = sqlite.Vendor.Dialect
+)
+
+func TestSplitScriptStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    []string
+	}{
+		{
+			name:   "terminators",
+			script: "SELECT 1; SELECT 2;\n\n;SELECT 3",
+			want:   []string{"SELECT 1", "SELECT 2", "SELECT 3"},
+		},
+		{
+			name:   "semicolons in literals and identifiers",
+			script: `SELECT 'a;b', "c;d"; SELECT 'it''s;'`,
+			want:   []string{`SELECT 'a;b', "c;d"`, `SELECT 'it''s;'`},
+		},
+		{
+			name:   "comments",
+			script: "SELECT 1 -- one; two\n; /* three; */ SELECT 2",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:    "dollar quotes",
+			script:  "DO $$ BEGIN RAISE NOTICE 'a;b'; END $$; SELECT 1",
+			dialect: postgresDialect,
+			want:    []string{"DO $$ BEGIN RAISE NOTICE 'a;b'; END $$", "SELECT 1"},
+		},
+		{
+			name:    "tagged dollar quotes",
+			script:  "SELECT $fn$ a; $$ b; $fn$; SELECT 2",
+			dialect: postgresDialect,
+			want:    []string{"SELECT $fn$ a; $$ b; $fn$", "SELECT 2"},
+		},
+		{
+			name:    "unterminated dollar quote",
+			script:  "SELECT $x$ a; b",
+			dialect: postgresDialect,
+			want:    []string{"SELECT $x$ a; b"},
+		},
+		{
+			name:    "dollar quotes off",
+			script:  "SELECT $$; SELECT 2",
+			dialect: mysqlDialect,
+			want:    []string{"SELECT $$", "SELECT 2"},
+		},
+		{
+			name:    "E-string escapes",
+			script:  `SELECT E'it\'s; here'; SELECT 'C:\'; SELECT 2`,
+			dialect: postgresDialect,
+			want:    []string{`SELECT E'it\'s; here'`, `SELECT 'C:\'`, "SELECT 2"},
+		},
+		{
+			name:    "E after a word is not an E-string",
+			script:  `SELECT 'a' LIKE 'b\'; SELECT 2`,
+			dialect: postgresDialect,
+			want:    []string{`SELECT 'a' LIKE 'b\'`, "SELECT 2"},
+		},
+		{
+			name:    "backslash escapes",
+			script:  `SELECT 'it\'s; here'; SELECT 2`,
+			dialect: mysqlDialect,
+			want:    []string{`SELECT 'it\'s; here'`, "SELECT 2"},
+		},
+		{
+			name:    "nested comments",
+			script:  "SELECT 1 /* a /* b; */ c; */; SELECT 2",
+			dialect: postgresDialect,
+			want:    []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:    "hash comments",
+			script:  "SELECT 1 # one; two\n; SELECT 2",
+			dialect: mysqlDialect,
+			want:    []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:    "dashes without a space in MySQL",
+			script:  "UPDATE t SET a=a--1 WHERE id=1;\nDELETE FROM t",
+			dialect: mysqlDialect,
+			want:    []string{"UPDATE t SET a=a--1 WHERE id=1", "DELETE FROM t"},
+		},
+		{
+			name:    "dashes before a number in MySQL",
+			script:  "SELECT 1--1;\nSELECT 2",
+			dialect: mysqlDialect,
+			want:    []string{"SELECT 1--1", "SELECT 2"},
+		},
+		{
+			name:    "dash comments in MySQL",
+			script:  "SELECT 1 -- one; two\n; SELECT 2 --\tthree;\n; SELECT 3 --",
+			dialect: mysqlDialect,
+			want:    []string{"SELECT 1", "SELECT 2", "SELECT 3"},
+		},
+		{
+			name:    "dashes without a space elsewhere",
+			script:  "SELECT 1--1;\nSELECT 2",
+			dialect: postgresDialect,
+			want:    []string{"SELECT 1--1;\nSELECT 2"},
+		},
+		{
+			name:    "delimiter command",
+			script:  "DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END//\nDELIMITER ;\nCALL p();",
+			dialect: mysqlDialect,
+			want:    []string{"CREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END", "CALL p()"},
+		},
+		{
+			name:    "trigger body",
+			script:  "CREATE TRIGGER t AFTER INSERT ON a BEGIN UPDATE b SET n = CASE WHEN n > 0 THEN n END; DELETE FROM c; END; SELECT 1",
+			dialect: sqliteDialect,
+			want:    []string{"CREATE TRIGGER t AFTER INSERT ON a BEGIN UPDATE b SET n = CASE WHEN n > 0 THEN n END; DELETE FROM c; END", "SELECT 1"},
+		},
+		{
+			name:    "BEGIN ATOMIC body",
+			script:  "CREATE FUNCTION f() RETURNS int LANGUAGE SQL BEGIN ATOMIC SELECT 1; END; SELECT 2",
+			dialect: postgresDialect,
+			want:    []string{"CREATE FUNCTION f() RETURNS int LANGUAGE SQL BEGIN ATOMIC SELECT 1; END", "SELECT 2"},
+		},
+		{
+			name:    "BEGIN outside a body",
+			script:  "BEGIN; UPDATE a SET n = 1; COMMIT",
+			dialect: sqliteDialect,
+			want:    []string{"BEGIN", "UPDATE a SET n = 1", "COMMIT"},
+		},
+		{
+			name:    "bracket identifiers",
+			script:  "SELECT [a;b] FROM t; SELECT 2",
+			dialect: sqliteDialect,
+			want:    []string{"SELECT [a;b] FROM t", "SELECT 2"},
+		},
+		{
+			name:   "only comments",
+			script: "-- nothing\n/* here */",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, statement := range domain.SplitScript(tt.script, tt.dialect) {
+				got = append(got, statement.Text)
+				if tt.script[statement.Start:statement.End] != statement.Text {
+					t.Errorf("range [%d:%d] = %q, want %q", statement.Start, statement.End, tt.script[statement.Start:statement.End], statement.Text)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitScript(%q) = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatementTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    []string
+	}{
+		{
+			name:   "words are upper-cased",
+			script: "select a from t",
+			want:   []string{"SELECT", "A", "FROM", "T"},
+		},
+		{
+			name:   "literals and identifiers become markers",
+			script: `SELECT 'DROP', "DELETE" FROM t`,
+			want:   []string{"SELECT", domain.StringToken, ",", domain.IdentifierToken, "FROM", "T"},
+		},
+		{
+			name:    "dollar quotes become string markers",
+			script:  "SELECT $$DROP TABLE t$$",
+			dialect: postgresDialect,
+			want:    []string{"SELECT", domain.StringToken},
+		},
+		{
+			name:    "placeholders become markers",
+			script:  "SELECT * FROM t WHERE a = $1 AND b = :b",
+			dialect: postgresDialect,
+			want:    []string{"SELECT", "*", "FROM", "T", "WHERE", "A", "=", domain.ParameterToken, "AND", "B", "=", domain.ParameterToken},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := domain.StatementTokens(tt.script, tt.dialect)
+			if len(statements) != 1 {
+				t.Fatalf("StatementTokens(%q) returned %d statements, want 1", tt.script, len(statements))
+			}
+			if got := statements[0]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokens = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitScriptPlaceholders(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    []string
+	}{
+		{
+			name:    "named and numbered",
+			script:  "SELECT :name, $1, :other_2",
+			dialect: postgresDialect,
+			want:    []string{":name", "$1", ":other_2"},
+		},
+		{
+			name:    "casts are not placeholders",
+			script:  "SELECT a::text, :x::int",
+			dialect: postgresDialect,
+			want:    []string{":x"},
+		},
+		{
+			name:    "array slices are not placeholders",
+			script:  "SELECT a[1:2], a[:n], a[n:], (f(x))[:m], a[1][:k]",
+			dialect: postgresDialect,
+			want:    nil,
+		},
+		{
+			name:    "array constructors hold placeholders",
+			script:  "SELECT ARRAY[:a, :b], a[ARRAY[1][1]] FROM t WHERE c = :c",
+			dialect: postgresDialect,
+			want:    []string{":a", ":b", ":c"},
+		},
+		{
+			name:    "not in literals, identifiers or comments",
+			script:  `SELECT ':a', "$1", $$ :b $$, E'\' :c' -- :d` + "\n/* $2 */",
+			dialect: postgresDialect,
+			want:    nil,
+		},
+		{
+			name:    "dollar after a word",
+			script:  "SELECT a$1 FROM t",
+			dialect: postgresDialect,
+			want:    nil,
+		},
+		{
+			name:    "question marks are operators without QuestionPlaceholders",
+			script:  "SELECT data ? 'key'",
+			dialect: postgresDialect,
+			want:    nil,
+		},
+		{
+			name:    "question marks",
+			script:  "SELECT ?, ? FROM t WHERE a = '?'",
+			dialect: mysqlDialect,
+			want:    []string{"?", "?"},
+		},
+		{
+			name:    "PREPARE ... AS",
+			script:  "PREPARE p(int) AS SELECT $1",
+			dialect: postgresDialect,
+			want:    nil,
+		},
+		{
+			name:    "routine body",
+			script:  "CREATE FUNCTION f(int) RETURNS int LANGUAGE SQL RETURN $1 + :x",
+			dialect: postgresDialect,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, statement := range domain.SplitScript(tt.script, tt.dialect) {
+				for _, param := range statement.Parameters {
+					got = append(got, statement.Text[param.Start:param.End])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("placeholders of %q = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageableSelect(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "select", query: "SELECT * FROM t;", want: true},
+		{name: "values", query: "VALUES (1), (2)", want: true},
+		{name: "common table expressions", query: "WITH a AS (SELECT 1) SELECT * FROM a", want: true},
+		{name: "writing common table expressions", query: "WITH a AS (DELETE FROM t RETURNING *) SELECT * FROM a"},
+		{name: "select into", query: "SELECT * INTO copy FROM t"},
+		{name: "into in a literal", query: "SELECT 'into' FROM t", want: true},
+		{name: "several statements", query: "SELECT 1; SELECT 2"},
+		{name: "update", query: "UPDATE t SET a = 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := domain.PageableSelect(tt.query, postgresDialect); got != tt.want {
+				t.Errorf("PageableSelect(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	defaultSSHPort              = 22
+	defaultSSHKeepAliveInterval = 30 * time.Second
+	sshDialTimeout              = 15 * time.Second
+)
+
+// SSHTunnelConfig holds the bastion host settings used to reach a database.
+// Exactly one authentication method is used, in order of preference: private
+// key file, password, then the SSH agent socket.
+type SSHTunnelConfig struct {
+	Host           string
+	Port           int
+	User           string
+	Password       string
+	PrivateKeyFile string
+	Passphrase     string
+	// PasswordRef and PassphraseRef read the password and the key passphrase
+	// from an environment variable or a file instead
+	PasswordRef   string
+	PassphraseRef string
+	AgentSocket   string
+	// KnownHostsFile defaults to ~/.ssh/known_hosts
+	KnownHostsFile string
+	// KeepAliveInterval defaults to 30 seconds
+	KeepAliveInterval time.Duration
+}
+
+// Validate checks that the tunnel can be opened with the given settings
+func (t *SSHTunnelConfig) Validate() error {
+	if t.Host == "" {
+		return fmt.Errorf("SSH host is required")
+	}
+	if t.User == "" {
+		return fmt.Errorf("SSH user is required")
+	}
+	if t.PrivateKeyFile == "" && t.Password == "" && t.PasswordRef == "" && t.agentSocket() == "" {
+		return fmt.Errorf("SSH password, private key file or agent socket is required")
+	}
+	for _, ref := range []string{t.PasswordRef, t.PassphraseRef} {
+		if ref != "" {
+			if err := ValidateSecretRef(ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Map returns the settings in their persisted form
+func (t *SSHTunnelConfig) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"host":              t.Host,
+		"port":              t.Port,
+		"user":              t.User,
+		"password":          t.Password,
+		"privateKeyFile":    t.PrivateKeyFile,
+		"passphrase":        t.Passphrase,
+		"passwordRef":       t.PasswordRef,
+		"passphraseRef":     t.PassphraseRef,
+		"agentSocket":       t.AgentSocket,
+		"knownHostsFile":    t.KnownHostsFile,
+		"keepAliveInterval": int(t.KeepAliveInterval / time.Second),
+	}
+}
+
+// NewSSHTunnelConfigFromMap restores settings persisted with Map
+func NewSSHTunnelConfigFromMap(data map[string]interface{}) *SSHTunnelConfig {
+	port, _ := data["port"].(float64)
+	keepAlive, _ := data["keepAliveInterval"].(float64)
+	host, _ := data["host"].(string)
+	user, _ := data["user"].(string)
+	password, _ := data["password"].(string)
+	privateKeyFile, _ := data["privateKeyFile"].(string)
+	passphrase, _ := data["passphrase"].(string)
+	passwordRef, _ := data["passwordRef"].(string)
+	passphraseRef, _ := data["passphraseRef"].(string)
+	agentSocket, _ := data["agentSocket"].(string)
+	knownHostsFile, _ := data["knownHostsFile"].(string)
+
+	return &SSHTunnelConfig{
+		Host:              host,
+		Port:              int(port),
+		User:              user,
+		Password:          password,
+		PrivateKeyFile:    privateKeyFile,
+		Passphrase:        passphrase,
+		PasswordRef:       passwordRef,
+		PassphraseRef:     passphraseRef,
+		AgentSocket:       agentSocket,
+		KnownHostsFile:    knownHostsFile,
+		KeepAliveInterval: time.Duration(keepAlive) * time.Second,
+	}
+}
+
+func (t *SSHTunnelConfig) address() string {
+	port := t.Port
+	if port == 0 {
+		port = defaultSSHPort
+	}
+	return net.JoinHostPort(t.Host, strconv.Itoa(port))
+}
+
+func (t *SSHTunnelConfig) agentSocket() string {
+	if t.AgentSocket != "" {
+		return t.AgentSocket
+	}
+	return os.Getenv("SSH_AUTH_SOCK")
+}
+
+func (t *SSHTunnelConfig) knownHostsFile() (string, error) {
+	if t.KnownHostsFile != "" {
+		return t.KnownHostsFile, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve known_hosts file: %w", err)
+	}
+	return filepath.Join(homeDir, ".ssh", "known_hosts"), nil
+}
+
+// clientConfig builds the SSH client configuration, verifying the bastion
+// host key against the known_hosts file
+func (t *SSHTunnelConfig) clientConfig() (*ssh.ClientConfig, io.Closer, error) {
+	knownHostsFile, err := t.knownHostsFile()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load known hosts from %s: %w", knownHostsFile, err)
+	}
+
+	var auth ssh.AuthMethod
+	var agentConn io.Closer
+
+	switch {
+	case t.PrivateKeyFile != "":
+		key, err := os.ReadFile(t.PrivateKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read SSH private key: %w", err)
+		}
+
+		var signer ssh.Signer
+		if t.Passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(t.Passphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse SSH private key: %w", err)
+		}
+		auth = ssh.PublicKeys(signer)
+	case t.Password != "":
+		auth = ssh.Password(t.Password)
+	default:
+		conn, err := net.Dial("unix", t.agentSocket())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to SSH agent: %w", err)
+		}
+		auth = ssh.PublicKeysCallback(agent.NewClient(conn).Signers)
+		agentConn = conn
+	}
+
+	return &ssh.ClientConfig{
+		User: t.User,
+		Auth: []ssh.AuthMethod{auth},
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if err := hostKeyCallback(hostname, remote, key); err != nil {
+				var keyErr *knownhosts.KeyError
+				if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+					return fmt.Errorf("host key of %s is not in %s, add it with ssh-keyscan before connecting", hostname, knownHostsFile)
+				}
+				return err
+			}
+			return nil
+		},
+		Timeout: sshDialTimeout,
+	}, agentConn, nil
+}
+
+// sshTunnel forwards connections accepted on a local port to a remote
+// address through an SSH client
+type sshTunnel struct {
+	client   *ssh.Client
+	listener net.Listener
+	remote   string
+	done     chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+}
+
+// openSSHTunnel connects to the bastion host and starts forwarding a local
+// port to remoteAddr
+func openSSHTunnel(cfg *SSHTunnelConfig, remoteAddr string) (*sshTunnel, error) {
+	clientConfig, agentConn, err := cfg.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if agentConn != nil {
+		defer agentConn.Close()
+	}
+
+	client, err := ssh.Dial("tcp", cfg.address(), clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SSH host %s: %w", cfg.address(), err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to listen for SSH tunnel: %w", err)
+	}
+
+	t := &sshTunnel{
+		client:   client,
+		listener: listener,
+		remote:   remoteAddr,
+		done:     make(chan struct{}),
+	}
+
+	keepAlive := cfg.KeepAliveInterval
+	if keepAlive <= 0 {
+		keepAlive = defaultSSHKeepAliveInterval
+	}
+
+	t.wg.Add(2)
+	go t.accept()
+	go t.keepAlive(keepAlive)
+
+	return t, nil
+}
+
+// LocalPort returns the loopback port forwarded to the remote address
+func (t *sshTunnel) LocalPort() int {
+	return t.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Alive reports whether the tunnel is still forwarding connections
+func (t *sshTunnel) Alive() bool {
+	select {
+	case <-t.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Close stops forwarding and closes the SSH connection
+func (t *sshTunnel) Close() error {
+	var err error
+	t.once.Do(func() {
+		close(t.done)
+		t.listener.Close()
+		err = t.client.Close()
+	})
+	t.wg.Wait()
+	return err
+}
+
+func (t *sshTunnel) accept() {
+	defer t.wg.Done()
+
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go t.forward(local)
+	}
+}
+
+func (t *sshTunnel) forward(local net.Conn) {
+	defer local.Close()
+
+	remote, err := t.client.Dial("tcp", t.remote)
+	if err != nil {
+		return
+	}
+	defer remote.Close()
+
+	copyDone := make(chan struct{}, 2)
+	go func() {
+		io.Copy(remote, local)
+		copyDone <- struct{}{}
+	}()
+	go func() {
+		io.Copy(local, remote)
+		copyDone <- struct{}{}
+	}()
+
+	select {
+	case <-copyDone:
+	case <-t.done:
+	}
+}
+
+// keepAlive pings the SSH server so idle tunnels are not dropped by
+// firewalls, and tears the tunnel down when the server stops answering
+func (t *sshTunnel) keepAlive(interval time.Duration) {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			if _, _, err := t.client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				go t.Close()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,436 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestSSHTunnelConfigValidate(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	tests := []struct {
+		name    string
+		config  SSHTunnelConfig
+		wantErr string
+	}{
+		{
+			name:   "password",
+			config: SSHTunnelConfig{Host: "bastion", User: "deploy", Password: "s3cret"},
+		},
+		{
+			name:   "private key",
+			config: SSHTunnelConfig{Host: "bastion", User: "deploy", PrivateKeyFile: "/home/deploy/.ssh/id_ed25519"},
+		},
+		{
+			name:   "password reference",
+			config: SSHTunnelConfig{Host: "bastion", User: "deploy", PasswordRef: "${env:BASTION_PASSWORD}"},
+		},
+		{
+			name:   "agent socket",
+			config: SSHTunnelConfig{Host: "bastion", User: "deploy", AgentSocket: "/tmp/agent.sock"},
+		},
+		{
+			name:    "no host",
+			config:  SSHTunnelConfig{User: "deploy", Password: "s3cret"},
+			wantErr: "SSH host is required",
+		},
+		{
+			name:    "no user",
+			config:  SSHTunnelConfig{Host: "bastion", Password: "s3cret"},
+			wantErr: "SSH user is required",
+		},
+		{
+			name:    "no way to authenticate",
+			config:  SSHTunnelConfig{Host: "bastion", User: "deploy"},
+			wantErr: "SSH password, private key file or agent socket is required",
+		},
+		{
+			name:    "bad passphrase reference",
+			config:  SSHTunnelConfig{Host: "bastion", User: "deploy", PrivateKeyFile: "/home/deploy/.ssh/id_ed25519", PassphraseRef: "vault"},
+			wantErr: "secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSSHTunnelConfigValidateUsesAgentFromEnvironment(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+
+	config := SSHTunnelConfig{Host: "bastion", User: "deploy"}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() with SSH_AUTH_SOCK set error = %v", err)
+	}
+}
+
+func TestSSHTunnelConfigMap(t *testing.T) {
+	config := &SSHTunnelConfig{
+		Host:              "bastion.example.com",
+		Port:              2222,
+		User:              "deploy",
+		Password:          "s3cret",
+		PrivateKeyFile:    "/home/deploy/.ssh/id_ed25519",
+		Passphrase:        "vault:connections/a/ssh-passphrase",
+		PasswordRef:       "${env:BASTION_PASSWORD}",
+		PassphraseRef:     "file:/run/secrets/passphrase",
+		AgentSocket:       "/tmp/agent.sock",
+		KnownHostsFile:    "/home/deploy/.ssh/known_hosts",
+		KeepAliveInterval: 45 * time.Second,
+	}
+
+	// The settings are persisted as JSON, which reads numbers back as float64
+	data, err := json.Marshal(config.Map())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var persisted map[string]interface{}
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := NewSSHTunnelConfigFromMap(persisted); !reflect.DeepEqual(got, config) {
+		t.Errorf("NewSSHTunnelConfigFromMap(Map()) = %+v, want %+v", got, config)
+	}
+	if got := NewSSHTunnelConfigFromMap(map[string]interface{}{}); !reflect.DeepEqual(got, &SSHTunnelConfig{}) {
+		t.Errorf("NewSSHTunnelConfigFromMap() of no settings = %+v", got)
+	}
+}
+
+// testSSHServer accepts one connection at a time, authenticated with the
+// password s3cret or the client key, and records the method used
+type testSSHServer struct {
+	addr    string
+	hostKey ssh.Signer
+	methods chan string
+}
+
+func newTestSSHServer(t *testing.T, clientKey ssh.PublicKey) *testSSHServer {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testSSHServer{hostKey: hostKey, methods: make(chan string, 10)}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "deploy" && string(password) == "s3cret" {
+				s.methods <- "password"
+				return nil, nil
+			}
+			return nil, fmt.Errorf("wrong password")
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "deploy" && string(key.Marshal()) == string(clientKey.Marshal()) {
+				s.methods <- "publickey"
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown key")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	s.addr = listener.Addr().String()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, channels, requests, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(requests)
+				for channel := range channels {
+					channel.Reject(ssh.Prohibited, "no channels")
+				}
+			}()
+		}
+	}()
+	return s
+}
+
+// knownHosts writes a known_hosts file listing the key for the server
+func (s *testSSHServer) knownHosts(t *testing.T, key ssh.PublicKey) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(file, []byte(knownhosts.Line([]string{s.addr}, key)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// dial connects to the server with the client configuration of the tunnel
+func (s *testSSHServer) dial(config *SSHTunnelConfig) error {
+	clientConfig, agentConn, err := config.clientConfig()
+	if err != nil {
+		return err
+	}
+	if agentConn != nil {
+		defer agentConn.Close()
+	}
+
+	client, err := ssh.Dial("tcp", s.addr, clientConfig)
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
+
+// serveAgent serves an SSH agent holding the key on a socket of a temporary
+// directory
+func serveAgent(t *testing.T, key ed25519.PrivateKey) string {
+	t.Helper()
+
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: key}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unix socket paths are short, t.TempDir() may be too long for one
+	dir, err := os.MkdirTemp("", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "agent.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+	return socket
+}
+
+func TestSSHTunnelConfigAuthentication(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKey, err := ssh.NewPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newTestSSHServer(t, clientKey)
+	knownHosts := server.knownHosts(t, server.hostKey.PublicKey())
+
+	dir := t.TempDir()
+	writeKey := func(name string, block *pem.Block, err error) string {
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	block, err := ssh.MarshalPrivateKey(private, "")
+	plainKey := writeKey("id_ed25519", block, err)
+	block, err = ssh.MarshalPrivateKeyWithPassphrase(private, "", []byte("open sesame"))
+	encryptedKey := writeKey("id_ed25519_encrypted", block, err)
+	agentSocket := serveAgent(t, private)
+
+	tests := []struct {
+		name    string
+		config  SSHTunnelConfig
+		method  string
+		wantErr string
+	}{
+		{
+			name:   "private key",
+			config: SSHTunnelConfig{PrivateKeyFile: plainKey},
+			method: "publickey",
+		},
+		{
+			name:   "private key with a passphrase",
+			config: SSHTunnelConfig{PrivateKeyFile: encryptedKey, Passphrase: "open sesame"},
+			method: "publickey",
+		},
+		{
+			name:   "private key before password",
+			config: SSHTunnelConfig{PrivateKeyFile: plainKey, Password: "wrong"},
+			method: "publickey",
+		},
+		{
+			name:   "password",
+			config: SSHTunnelConfig{Password: "s3cret"},
+			method: "password",
+		},
+		{
+			name:   "password before agent",
+			config: SSHTunnelConfig{Password: "s3cret", AgentSocket: filepath.Join(dir, "missing.sock")},
+			method: "password",
+		},
+		{
+			name:   "agent",
+			config: SSHTunnelConfig{AgentSocket: agentSocket},
+			method: "publickey",
+		},
+		{
+			name:    "wrong passphrase",
+			config:  SSHTunnelConfig{PrivateKeyFile: encryptedKey, Passphrase: "wrong"},
+			wantErr: "failed to parse SSH private key",
+		},
+		{
+			name:    "missing private key",
+			config:  SSHTunnelConfig{PrivateKeyFile: filepath.Join(dir, "missing")},
+			wantErr: "failed to read SSH private key",
+		},
+		{
+			name:    "missing agent",
+			config:  SSHTunnelConfig{AgentSocket: filepath.Join(dir, "missing.sock")},
+			wantErr: "failed to connect to SSH agent",
+		},
+		{
+			name:    "wrong password",
+			config:  SSHTunnelConfig{Password: "wrong"},
+			wantErr: "unable to authenticate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.User = "deploy"
+			config.KnownHostsFile = knownHosts
+
+			err := server.dial(&config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("dial error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dial error = %v", err)
+			}
+
+			select {
+			case method := <-server.methods:
+				if method != tt.method {
+					t.Errorf("authenticated with %s, want %s", method, tt.method)
+				}
+			default:
+				t.Errorf("the server saw no authentication")
+			}
+		})
+	}
+}
+
+func TestSSHTunnelConfigKnownHosts(t *testing.T) {
+	server := newTestSSHServer(t, nil)
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ssh.NewSignerFromKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A file that lists another host only
+	unlisted := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(unlisted, []byte(knownhosts.Line([]string{"db.example.com"}, other.PublicKey())+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		knownHosts string
+		wantErr    string
+	}{
+		{
+			name:       "host key listed",
+			knownHosts: server.knownHosts(t, server.hostKey.PublicKey()),
+		},
+		{
+			name:       "host not listed",
+			knownHosts: unlisted,
+			wantErr:    "is not in " + unlisted + ", add it with ssh-keyscan",
+		},
+		{
+			name:       "host key changed",
+			knownHosts: server.knownHosts(t, other.PublicKey()),
+			wantErr:    "key mismatch",
+		},
+		{
+			name:       "missing file",
+			knownHosts: filepath.Join(t.TempDir(), "missing"),
+			wantErr:    "failed to load known hosts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &SSHTunnelConfig{Host: "127.0.0.1", User: "deploy", Password: "s3cret", KnownHostsFile: tt.knownHosts}
+			err := server.dial(config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("dial error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("dial error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// TLSModes are the verification modes a TLS configuration accepts
+var TLSModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+// TLSConfig holds the TLS settings of a connection
+type TLSConfig struct {
+	// Mode is one of TLSModes: disable, require encryption only, verify the
+	// certificate chain, or verify the chain and the server name
+	Mode string
+	// CAFile is a PEM bundle used instead of the system roots
+	CAFile string
+	// CertFile and KeyFile hold the PEM client certificate and key
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the host name checked in verify-full mode
+	ServerName string
+}
+
+// Validate checks the mode and that client certificate and key come together
+func (t *TLSConfig) Validate() error {
+	if !slices.Contains(TLSModes, t.Mode) {
+		return fmt.Errorf("unsupported TLS mode: %s", t.Mode)
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("TLS client certificate and key must be given together")
+	}
+	return nil
+}
+
+// Enabled reports whether the connection is encrypted
+func (t *TLSConfig) Enabled() bool {
+	return t != nil && t.Mode != "disable"
+}
+
+// Map returns the settings in their persisted form
+func (t *TLSConfig) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"mode":       t.Mode,
+		"caFile":     t.CAFile,
+		"certFile":   t.CertFile,
+		"keyFile":    t.KeyFile,
+		"serverName": t.ServerName,
+	}
+}
+
+// NewTLSConfigFromMap restores settings persisted with Map
+func NewTLSConfigFromMap(data map[string]interface{}) *TLSConfig {
+	mode, _ := data["mode"].(string)
+	caFile, _ := data["caFile"].(string)
+	certFile, _ := data["certFile"].(string)
+	keyFile, _ := data["keyFile"].(string)
+	serverName, _ := data["serverName"].(string)
+
+	return &TLSConfig{
+		Mode:       mode,
+		CAFile:     caFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: serverName,
+	}
+}
+
+// ClientConfig builds a crypto/tls configuration verifying the server as the
+// mode requires. host is the name checked when no ServerName override is set.
+func (t *TLSConfig) ClientConfig(host string) (*tls.Config, error) {
+	serverName := t.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+
+	cfg := &tls.Config{
+		ServerName: serverName,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS CA bundle %s", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	switch t.Mode {
+	case "require":
+		cfg.InsecureSkipVerify = true
+	case "verify-ca":
+		// Go only offers chain verification together with the host name
+		// check, so the chain is verified by hand
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("server presented no certificate")
+			}
+			opts := x509.VerifyOptions{
+				Roots:         cfg.RootCAs,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		}
+	}
+
+	return cfg, nil
+}
+
+// TLSState describes the TLS session negotiated with a database server
+type TLSState struct {
+	Enabled bool
+	Version string
+	Cipher  string
+	Subject string
+	Issuer  string
+}
+
+// NewTLSState describes a negotiated crypto/tls connection
+func NewTLSState(cs tls.ConnectionState) *TLSState {
+	state := &TLSState{
+		Enabled: true,
+		Version: tls.VersionName(cs.Version),
+		Cipher:  tls.CipherSuiteName(cs.CipherSuite),
+	}
+	if len(cs.PeerCertificates) > 0 {
+		state.Subject = cs.PeerCertificates[0].Subject.String()
+		state.Issuer = cs.PeerCertificates[0].Issuer.String()
+	}
+	return state
+}
+
+// normalizeTLSVersion turns server reported versions like TLSv1.3 into the
+// names used by crypto/tls, e.g. TLS 1.3
+func normalizeTLSVersion(version string) string {
+	return strings.Replace(version, "TLSv", "TLS ", 1)
+}
+
+// NewReportedTLSState describes a TLS session from the version and cipher
+// reported by the server itself
+func NewReportedTLSState(version, cipher string) *TLSState {
+	if version == "" {
+		return &TLSState{}
+	}
+	return &TLSState{
+		Enabled: true,
+		Version: normalizeTLSVersion(version),
+		Cipher:  cipher,
+	}
+}
+
+// TLSInspector is implemented by database services able to report the TLS
+// state of an open session
+type TLSInspector interface {
+	TLSState(c *Connection) (*TLSState, error)
+}
@@ -0,0 +1,307 @@
+package domain_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"seagle/core/domain"
+)
+
+// testPKI is a CA with a server certificate for db.internal and a client
+// certificate, written as PEM files
+type testPKI struct {
+	caFile     string
+	certFile   string
+	keyFile    string
+	ca         *x509.Certificate
+	serverCert tls.Certificate
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, caDER := newCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverKey, serverDER := newCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "db.internal"},
+		DNSNames:    []string{"db.internal"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	clientKey, clientDER := newCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "app"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	p := &testPKI{
+		caFile:     writePEM(t, dir, "ca.pem", "CERTIFICATE", caDER),
+		certFile:   writePEM(t, dir, "client.pem", "CERTIFICATE", clientDER),
+		keyFile:    writePEM(t, dir, "client.key", "EC PRIVATE KEY", marshalKey(t, clientKey)),
+		ca:         ca,
+		serverCert: tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey},
+	}
+	return p
+}
+
+// newCertificate signs the template with the parent, or itself without one
+func newCertificate(t *testing.T, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, der
+}
+
+func marshalKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// handshake runs a TLS handshake between the client configuration and a
+// server presenting the db.internal certificate, asking for a client
+// certificate signed by the CA when requireClient is set
+func (p *testPKI) handshake(client *tls.Config, requireClient bool) error {
+	server := &tls.Config{Certificates: []tls.Certificate{p.serverCert}}
+	if requireClient {
+		server.ClientAuth = tls.RequireAndVerifyClientCert
+		server.ClientCAs = x509.NewCertPool()
+		server.ClientCAs.AddCert(p.ca)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	go func() {
+		serverConn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer serverConn.Close()
+		conn := tls.Server(serverConn, server)
+		if conn.Handshake() == nil {
+			// Wait for the client to hang up
+			conn.Read(make([]byte, 1))
+		}
+	}()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		return err
+	}
+	defer clientConn.Close()
+
+	conn := tls.Client(clientConn, client)
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+	// TLS 1.3 servers check the client certificate after the client finished
+	// its handshake, a rejection shows on the first read
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, err := conn.Read(make([]byte, 1)); err != nil && !isTimeout(err) {
+		return err
+	}
+	return nil
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+func TestTLSConfigClientConfig(t *testing.T) {
+	p := newTestPKI(t)
+
+	tests := []struct {
+		name          string
+		config        domain.TLSConfig
+		host          string
+		requireClient bool
+		wantErr       string
+	}{
+		{
+			name:   "require takes any certificate",
+			config: domain.TLSConfig{Mode: "require"},
+			host:   "10.0.0.5",
+		},
+		{
+			name:   "verify-ca takes a certificate of the CA for another name",
+			config: domain.TLSConfig{Mode: "verify-ca", CAFile: p.caFile},
+			host:   "10.0.0.5",
+		},
+		{
+			name:    "verify-ca refuses a certificate of an unknown CA",
+			config:  domain.TLSConfig{Mode: "verify-ca"},
+			host:    "db.internal",
+			wantErr: "certificate signed by unknown authority",
+		},
+		{
+			name:   "verify-full takes a certificate of the CA for the host",
+			config: domain.TLSConfig{Mode: "verify-full", CAFile: p.caFile},
+			host:   "db.internal",
+		},
+		{
+			name:    "verify-full refuses a certificate for another name",
+			config:  domain.TLSConfig{Mode: "verify-full", CAFile: p.caFile},
+			host:    "replica.internal",
+			wantErr: "certificate is valid for db.internal, not replica.internal",
+		},
+		{
+			name:   "verify-full checks the server name in place of the host",
+			config: domain.TLSConfig{Mode: "verify-full", CAFile: p.caFile, ServerName: "db.internal"},
+			host:   "10.0.0.5",
+		},
+		{
+			name:    "verify-full refuses a certificate of an unknown CA",
+			config:  domain.TLSConfig{Mode: "verify-full"},
+			host:    "db.internal",
+			wantErr: "certificate signed by unknown authority",
+		},
+		{
+			name:          "client certificate",
+			config:        domain.TLSConfig{Mode: "verify-full", CAFile: p.caFile, CertFile: p.certFile, KeyFile: p.keyFile},
+			host:          "db.internal",
+			requireClient: true,
+		},
+		{
+			name:          "no client certificate",
+			config:        domain.TLSConfig{Mode: "verify-full", CAFile: p.caFile},
+			host:          "db.internal",
+			requireClient: true,
+			wantErr:       "certificate required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := tt.config.ClientConfig(tt.host)
+			if err != nil {
+				t.Fatalf("ClientConfig() error = %v", err)
+			}
+
+			err = p.handshake(cfg, tt.requireClient)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("handshake error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("handshake error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSConfigClientConfigErrors(t *testing.T) {
+	p := newTestPKI(t)
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  domain.TLSConfig
+		wantErr string
+	}{
+		{
+			name:    "missing CA bundle",
+			config:  domain.TLSConfig{Mode: "verify-full", CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: "failed to read TLS CA bundle",
+		},
+		{
+			name:    "CA bundle without certificates",
+			config:  domain.TLSConfig{Mode: "verify-full", CAFile: notPEM},
+			wantErr: "no certificates found in TLS CA bundle",
+		},
+		{
+			name:    "key of another certificate",
+			config:  domain.TLSConfig{Mode: "require", CertFile: p.certFile, KeyFile: p.caFile},
+			wantErr: "failed to load TLS client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.config.ClientConfig("db.internal")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ClientConfig() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  domain.TLSConfig
+		wantErr bool
+	}{
+		{name: "disable", config: domain.TLSConfig{Mode: "disable"}},
+		{name: "verify-full with a client certificate", config: domain.TLSConfig{Mode: "verify-full", CertFile: "client.pem", KeyFile: "client.key"}},
+		{name: "unknown mode", config: domain.TLSConfig{Mode: "prefer"}, wantErr: true},
+		{name: "certificate without key", config: domain.TLSConfig{Mode: "require", CertFile: "client.pem"}, wantErr: true},
+		{name: "key without certificate", config: domain.TLSConfig{Mode: "require", KeyFile: "client.key"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+// FindTransactionControl returns the first statement of the script that
+// starts or ends a transaction, which would end the transaction the script
+// runs in. ROLLBACK TO a savepoint keeps the transaction and is not reported.
+func FindTransactionControl(script string, dialect SQLDialect) (string, bool) {
+	for _, statement := range splitStatements(script, dialect) {
+		switch statement.keyword() {
+		case "BEGIN", "START", "COMMIT", "END", "ABORT":
+			return statement.text, true
+		case "ROLLBACK":
+			if _, ok := statement.has("TO"); !ok {
+				return statement.text, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FindImplicitCommit returns the first statement of the script that commits
+// the open transaction before it runs, in dialects with ImplicitCommits.
+// Rolling back after it would not undo the changes made before.
+func FindImplicitCommit(script string, dialect SQLDialect) (string, bool) {
+	if !dialect.ImplicitCommits {
+		return "", false
+	}
+	for _, statement := range splitStatements(script, dialect) {
+		if statement.commitsImplicitly() {
+			return statement.text, true
+		}
+	}
+	return "", false
+}
+
+// commitsImplicitly reports whether MySQL commits the open transaction
+// before running the statement. Temporary tables are created and dropped
+// without a commit.
+func (s sqlStatement) commitsImplicitly() bool {
+	switch s.keyword() {
+	case "ALTER", "RENAME", "TRUNCATE", "GRANT", "REVOKE", "LOCK", "UNLOCK",
+		"ANALYZE", "CHECK", "OPTIMIZE", "REPAIR", "CACHE", "FLUSH", "RESET", "INSTALL", "UNINSTALL":
+		return true
+	case "CREATE", "DROP":
+		_, temporary := s.has("TEMPORARY")
+		return !temporary
+	case "LOAD":
+		// LOAD INDEX INTO CACHE, LOAD DATA runs in the transaction
+		_, index := s.has("INDEX")
+		return index
+	case "SET":
+		_, ok := s.has("AUTOCOMMIT", "PASSWORD")
+		return ok
+	}
+	return false
+}
+
+// BeginStatement returns the statement beginning a transaction on the
+// connection, a read-only transaction for read-only connections when the
+// vendor has one
+func (c *Connection) BeginStatement() string {
+	if begin := c.Dialect().ReadOnlyBegin; c.ReadOnly() && begin != "" {
+		return begin
+	}
+	return "BEGIN"
+}
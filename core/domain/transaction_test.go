@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestFindImplicitCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		dialect domain.SQLDialect
+		want    string
+	}{
+		{name: "DDL", script: "UPDATE t SET a = 1; ALTER TABLE t ADD b int", dialect: mysqlDialect, want: "ALTER TABLE t ADD b int"},
+		{name: "CREATE TABLE", script: "CREATE TABLE t (a int)", dialect: mysqlDialect, want: "CREATE TABLE t (a int)"},
+		{name: "temporary tables", script: "CREATE TEMPORARY TABLE t (a int); DROP TEMPORARY TABLE t", dialect: mysqlDialect},
+		{name: "TRUNCATE", script: "TRUNCATE t", dialect: mysqlDialect, want: "TRUNCATE t"},
+		{name: "LOCK TABLES", script: "LOCK TABLES t WRITE", dialect: mysqlDialect, want: "LOCK TABLES t WRITE"},
+		{name: "SET autocommit", script: "SET autocommit = 1", dialect: mysqlDialect, want: "SET autocommit = 1"},
+		{name: "SET @@autocommit", script: "SET @@session.autocommit = 1", dialect: mysqlDialect, want: "SET @@session.autocommit = 1"},
+		{name: "other SET", script: "SET @a = 1; SET NAMES utf8mb4", dialect: mysqlDialect},
+		{name: "LOAD INDEX", script: "LOAD INDEX INTO CACHE t", dialect: mysqlDialect, want: "LOAD INDEX INTO CACHE t"},
+		{name: "LOAD DATA", script: "LOAD DATA INFILE 'a.csv' INTO TABLE t", dialect: mysqlDialect},
+		{name: "data changes", script: "INSERT INTO t VALUES (1); DELETE FROM t; SELECT 'DROP TABLE t'", dialect: mysqlDialect},
+		{name: "transactional DDL", script: "ALTER TABLE t ADD b int", dialect: postgresDialect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := domain.FindImplicitCommit(tt.script, tt.dialect)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("FindImplicitCommit(%q) = %q, %v, want %q", tt.script, got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// VaultRefPrefix starts the values that reference a secret kept in the vault
+const VaultRefPrefix = "vault:"
+
+var (
+	// ErrVaultLocked is returned when a secret is needed while the vault is locked
+	ErrVaultLocked = errors.New("vault is locked, unlock it with the master passphrase")
+	// ErrVaultNotInitialized is returned when a secret is stored before a
+	// master passphrase was chosen
+	ErrVaultNotInitialized = errors.New("vault has not been created, choose a master passphrase first")
+)
+
+// SecretResolver turns the stored value of a secret field into the secret
+type SecretResolver interface {
+	// Resolve returns the secret a value references, or the value itself
+	// when it is not a reference
+	Resolve(value string) (string, error)
+}
+
+// Vault keeps secrets encrypted under a key derived from a master
+// passphrase. Repositories store only references to them.
+type Vault interface {
+	SecretResolver
+	Initialized() bool
+	Locked() bool
+	// RelockAfter is how long the vault stays unlocked without being used
+	RelockAfter() time.Duration
+	// Initialize creates the vault with a master passphrase and unlocks it
+	Initialize(passphrase string) error
+	Unlock(passphrase string) error
+	Lock()
+	// Put encrypts the secret under the name and returns its reference
+	Put(name, secret string) (string, error)
+	// Delete removes the secret stored under the name, if any
+	Delete(name string) error
+}
+
+// VaultRef returns the reference to the secret stored under the name
+func VaultRef(name string) string {
+	return VaultRefPrefix + name
+}
+
+// IsVaultRef reports whether the value references a secret in the vault
+func IsVaultRef(value string) bool {
+	return strings.HasPrefix(value, VaultRefPrefix)
+}
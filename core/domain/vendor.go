@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// FormField describes an input of the connection form. Its name is one of
+// host, port, database, username or password.
+type FormField struct {
+	Name        string
+	Label       string
+	Type        string // text, number, password or file
+	Required    bool
+	Placeholder string
+}
+
+// ArgumentSpec describes an extra driver argument accepted by a vendor
+type ArgumentSpec struct {
+	Name        string
+	Label       string
+	Description string
+	Type        string // string, bool, int or enum
+	Options     []string
+	Default     string
+}
+
+// Vendor describes a database engine. Each engine lives in its own package
+// under core/domain/vendors and registers its descriptor from an init function.
+type Vendor struct {
+	// Name is the identifier stored in connections, e.g. "postgresql"
+	Name string
+	// DisplayName is the human readable engine name, e.g. "PostgreSQL"
+	DisplayName string
+	// Schemes are the connection string URL schemes handled by the vendor
+	Schemes []string
+	// DefaultPort is used when a connection does not specify a port
+	DefaultPort int
+	// DefaultDatabase is used when a connection does not specify a database
+	DefaultDatabase string
+	// DriverName is the database/sql driver the vendor registers
+	DriverName string
+
+	// Fields are the connection form inputs used by the vendor
+	Fields []FormField
+	// SSLModes are the accepted TLS modes, a subset of TLSModes, empty when
+	// TLS does not apply
+	SSLModes []string
+	// Arguments are the extra driver arguments accepted from the form
+	Arguments []ArgumentSpec
+	// SupportsSSH tells whether the database can be reached through an SSH tunnel
+	SupportsSSH bool
+
+	// BuildDSN renders the driver connection string for a connection
+	BuildDSN func(c *Connection) string
+	// NewService creates the DatabaseService sharing the given sessions
+	NewService func(sessions *SessionManager) DatabaseService
+	// TablesQuery returns a query listing the (table_name, table_schema)
+	// pairs of the connection's database
+	TablesQuery func(c *Connection) string
+	// QuoteIdentifier quotes a table, schema or column name
+	QuoteIdentifier func(name string) string
+	// PromptHints are dialect specific rules given to the AI query generator
+	PromptHints []string
+	// Dialect tells how scripts are split into statements
+	Dialect SQLDialect
+
+	// Formats are the connection string formats the vendor parses and
+	// renders, the one named URLFormat handling its schemes
+	Formats []ConnectionStringFormat
+	// Validate optionally checks vendor specific connection requirements
+	Validate func(c *Connection) error
+	// OpenConnector optionally replaces sql.Open with BuildDSN, for drivers
+	// needing more than a connection string, e.g. TLS settings or a dialer
+	OpenConnector func(c *Connection) (driver.Connector, error)
+	// TLSArguments optionally takes the TLS settings out of the arguments of
+	// a connection string, e.g. sslmode, and returns nil when it has none
+	TLSArguments func(arguments map[string]string) (*TLSConfig, error)
+}
+
+// ValidateArguments checks the SSL mode and extra arguments given through the
+// connection form against the vendor capabilities
+func (v *Vendor) ValidateArguments(sslMode string, arguments map[string]string) error {
+	if sslMode != "" && !slices.Contains(v.SSLModes, sslMode) {
+		return fmt.Errorf("unsupported SSL mode for %s: %s", v.DisplayName, sslMode)
+	}
+
+	for name, value := range arguments {
+		spec, ok := v.Argument(name)
+		if !ok {
+			return fmt.Errorf("unsupported argument for %s: %s", v.DisplayName, name)
+		}
+
+		switch spec.Type {
+		case "bool":
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("argument %s must be true or false", name)
+			}
+		case "int":
+			if _, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("argument %s must be an integer", name)
+			}
+		case "enum":
+			if !slices.Contains(spec.Options, value) {
+				return fmt.Errorf("argument %s must be one of %v", name, spec.Options)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Argument returns the spec of the argument with the given name
+func (v *Vendor) Argument(name string) (ArgumentSpec, bool) {
+	for _, spec := range v.Arguments {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return ArgumentSpec{}, false
+}
+
+// Format returns the connection string format with the given name
+func (v *Vendor) Format(name string) (ConnectionStringFormat, bool) {
+	for _, format := range v.Formats {
+		if format.Name == name {
+			return format, true
+		}
+	}
+	return ConnectionStringFormat{}, false
+}
+
+var (
+	vendorsMu sync.RWMutex
+	vendors   = make(map[string]*Vendor)
+	schemes   = make(map[string]*Vendor)
+)
+
+// RegisterVendor makes a vendor available by name and URL schemes.
+// It panics if the vendor or one of its schemes is already registered.
+func RegisterVendor(v *Vendor) {
+	vendorsMu.Lock()
+	defer vendorsMu.Unlock()
+
+	if v == nil || v.Name == "" {
+		panic("domain: RegisterVendor vendor is nil or has no name")
+	}
+	if v.BuildDSN == nil || v.NewService == nil || v.TablesQuery == nil || v.QuoteIdentifier == nil {
+		panic("domain: RegisterVendor vendor " + v.Name + " is incomplete")
+	}
+	if _, dup := vendors[v.Name]; dup {
+		panic("domain: RegisterVendor called twice for vendor " + v.Name)
+	}
+	for _, scheme := range v.Schemes {
+		if _, dup := schemes[scheme]; dup {
+			panic("domain: RegisterVendor called twice for scheme " + scheme)
+		}
+	}
+
+	vendors[v.Name] = v
+	for _, scheme := range v.Schemes {
+		schemes[scheme] = v
+	}
+}
+
+// LookupVendor returns the vendor registered under the given name
+func LookupVendor(name string) (*Vendor, error) {
+	vendorsMu.RLock()
+	defer vendorsMu.RUnlock()
+
+	v, ok := vendors[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported vendor: %s", name)
+	}
+	return v, nil
+}
+
+// LookupVendorByScheme returns the vendor handling the given URL scheme
+func LookupVendorByScheme(scheme string) (*Vendor, error) {
+	vendorsMu.RLock()
+	defer vendorsMu.RUnlock()
+
+	v, ok := schemes[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported scheme: %s", scheme)
+	}
+	return v, nil
+}
+
+// Vendors returns every registered vendor sorted by name
+func Vendors() []*Vendor {
+	vendorsMu.RLock()
+	defer vendorsMu.RUnlock()
+
+	list := make([]*Vendor, 0, len(vendors))
+	for _, v := range vendors {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
@@ -0,0 +1,151 @@
+package mysql
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// dsnPattern matches [user[:password]@][net[(address)]]/database[?params]
+var dsnPattern = regexp.MustCompile(`^(?:(.*)@)?([a-z0-9]*)(?:\(([^)]*)\))?/([^?]*)(?:\?(.*))?$`)
+
+// isDSN reports whether s looks like a Go MySQL driver DSN
+func isDSN(s string) bool {
+	return strings.ContainsAny(s, "@(") && dsnPattern.MatchString(s)
+}
+
+// parseDSN parses a Go MySQL driver DSN such as user:pass@tcp(host:3306)/db
+// or user@unix(/run/mysqld/mysqld.sock)/db
+func parseDSN(id, s string) (*domain.Connection, error) {
+	match := dsnPattern.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("invalid MySQL DSN")
+	}
+	userinfo, network, addr, database, params := match[1], match[2], match[3], match[4], match[5]
+
+	username, password, _ := strings.Cut(userinfo, ":")
+
+	host, port := "127.0.0.1", defaultPort
+	switch network {
+	case "unix":
+		if addr == "" {
+			return nil, fmt.Errorf("unix socket path is required in MySQL DSN")
+		}
+		host = addr
+	case "", "tcp", "tcp4", "tcp6":
+		if addr != "" {
+			h, p, err := net.SplitHostPort(addr)
+			if err != nil {
+				// No port given
+				h, p = addr, ""
+			}
+			host = h
+			if p != "" {
+				if port, err = strconv.Atoi(p); err != nil {
+					return nil, fmt.Errorf("invalid port in MySQL DSN: %s", p)
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported network in MySQL DSN: %s", network)
+	}
+
+	values, err := url.ParseQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters in MySQL DSN: %v", err)
+	}
+	arguments := make(map[string]string)
+	for key, v := range values {
+		if len(v) > 0 {
+			arguments[key] = v[0]
+		}
+	}
+
+	return domain.NewParsedConnection(id, vendorName, []domain.Endpoint{{Host: host, Port: port}}, database, username, password, arguments)
+}
+
+// renderDSN writes the connection as a Go MySQL driver DSN. The driver has no
+// failover, so only the primary host is written.
+func renderDSN(c *domain.Connection, includePassword bool) string {
+	var b strings.Builder
+	if c.Username() != "" || (includePassword && c.Password() != "") {
+		b.WriteString(c.Username())
+		if includePassword && c.Password() != "" {
+			b.WriteString(":")
+			b.WriteString(c.Password())
+		}
+		b.WriteString("@")
+	}
+	b.WriteString(address(c))
+	b.WriteString("/")
+	b.WriteString(c.Database())
+
+	arguments := portableArguments(c)
+	if len(arguments) > 0 {
+		keys := make([]string, 0, len(arguments))
+		for k := range arguments {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		params := make([]string, len(keys))
+		for i, k := range keys {
+			params[i] = k + "=" + url.QueryEscape(arguments.Get(k))
+		}
+		b.WriteString("?")
+		b.WriteString(strings.Join(params, "&"))
+	}
+
+	return b.String()
+}
+
+// portableArguments returns the arguments with the TLS mode written as one of
+// the driver's built-in tls values, since registered profiles only exist in
+// this process. verify-ca has no built-in equivalent and maps to the stricter
+// true.
+func portableArguments(c *domain.Connection) url.Values {
+	arguments := url.Values{}
+	for k, v := range c.Arguments() {
+		arguments.Set(k, v)
+	}
+	if t := c.TLS(); t != nil {
+		switch t.Mode {
+		case "disable":
+			arguments.Set("tls", "false")
+		case "require":
+			arguments.Set("tls", "skip-verify")
+		default:
+			arguments.Set("tls", "true")
+		}
+	}
+	return arguments
+}
+
+// tlsModes are the TLS modes of the driver's built-in tls values
+var tlsModes = map[string]string{
+	"false":       "disable",
+	"skip-verify": "require",
+	"true":        "verify-full",
+}
+
+// tlsArguments takes the tls parameter out of the arguments of a connection
+// string. preferred and registered profile names have no TLS mode.
+func tlsArguments(arguments map[string]string) (*domain.TLSConfig, error) {
+	value, ok := arguments["tls"]
+	if !ok {
+		return nil, nil
+	}
+
+	mode, ok := tlsModes[value]
+	if !ok {
+		return nil, fmt.Errorf("unsupported tls value for MySQL: %s", value)
+	}
+	delete(arguments, "tls")
+	return &domain.TLSConfig{Mode: mode}, nil
+}
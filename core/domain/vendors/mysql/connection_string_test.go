@@ -0,0 +1,186 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		host      string
+		port      int
+		database  string
+		username  string
+		password  string
+		arguments map[string]string
+	}{
+		{
+			name:      "tcp with port",
+			s:         "root:secret@tcp(db.example.com:3307)/shop",
+			host:      "db.example.com",
+			port:      3307,
+			database:  "shop",
+			username:  "root",
+			password:  "secret",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "tcp without port",
+			s:         "root@tcp(db.example.com)/shop",
+			host:      "db.example.com",
+			port:      3306,
+			database:  "shop",
+			username:  "root",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "ipv6 address",
+			s:         "root@tcp([::1]:3307)/shop",
+			host:      "::1",
+			port:      3307,
+			database:  "shop",
+			username:  "root",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "no network",
+			s:         "root@/shop",
+			host:      "127.0.0.1",
+			port:      3306,
+			database:  "shop",
+			username:  "root",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "password with a colon",
+			s:         "root:pa:ss@tcp(localhost:3306)/shop",
+			host:      "localhost",
+			port:      3306,
+			database:  "shop",
+			username:  "root",
+			password:  "pa:ss",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "unix socket",
+			s:         "root@unix(/run/mysqld/mysqld.sock)/shop",
+			host:      "/run/mysqld/mysqld.sock",
+			port:      3306,
+			database:  "shop",
+			username:  "root",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "parameters",
+			s:         "root@tcp(localhost:3306)/shop?parseTime=true&loc=Europe%2FParis",
+			host:      "localhost",
+			port:      3306,
+			database:  "shop",
+			username:  "root",
+			arguments: map[string]string{"parseTime": "true", "loc": "Europe/Paris"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseDSN("id", tt.s)
+			if err != nil {
+				t.Fatalf("parseDSN(%q) error: %v", tt.s, err)
+			}
+			if got := c.Host(); got != tt.host {
+				t.Errorf("host = %q, want %q", got, tt.host)
+			}
+			if got := c.Port(); got != tt.port {
+				t.Errorf("port = %d, want %d", got, tt.port)
+			}
+			if got := c.Database(); got != tt.database {
+				t.Errorf("database = %q, want %q", got, tt.database)
+			}
+			if got := c.Username(); got != tt.username {
+				t.Errorf("username = %q, want %q", got, tt.username)
+			}
+			if got := c.Password(); got != tt.password {
+				t.Errorf("password = %q, want %q", got, tt.password)
+			}
+			if got := c.Arguments(); !reflect.DeepEqual(got, tt.arguments) {
+				t.Errorf("arguments = %v, want %v", got, tt.arguments)
+			}
+		})
+	}
+}
+
+func TestParseDSNErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "no database slash", s: "root@tcp(localhost:3306)"},
+		{name: "unix without path", s: "root@unix()/shop"},
+		{name: "unsupported network", s: "root@pipe(name)/shop"},
+		{name: "invalid port", s: "root@tcp(localhost:abc)/shop"},
+		{name: "invalid parameters", s: "root@tcp(localhost)/shop?a=%zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseDSN("id", tt.s); err == nil {
+				t.Errorf("parseDSN(%q) succeeded, want an error", tt.s)
+			}
+		})
+	}
+}
+
+func TestIsDSN(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{s: "root:secret@tcp(localhost:3306)/shop", want: true},
+		{s: "root@/shop", want: true},
+		{s: "tcp(localhost)/shop", want: true},
+		{s: "host=localhost dbname=shop", want: false},
+		{s: "localhost/shop", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isDSN(tt.s); got != tt.want {
+			t.Errorf("isDSN(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestRenderDSNRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "tcp", s: "root:secret@tcp(localhost:3306)/shop"},
+		{name: "ipv6", s: "root@tcp([::1]:3307)/shop"},
+		{name: "unix socket", s: "root@unix(/run/mysqld/mysqld.sock)/shop"},
+		{name: "sorted parameters", s: "root@tcp(localhost:3306)/shop?loc=Europe%2FParis&parseTime=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseDSN("id", tt.s)
+			if err != nil {
+				t.Fatalf("parseDSN(%q) error: %v", tt.s, err)
+			}
+			if got := renderDSN(c, true); got != tt.s {
+				t.Errorf("renderDSN() = %q, want %q", got, tt.s)
+			}
+		})
+	}
+}
+
+func TestRenderDSNLeavesPasswordOut(t *testing.T) {
+	c, err := parseDSN("id", "root:secret@tcp(localhost:3306)/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := renderDSN(c, false), "root@tcp(localhost:3306)/shop"; got != want {
+		t.Errorf("renderDSN() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// EstimateRows asks the optimizer for the rows the statement examines. The
+// statement is explained, not run, and the largest estimate is taken.
+func (s *MySQLService) EstimateRows(c *domain.Connection, statement string) (int64, error) {
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return 0, fmt.Errorf("no active connection found")
+	}
+
+	rows, err := dbConn.Query("EXPLAIN " + statement)
+	if err != nil {
+		return 0, fmt.Errorf("failed to explain statement: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get columns: %w", err)
+	}
+	rowsColumn := -1
+	for i, column := range columns {
+		if strings.EqualFold(column, "rows") {
+			rowsColumn = i
+		}
+	}
+	if rowsColumn < 0 {
+		return 0, fmt.Errorf("plan has no rows estimate")
+	}
+
+	var estimate int64
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		for i := range values {
+			values[i] = new(sql.RawBytes)
+		}
+		var examined sql.NullInt64
+		values[rowsColumn] = &examined
+		if err := rows.Scan(values...); err != nil {
+			return 0, fmt.Errorf("failed to scan plan: %w", err)
+		}
+		if examined.Valid {
+			estimate = max(estimate, examined.Int64)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	return estimate, nil
+}
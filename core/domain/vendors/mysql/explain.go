@@ -0,0 +1,416 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+var (
+	// treeCost matches the estimate of a node of EXPLAIN ANALYZE, e.g.
+	// (cost=0.35..1.15 rows=9)
+	treeCost = regexp.MustCompile(`\(cost=([0-9.e+-]+)(?:\.\.([0-9.e+-]+))? rows=([0-9.e+-]+)\)`)
+	// treeActual matches what a node of EXPLAIN ANALYZE measured, e.g.
+	// (actual time=0.080..0.088 rows=9 loops=1)
+	treeActual = regexp.MustCompile(`\(actual time=([0-9.e+-]+)\.\.([0-9.e+-]+) rows=([0-9.e+-]+) loops=([0-9]+)\)`)
+)
+
+// accessTypes names the access types of EXPLAIN FORMAT=JSON the way
+// EXPLAIN ANALYZE does
+var accessTypes = map[string]string{
+	"ALL":             "Table scan",
+	"index":           "Index scan",
+	"range":           "Index range scan",
+	"index_merge":     "Index merge",
+	"ref":             "Index lookup",
+	"ref_or_null":     "Index lookup or null",
+	"eq_ref":          "Single-row index lookup",
+	"const":           "Constant row",
+	"system":          "Constant row",
+	"fulltext":        "Full-text index search",
+	"unique_subquery": "Index subquery lookup",
+	"index_subquery":  "Index subquery lookup",
+}
+
+// subqueryKeys are the members of EXPLAIN FORMAT=JSON listing subqueries
+var subqueryKeys = []string{
+	"attached_subqueries",
+	"select_list_subqueries",
+	"having_subqueries",
+	"order_by_subqueries",
+	"group_by_subqueries",
+	"optimized_away_subqueries",
+}
+
+// ExplainQuery explains the statement with EXPLAIN FORMAT=JSON, or runs it
+// with EXPLAIN ANALYZE, which only prints a tree of text
+func (s *MySQLService) ExplainQuery(ctx context.Context, session domain.QuerySession, statement string, args []interface{}, options domain.ExplainOptions) (*domain.QueryPlan, error) {
+	if options.Analyze {
+		raw, err := domain.ReadPlanText(ctx, session, "EXPLAIN ANALYZE "+statement, args)
+		if err != nil {
+			return nil, err
+		}
+		root, err := parseTree(raw)
+		if err != nil {
+			return nil, err
+		}
+		return domain.FinishPlan(&domain.QueryPlan{Root: root, Analyzed: true, Raw: raw}), nil
+	}
+
+	raw, err := domain.ReadPlanText(ctx, session, "EXPLAIN FORMAT=JSON "+statement, args)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	var root *domain.PlanNode
+	if _, ok := document["operation"]; ok {
+		// explain_json_format_version=2 prints the tree of EXPLAIN ANALYZE
+		root = operationNode(document)
+	} else {
+		root = jsonNode(document)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty plan")
+	}
+	return domain.FinishPlan(&domain.QueryPlan{Root: root, Raw: raw}), nil
+}
+
+// parseTree parses the tree EXPLAIN ANALYZE prints, one node per line
+// starting with -> and indented by 4 spaces per level
+func parseTree(text string) (*domain.PlanNode, error) {
+	type entry struct {
+		level int
+		text  string
+	}
+	var entries []entry
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(trimmed, "-> ") {
+			entries = append(entries, entry{level: (len(line) - len(trimmed)) / 4, text: strings.TrimPrefix(trimmed, "-> ")})
+			continue
+		}
+		// Long conditions go on over several lines, before the estimates
+		if len(entries) > 0 && strings.TrimSpace(line) != "" {
+			last := &entries[len(entries)-1]
+			last.text += " " + strings.TrimSpace(line)
+		}
+	}
+
+	var root *domain.PlanNode
+	var parents []*domain.PlanNode
+	for _, e := range entries {
+		node := treeNode(e.text)
+		level := min(e.level, len(parents))
+		parents = parents[:level]
+		if level == 0 {
+			if root != nil {
+				return nil, fmt.Errorf("failed to parse plan: more than one root")
+			}
+			root = node
+		} else {
+			parent := parents[level-1]
+			parent.Children = append(parent.Children, node)
+		}
+		parents = append(parents, node)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty plan")
+	}
+	return root, nil
+}
+
+// treeNode parses a node of EXPLAIN ANALYZE, e.g.
+// Index lookup on t2 using idx (a=t1.a)  (cost=0.26 rows=1) (actual time=0.009..0.010 rows=1 loops=9)
+func treeNode(text string) *domain.PlanNode {
+	node := &domain.PlanNode{}
+
+	end := len(text)
+	for _, marker := range []string{" (cost=", " (actual time=", " (never executed)"} {
+		if i := strings.Index(text, marker); i >= 0 && i < end {
+			end = i
+		}
+	}
+
+	if m := treeCost.FindStringSubmatch(text[end:]); m != nil {
+		if m[2] != "" {
+			node.StartupCost = parseNumber(m[1])
+			node.TotalCost = parseNumber(m[2])
+		} else {
+			node.TotalCost = parseNumber(m[1])
+		}
+		node.EstimatedRows = parseNumber(m[3])
+	}
+	if m := treeActual.FindStringSubmatch(text[end:]); m != nil {
+		node.ActualRows = parseNumber(m[3])
+		node.Loops = parseNumber(m[4])
+		// The time is that of the last row, averaged over the loops
+		if last := parseNumber(m[2]); last != nil && node.Loops != nil {
+			total := *last * *node.Loops
+			node.ActualTime = &total
+		}
+	} else if strings.Contains(text[end:], "(never executed)") {
+		zero := 0.0
+		node.Loops = &zero
+	}
+
+	name := strings.TrimSpace(text[:end])
+	colon, on := strings.Index(name, ": "), strings.Index(name, " on ")
+	switch {
+	case colon >= 0 && (on < 0 || colon < on):
+		node.NodeType, node.Detail = name[:colon], name[colon+2:]
+	case on >= 0:
+		node.NodeType = name[:on]
+		node.Relation, node.Index, node.Detail = splitTarget(name[on+4:])
+	default:
+		node.NodeType = name
+	}
+
+	// Scans of temporary tables, <temporary> or <union2,3>, read what the
+	// statement built itself
+	node.FullScan = node.NodeType == "Table scan" && !strings.HasPrefix(node.Relation, "<")
+	if node.FullScan && node.ActualRows != nil && node.Loops != nil {
+		scanned := *node.ActualRows * *node.Loops
+		node.RowsScanned = &scanned
+	}
+	return node
+}
+
+// splitTarget splits what follows " on " in a node of EXPLAIN ANALYZE into
+// the table, the index after "using" and the rest, e.g. t2 using idx (a=t1.a)
+func splitTarget(text string) (relation, index, detail string) {
+	relation, rest, _ := strings.Cut(text, " ")
+	if after, ok := strings.CutPrefix(rest, "using "); ok {
+		index, rest, _ = strings.Cut(after, " ")
+	}
+	return relation, index, strings.TrimSpace(rest)
+}
+
+// jsonNode converts a member of EXPLAIN FORMAT=JSON, query_block at the top,
+// to a node of the plan tree
+func jsonNode(document map[string]interface{}) *domain.PlanNode {
+	block, ok := document["query_block"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return queryBlockNode(block)
+}
+
+// queryBlockNode converts a query block, a SELECT of the statement
+func queryBlockNode(block map[string]interface{}) *domain.PlanNode {
+	node := &domain.PlanNode{NodeType: "Query block"}
+	if id, ok := block["select_id"]; ok {
+		node.NodeType += fmt.Sprintf(" #%v", id)
+	}
+	if costInfo, ok := block["cost_info"].(map[string]interface{}); ok {
+		node.TotalCost = number(costInfo["query_cost"])
+	}
+	if message, ok := block["message"].(string); ok {
+		node.Detail = message
+	}
+	node.Children = operationChildren(block)
+	return node
+}
+
+// operationChildren converts the operations a query block or an operation
+// holds: tables, joins, sorts, groupings and subqueries
+func operationChildren(object map[string]interface{}) []*domain.PlanNode {
+	var children []*domain.PlanNode
+
+	operations := []struct{ key, nodeType string }{
+		{"ordering_operation", "Sort"},
+		{"grouping_operation", "Group"},
+		{"duplicates_removal", "Remove duplicates"},
+		{"windowing", "Window"},
+		{"buffer_result", "Buffer result"},
+	}
+	for _, operation := range operations {
+		member, ok := object[operation.key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		node := &domain.PlanNode{NodeType: operation.nodeType}
+		var details []string
+		if b, _ := member["using_filesort"].(bool); b {
+			details = append(details, "using filesort")
+		}
+		if b, _ := member["using_temporary_table"].(bool); b {
+			details = append(details, "using temporary table")
+		}
+		node.Detail = strings.Join(details, ", ")
+		node.Children = operationChildren(member)
+		children = append(children, node)
+	}
+
+	if loop, ok := object["nested_loop"].([]interface{}); ok {
+		node := &domain.PlanNode{NodeType: "Nested loop"}
+		for _, item := range loop {
+			if member, ok := item.(map[string]interface{}); ok {
+				node.Children = append(node.Children, operationChildren(member)...)
+			}
+		}
+		children = append(children, node)
+	}
+
+	if table, ok := object["table"].(map[string]interface{}); ok {
+		children = append(children, tableNode(table))
+	}
+
+	if union, ok := object["union_result"].(map[string]interface{}); ok {
+		node := &domain.PlanNode{NodeType: "Union"}
+		if b, _ := union["using_temporary_table"].(bool); b {
+			node.Detail = "using temporary table"
+		}
+		specifications, _ := union["query_specifications"].([]interface{})
+		for _, item := range specifications {
+			if member, ok := item.(map[string]interface{}); ok {
+				if child := jsonNode(member); child != nil {
+					node.Children = append(node.Children, child)
+				}
+			}
+		}
+		children = append(children, node)
+	}
+
+	return append(children, subqueryChildren(object)...)
+}
+
+// tableNode converts the access to a table
+func tableNode(table map[string]interface{}) *domain.PlanNode {
+	accessType, _ := table["access_type"].(string)
+	node := &domain.PlanNode{NodeType: accessType}
+	if name, ok := accessTypes[accessType]; ok {
+		node.NodeType = name
+	}
+	node.Relation, _ = table["table_name"].(string)
+	node.Index, _ = table["key"].(string)
+	node.Detail, _ = table["attached_condition"].(string)
+	node.FullScan = accessType == "ALL" && !strings.HasPrefix(node.Relation, "<")
+	node.EstimatedRows = number(table["rows_examined_per_scan"])
+	if costInfo, ok := table["cost_info"].(map[string]interface{}); ok {
+		node.TotalCost = number(costInfo["prefix_cost"])
+	}
+
+	if member, ok := table["materialized_from_subquery"].(map[string]interface{}); ok {
+		if child := jsonNode(member); child != nil {
+			node.Children = append(node.Children, &domain.PlanNode{
+				NodeType: "Materialize",
+				Children: []*domain.PlanNode{child},
+			})
+		}
+	}
+	node.Children = append(node.Children, subqueryChildren(table)...)
+	return node
+}
+
+// subqueryChildren converts the subqueries listed by an object
+func subqueryChildren(object map[string]interface{}) []*domain.PlanNode {
+	var children []*domain.PlanNode
+	for _, key := range subqueryKeys {
+		subqueries, _ := object[key].([]interface{})
+		for _, item := range subqueries {
+			member, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			child := jsonNode(member)
+			if child == nil {
+				continue
+			}
+			if b, _ := member["dependent"].(bool); b {
+				child.Detail = strings.TrimSpace("dependent subquery " + child.Detail)
+			}
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// operationNode converts a node of explain_json_format_version=2, which has
+// the fields of EXPLAIN ANALYZE under their own names
+func operationNode(object map[string]interface{}) *domain.PlanNode {
+	operation, _ := object["operation"].(string)
+	node := treeNode(operation)
+	if relation, ok := object["table_name"].(string); ok {
+		node.Relation = relation
+	}
+	if index, ok := object["index_name"].(string); ok {
+		node.Index = index
+	}
+	if accessType, _ := object["access_type"].(string); accessType == "table" {
+		node.FullScan = !strings.HasPrefix(node.Relation, "<")
+	}
+	node.EstimatedRows = number(object["estimated_rows"])
+	node.StartupCost = number(object["estimated_first_row_cost"])
+	node.TotalCost = number(object["estimated_total_cost"])
+	node.ActualRows = number(object["actual_rows"])
+	node.Loops = number(object["actual_loops"])
+	if last := number(object["actual_last_row_ms"]); last != nil && node.Loops != nil {
+		total := *last * *node.Loops
+		node.ActualTime = &total
+	}
+	if node.FullScan && node.ActualRows != nil && node.Loops != nil {
+		scanned := *node.ActualRows * *node.Loops
+		node.RowsScanned = &scanned
+	}
+
+	inputs, _ := object["inputs"].([]interface{})
+	for _, item := range inputs {
+		if member, ok := item.(map[string]interface{}); ok {
+			node.Children = append(node.Children, operationNode(member))
+		}
+	}
+
+	// Subqueries hang off the node under their own keys
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "_subqueries") {
+			continue
+		}
+		subqueries, _ := object[key].([]interface{})
+		for _, item := range subqueries {
+			if member, ok := item.(map[string]interface{}); ok {
+				node.Children = append(node.Children, operationNode(member))
+			}
+		}
+	}
+	return node
+}
+
+// number reads a number of a JSON plan, which MySQL prints as text for costs
+func number(value interface{}) *float64 {
+	switch v := value.(type) {
+	case json.Number:
+		return parseNumber(v.String())
+	case string:
+		return parseNumber(v)
+	case float64:
+		return &v
+	}
+	return nil
+}
+
+// parseNumber parses a number of a plan, nil when it is not one
+func parseNumber(text string) *float64 {
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
@@ -0,0 +1,516 @@
+package mysql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+// planSession answers every statement with a plan, printed in a single row
+// as MySQL does
+type planSession struct {
+	plan       string
+	statements []string
+}
+
+func (s *planSession) Query(ctx context.Context, statement string, args ...interface{}) (domain.QueryCursor, error) {
+	s.statements = append(s.statements, statement)
+	return domain.NewStaticCursor([]domain.ColumnType{{Name: "EXPLAIN"}}, [][]interface{}{{s.plan}}, 0), nil
+}
+
+func (s *planSession) Close() error {
+	return nil
+}
+
+// node is what a test expects of a plan node
+type node struct {
+	nodeType      string
+	relation      string
+	index         string
+	detail        string
+	fullScan      bool
+	estimatedRows *float64
+	totalCost     *float64
+	actualRows    *float64
+	loops         *float64
+	actualTime    *float64
+	rowsScanned   *float64
+	children      []node
+}
+
+func nodeOf(n *domain.PlanNode) node {
+	got := node{
+		nodeType:      n.NodeType,
+		relation:      n.Relation,
+		index:         n.Index,
+		detail:        n.Detail,
+		fullScan:      n.FullScan,
+		estimatedRows: n.EstimatedRows,
+		totalCost:     n.TotalCost,
+		actualRows:    n.ActualRows,
+		loops:         n.Loops,
+		actualTime:    n.ActualTime,
+		rowsScanned:   n.RowsScanned,
+	}
+	for _, child := range n.Children {
+		got.children = append(got.children, nodeOf(child))
+	}
+	return got
+}
+
+func float(f float64) *float64 {
+	return &f
+}
+
+// Plans printed by MySQL 8.0 for a shop schema of orders and customers
+const (
+	nestedLoopJSON = `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {
+      "query_cost": "2220.33"
+    },
+    "nested_loop": [
+      {
+        "table": {
+          "table_name": "o",
+          "access_type": "ALL",
+          "possible_keys": [
+            "customer_id"
+          ],
+          "rows_examined_per_scan": 10152,
+          "rows_produced_per_join": 3383,
+          "filtered": "33.33",
+          "cost_info": {
+            "read_cost": "697.68",
+            "eval_cost": "338.37",
+            "prefix_cost": "1036.05",
+            "data_read_per_join": "79K"
+          },
+          "used_columns": [
+            "id",
+            "customer_id",
+            "total"
+          ],
+          "attached_condition": "(` + "`shop`.`o`.`total`" + ` > 100)"
+        }
+      },
+      {
+        "table": {
+          "table_name": "c",
+          "access_type": "eq_ref",
+          "possible_keys": [
+            "PRIMARY"
+          ],
+          "key": "PRIMARY",
+          "used_key_parts": [
+            "id"
+          ],
+          "key_length": "4",
+          "ref": [
+            "shop.o.customer_id"
+          ],
+          "rows_examined_per_scan": 1,
+          "rows_produced_per_join": 3383,
+          "filtered": "100.00",
+          "cost_info": {
+            "read_cost": "845.91",
+            "eval_cost": "338.37",
+            "prefix_cost": "2220.33",
+            "data_read_per_join": "1M"
+          },
+          "used_columns": [
+            "id",
+            "name"
+          ]
+        }
+      }
+    ]
+  }
+}`
+
+	subqueryJSON = `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {
+      "query_cost": "101.25"
+    },
+    "ordering_operation": {
+      "using_filesort": true,
+      "table": {
+        "table_name": "c",
+        "access_type": "ALL",
+        "rows_examined_per_scan": 1000,
+        "rows_produced_per_join": 1000,
+        "filtered": "100.00",
+        "cost_info": {
+          "read_cost": "1.25",
+          "eval_cost": "100.00",
+          "prefix_cost": "101.25",
+          "data_read_per_join": "242K"
+        },
+        "used_columns": [
+          "id",
+          "name"
+        ]
+      },
+      "select_list_subqueries": [
+        {
+          "dependent": true,
+          "cacheable": false,
+          "query_block": {
+            "select_id": 2,
+            "cost_info": {
+              "query_cost": "1.10"
+            },
+            "table": {
+              "table_name": "o",
+              "access_type": "ref",
+              "possible_keys": [
+                "customer_id"
+              ],
+              "key": "customer_id",
+              "used_key_parts": [
+                "customer_id"
+              ],
+              "key_length": "4",
+              "ref": [
+                "shop.c.id"
+              ],
+              "rows_examined_per_scan": 3,
+              "rows_produced_per_join": 3,
+              "filtered": "100.00",
+              "using_index": true,
+              "cost_info": {
+                "read_cost": "0.75",
+                "eval_cost": "0.34",
+                "prefix_cost": "1.10",
+                "data_read_per_join": "81"
+              },
+              "used_columns": [
+                "id",
+                "customer_id"
+              ]
+            }
+          }
+        }
+      ]
+    }
+  }
+}`
+
+	unionJSON = `{
+  "query_block": {
+    "union_result": {
+      "using_temporary_table": true,
+      "table_name": "<union1,2>",
+      "access_type": "ALL",
+      "query_specifications": [
+        {
+          "dependent": false,
+          "cacheable": true,
+          "query_block": {
+            "select_id": 1,
+            "message": "No tables used"
+          }
+        },
+        {
+          "dependent": false,
+          "cacheable": true,
+          "query_block": {
+            "select_id": 2,
+            "cost_info": {
+              "query_cost": "0.35"
+            },
+            "table": {
+              "table_name": "c",
+              "access_type": "const",
+              "possible_keys": [
+                "PRIMARY"
+              ],
+              "key": "PRIMARY",
+              "used_key_parts": [
+                "id"
+              ],
+              "key_length": "4",
+              "ref": [
+                "const"
+              ],
+              "rows_examined_per_scan": 1,
+              "rows_produced_per_join": 1,
+              "filtered": "100.00",
+              "cost_info": {
+                "read_cost": "0.00",
+                "eval_cost": "0.10",
+                "prefix_cost": "0.00",
+                "data_read_per_join": "248"
+              },
+              "used_columns": [
+                "id"
+              ]
+            }
+          }
+        }
+      ]
+    }
+  }
+}`
+
+	// explain_json_format_version=2, MySQL 8.3
+	operationJSON = `{
+  "query": "/* select#1 */ select ` + "`shop`.`o`.`id` AS `id`" + ` from ` + "`shop`.`orders` `o`" + ` where (` + "`shop`.`o`.`total`" + ` > 100)",
+  "inputs": [
+    {
+      "operation": "Table scan on o",
+      "table_name": "o",
+      "access_type": "table",
+      "schema_name": "shop",
+      "used_columns": [
+        "id",
+        "total"
+      ],
+      "estimated_rows": 10152.0,
+      "estimated_total_cost": 1036.05,
+      "estimated_first_row_cost": 0.10206
+    }
+  ],
+  "condition": "(o.total > 100)",
+  "operation": "Filter: (o.total > 100)",
+  "access_type": "filter",
+  "estimated_rows": 3383.661,
+  "filter_columns": [
+    "shop.o.total"
+  ],
+  "estimated_total_cost": 1036.05,
+  "estimated_first_row_cost": 0.30619,
+  "query_type": "select",
+  "json_schema_version": "2.0"
+}`
+
+	nestedLoopTree = `-> Nested loop inner join  (cost=2220.33 rows=3384) (actual time=0.098..30.120 rows=48210 loops=1)
+    -> Filter: (o.total > 100)  (cost=1036.05 rows=3384) (actual time=0.071..18.870 rows=48210 loops=1)
+        -> Table scan on o  (cost=1036.05 rows=10152) (actual time=0.068..12.102 rows=100000 loops=1)
+    -> Single-row index lookup on c using PRIMARY (id=o.customer_id)  (cost=0.25 rows=1) (actual time=0.0002..0.0002 rows=1 loops=48210)
+`
+
+	subqueryTree = `-> Filter: exists(select #2)  (cost=101.25 rows=1000) (actual time=0.061..0.552 rows=0 loops=1)
+    -> Table scan on c  (cost=101.25 rows=1000) (actual time=0.049..0.388 rows=1000 loops=1)
+    -> Select #2 (subquery in condition; dependent)
+        -> Limit: 1 row(s)  (cost=1.10 rows=1) (never executed)
+            -> Index lookup on o using customer_id (customer_id=c.id), with index condition: (o.status = 'refunded'
+                and o.total > 100)  (cost=1.10 rows=3) (never executed)
+`
+)
+
+func TestExplainQuery(t *testing.T) {
+	// Times are per loop, multiplied out at run time
+	lookupTime := 0.0002
+
+	tests := []struct {
+		name    string
+		plan    string
+		options domain.ExplainOptions
+		// statement is the EXPLAIN run for the query
+		statement string
+		root      node
+		hotspots  []string
+	}{
+		{
+			name:      "nested loop",
+			plan:      nestedLoopJSON,
+			statement: "EXPLAIN FORMAT=JSON SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE o.total > 100",
+			root: node{
+				nodeType:  "Query block #1",
+				totalCost: float(2220.33),
+				children: []node{{
+					nodeType: "Nested loop",
+					children: []node{
+						{nodeType: "Table scan", relation: "o", detail: "(`shop`.`o`.`total` > 100)", fullScan: true, estimatedRows: float(10152), totalCost: float(1036.05)},
+						{nodeType: "Single-row index lookup", relation: "c", index: "PRIMARY", estimatedRows: float(1), totalCost: float(2220.33)},
+					},
+				}},
+			},
+			hotspots: []string{"Table scan on o reads 10152 rows, an index on the filtered columns may avoid it"},
+		},
+		{
+			name:      "dependent subquery of a sorted select",
+			plan:      subqueryJSON,
+			statement: "EXPLAIN FORMAT=JSON SELECT c.id, (SELECT COUNT(*) FROM orders o WHERE o.customer_id = c.id) FROM customers c ORDER BY 2",
+			root: node{
+				nodeType:  "Query block #1",
+				totalCost: float(101.25),
+				children: []node{{
+					nodeType: "Sort",
+					detail:   "using filesort",
+					children: []node{
+						{nodeType: "Table scan", relation: "c", fullScan: true, estimatedRows: float(1000), totalCost: float(101.25)},
+						{
+							nodeType:  "Query block #2",
+							detail:    "dependent subquery",
+							totalCost: float(1.10),
+							children: []node{
+								{nodeType: "Index lookup", relation: "o", index: "customer_id", estimatedRows: float(3), totalCost: float(1.10)},
+							},
+						},
+					},
+				}},
+			},
+		},
+		{
+			name:      "union of a select without tables or costs",
+			plan:      unionJSON,
+			statement: "EXPLAIN FORMAT=JSON SELECT 1 UNION SELECT id FROM customers WHERE id = 1",
+			root: node{
+				nodeType: "Query block",
+				children: []node{{
+					nodeType: "Union",
+					detail:   "using temporary table",
+					children: []node{
+						{nodeType: "Query block #1", detail: "No tables used"},
+						{
+							nodeType:  "Query block #2",
+							totalCost: float(0.35),
+							children: []node{
+								{nodeType: "Constant row", relation: "c", index: "PRIMARY", estimatedRows: float(1), totalCost: float(0)},
+							},
+						},
+					},
+				}},
+			},
+		},
+		{
+			name:      "JSON format version 2",
+			plan:      operationJSON,
+			statement: "EXPLAIN FORMAT=JSON SELECT id FROM orders o WHERE o.total > 100",
+			root: node{
+				nodeType:      "Filter",
+				detail:        "(o.total > 100)",
+				estimatedRows: float(3383.661),
+				totalCost:     float(1036.05),
+				children: []node{
+					{nodeType: "Table scan", relation: "o", fullScan: true, estimatedRows: float(10152), totalCost: float(1036.05)},
+				},
+			},
+			hotspots: []string{"Table scan on o reads 10152 rows, an index on the filtered columns may avoid it"},
+		},
+		{
+			name:      "analyzed nested loop",
+			plan:      nestedLoopTree,
+			options:   domain.ExplainOptions{Analyze: true},
+			statement: "EXPLAIN ANALYZE SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE o.total > 100",
+			root: node{
+				nodeType: "Nested loop inner join", estimatedRows: float(3384), totalCost: float(2220.33),
+				actualRows: float(48210), loops: float(1), actualTime: float(30.120),
+				children: []node{
+					{
+						nodeType: "Filter", detail: "(o.total > 100)", estimatedRows: float(3384), totalCost: float(1036.05),
+						actualRows: float(48210), loops: float(1), actualTime: float(18.870),
+						children: []node{{
+							nodeType: "Table scan", relation: "o", fullScan: true, estimatedRows: float(10152), totalCost: float(1036.05),
+							actualRows: float(100000), loops: float(1), actualTime: float(12.102), rowsScanned: float(100000),
+						}},
+					},
+					{
+						nodeType: "Single-row index lookup", relation: "c", index: "PRIMARY", detail: "(id=o.customer_id)",
+						estimatedRows: float(1), totalCost: float(0.25),
+						actualRows: float(1), loops: float(48210), actualTime: float(lookupTime * 48210),
+					},
+				},
+			},
+			hotspots: []string{
+				"Table scan on o takes 12.102 ms, 40% of the execution time",
+				"Single-row index lookup on c takes 9.642 ms, 32% of the execution time",
+				"Filter takes 6.768 ms, 22% of the execution time",
+				"Nested loop inner join returned 48210 rows where 3384 were estimated, 14x more",
+				"Filter returned 48210 rows where 3384 were estimated, 14x more",
+				"Table scan on o reads 100000 rows, an index on the filtered columns may avoid it",
+			},
+		},
+		{
+			name:      "analyzed subquery never executed",
+			plan:      subqueryTree,
+			options:   domain.ExplainOptions{Analyze: true},
+			statement: "EXPLAIN ANALYZE SELECT * FROM customers c WHERE EXISTS (SELECT 1 FROM orders o WHERE o.customer_id = c.id AND o.status = 'refunded' AND o.total > 100)",
+			root: node{
+				nodeType: "Filter", detail: "exists(select #2)", estimatedRows: float(1000), totalCost: float(101.25),
+				actualRows: float(0), loops: float(1), actualTime: float(0.552),
+				children: []node{
+					{
+						nodeType: "Table scan", relation: "c", fullScan: true, estimatedRows: float(1000), totalCost: float(101.25),
+						actualRows: float(1000), loops: float(1), actualTime: float(0.388), rowsScanned: float(1000),
+					},
+					{
+						nodeType: "Select #2 (subquery in condition; dependent)",
+						children: []node{{
+							nodeType: "Limit", detail: "1 row(s)", estimatedRows: float(1), totalCost: float(1.10), loops: float(0),
+							children: []node{{
+								nodeType: "Index lookup", relation: "o", index: "customer_id",
+								detail:        "(customer_id=c.id), with index condition: (o.status = 'refunded' and o.total > 100)",
+								estimatedRows: float(3), totalCost: float(1.10), loops: float(0),
+							}},
+						}},
+					},
+				},
+			},
+			hotspots: []string{
+				"Table scan on c takes 0.388 ms, 70% of the execution time",
+				"Filter takes 0.164 ms, 30% of the execution time",
+				"Filter returned 0 rows where 1000 were estimated, 1000x fewer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &planSession{plan: tt.plan}
+			query := tt.statement[len("EXPLAIN FORMAT=JSON "):]
+			if tt.options.Analyze {
+				query = tt.statement[len("EXPLAIN ANALYZE "):]
+			}
+
+			plan, err := (&MySQLService{}).ExplainQuery(context.Background(), session, query, nil, tt.options)
+			if err != nil {
+				t.Fatalf("ExplainQuery() error = %v", err)
+			}
+
+			if want := []string{tt.statement}; !reflect.DeepEqual(session.statements, want) {
+				t.Errorf("statements = %q, want %q", session.statements, want)
+			}
+			if plan.Analyzed != tt.options.Analyze {
+				t.Errorf("Analyzed = %v, want %v", plan.Analyzed, tt.options.Analyze)
+			}
+			if got := nodeOf(plan.Root); !reflect.DeepEqual(got, tt.root) {
+				t.Errorf("root = %+v\nwant %+v", got, tt.root)
+			}
+
+			var hotspots []string
+			for _, h := range plan.Hotspots {
+				hotspots = append(hotspots, h.Message)
+			}
+			if !reflect.DeepEqual(hotspots, tt.hotspots) {
+				t.Errorf("hotspots = %q\nwant %q", hotspots, tt.hotspots)
+			}
+		})
+	}
+}
+
+func TestExplainQueryRejectsBadPlans(t *testing.T) {
+	tests := []struct {
+		plan    string
+		options domain.ExplainOptions
+	}{
+		{plan: `{"warnings": []}`},
+		{plan: `not a plan`},
+		{plan: "Query OK", options: domain.ExplainOptions{Analyze: true}},
+		{plan: "-> Table scan on a  (cost=1 rows=1)\n-> Table scan on b  (cost=1 rows=1)", options: domain.ExplainOptions{Analyze: true}},
+	}
+
+	for _, tt := range tests {
+		session := &planSession{plan: tt.plan}
+		if _, err := (&MySQLService{}).ExplainQuery(context.Background(), session, "SELECT 1", nil, tt.options); err == nil {
+			t.Errorf("ExplainQuery() of %q returned no error", tt.plan)
+		}
+	}
+}
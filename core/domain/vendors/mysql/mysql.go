@@ -0,0 +1,133 @@
+package mysql
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+const (
+	vendorName  = "mysql"
+	defaultPort = 3306
+)
+
+// Vendor describes MySQL to the vendor registry
+var Vendor = &domain.Vendor{
+	Name:        vendorName,
+	DisplayName: "MySQL",
+	Schemes:     []string{"mysql"},
+	DefaultPort: defaultPort,
+	DriverName:  "mysql",
+	Fields: []domain.FormField{
+		{Name: "host", Label: "Host", Type: "text", Required: true, Placeholder: "localhost"},
+		{Name: "port", Label: "Port", Type: "number", Required: true, Placeholder: "3306"},
+		{Name: "database", Label: "Database", Type: "text"},
+		{Name: "username", Label: "Username", Type: "text", Required: true},
+		{Name: "password", Label: "Password", Type: "password"},
+	},
+	SSLModes: domain.TLSModes,
+	Arguments: []domain.ArgumentSpec{
+		{Name: "timeout", Label: "Dial timeout", Description: "Connection timeout with unit suffix, e.g. 10s", Type: "string"},
+		{Name: "charset", Label: "Charset", Description: "Connection character set", Type: "string", Default: "utf8mb4"},
+		{Name: "parseTime", Label: "Parse time", Description: "Scan DATE and DATETIME columns as time values", Type: "bool", Default: "false"},
+		{Name: "loc", Label: "Location", Description: "Time zone used to parse time values", Type: "string", Default: "UTC"},
+		{Name: "allowCleartextPasswords", Label: "Allow cleartext passwords", Description: "Needed by some authentication plugins, only safe over TLS", Type: "bool", Default: "false"},
+	},
+	SupportsSSH:   true,
+	BuildDSN:      buildConnectionString,
+	OpenConnector: openConnector,
+	TLSArguments:  tlsArguments,
+	NewService: func(sessions *domain.SessionManager) domain.DatabaseService {
+		return NewMySQLService(sessions)
+	},
+	TablesQuery: func(c *domain.Connection) string {
+		return `
+			SELECT table_name, table_schema
+			FROM information_schema.tables 
+			WHERE table_schema = DATABASE()
+			AND table_type = 'BASE TABLE'
+			ORDER BY table_name
+		`
+	},
+	QuoteIdentifier: quoteIdentifier,
+	Formats: []domain.ConnectionStringFormat{
+		{
+			Name:  domain.URLFormat,
+			Label: "URL",
+			Parse: domain.ParseNetworkURL,
+			Render: func(c *domain.Connection, includePassword bool) string {
+				return domain.RenderNetworkURL(c, "mysql", portableArguments(c), includePassword)
+			},
+		},
+		{
+			Name:   "dsn",
+			Label:  "Go MySQL DSN",
+			Match:  isDSN,
+			Parse:  parseDSN,
+			Render: renderDSN,
+		},
+	},
+	PromptHints: []string{
+		"Consider MySQL-specific features like AUTO_INCREMENT, LIMIT, and backticks for identifiers",
+		"Use backticks for identifiers if needed",
+	},
+	Dialect: domain.SQLDialect{BackslashEscapes: true, HashComments: true, SpacedDashComments: true, DelimiterCommand: true, QuestionPlaceholders: true, ImplicitCommits: true, ReadOnlyBegin: "START TRANSACTION READ ONLY"},
+}
+
+func init() {
+	domain.RegisterVendor(Vendor)
+}
+
+// buildConnectionString renders a Go MySQL driver DSN
+func buildConnectionString(c *domain.Connection) string {
+	// MySQL connection string format: user:password@tcp(host:port)/database?params
+	connStr := fmt.Sprintf("%s:%s@%s/%s", c.Username(), c.Password(), address(c), c.Database())
+
+	arguments := c.Arguments()
+	if t := c.TLS(); t != nil {
+		arguments["tls"] = "false"
+		if t.Enabled() {
+			arguments["tls"] = tlsProfile(c)
+		}
+	}
+	if timeout := c.StatementTimeout(); timeout > 0 {
+		// Set on every session by the driver, it only applies to SELECT.
+		// Other statements run on a session are stopped with KILL QUERY, by
+		// the canceler OpenSession registers, once the timeout passes on the
+		// client; those run on the pool outside a session have no limit.
+		arguments["max_execution_time"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+	if len(arguments) > 0 {
+		keys := make([]string, 0, len(arguments))
+		for k := range arguments {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		args := make([]string, 0, len(keys))
+		for _, k := range keys {
+			args = append(args, fmt.Sprintf("%s=%s", k, arguments[k]))
+		}
+		connStr += "?" + strings.Join(args, "&")
+	}
+
+	return connStr
+}
+
+// address renders the network and address part of a DSN, e.g. tcp(host:3306)
+// or unix(/run/mysqld/mysqld.sock)
+func address(c *domain.Connection) string {
+	if c.UnixSocket() {
+		return "unix(" + c.Host() + ")"
+	}
+	return "tcp(" + net.JoinHostPort(c.Host(), strconv.Itoa(c.Port())) + ")"
+}
+
+// quoteIdentifier quotes a MySQL identifier with backticks
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,242 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"seagle/core/domain"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+type MySQLService struct {
+	sessions *domain.SessionManager
+}
+
+func NewMySQLService(sessions *domain.SessionManager) *MySQLService {
+	return &MySQLService{
+		sessions: sessions,
+	}
+}
+
+func (s *MySQLService) pooledDBConn(c *domain.Connection) *sql.DB {
+	return s.sessions.Get(c)
+}
+
+func (s *MySQLService) Connect(c *domain.Connection) error {
+	_, err := s.sessions.Open(c, Vendor)
+	return err
+}
+
+func (s *MySQLService) Disconnect(c *domain.Connection) error {
+	return s.sessions.Close(c)
+}
+
+func (s *MySQLService) GetDatabaseNames(c *domain.Connection) ([]string, error) {
+	query := `SHOW DATABASES`
+
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	rows, err := dbConn.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query databases: %w", err)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var dbName string
+		if err := rows.Scan(&dbName); err != nil {
+			return nil, fmt.Errorf("failed to scan database name: %w", err)
+		}
+		// Filter out MySQL system databases
+		if dbName != "information_schema" && dbName != "mysql" && dbName != "performance_schema" && dbName != "sys" {
+			databases = append(databases, dbName)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating database results: %w", err)
+	}
+
+	return databases, nil
+}
+
+func (s *MySQLService) GetTableNames(c *domain.Connection, databaseName string) ([]string, error) {
+	query := `
+		SELECT table_name 
+		FROM information_schema.tables 
+		WHERE table_schema = ? 
+		AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`
+
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	rows, err := dbConn.Query(query, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tables for database %s: %w", databaseName, err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating table results: %w", err)
+	}
+
+	return tables, nil
+}
+
+func (s *MySQLService) GetTableColumns(c *domain.Connection, databaseName, tableName string) ([]domain.ColumnMetadata, error) {
+	query := `
+		SELECT column_name, data_type, is_nullable, column_default, ordinal_position
+		FROM information_schema.columns
+		WHERE table_schema = ? AND table_name = ?
+		ORDER BY ordinal_position		
+	`
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	rows, err := dbConn.Query(query, databaseName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns for table %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	type Col struct {
+		Name         string
+		DataType     string
+		IsNullable   string
+		DefaultValue sql.NullString
+		Position     int
+	}
+
+	var columns []domain.ColumnMetadata
+	for rows.Next() {
+		var col Col
+		if err := rows.Scan(&col.Name, &col.DataType, &col.IsNullable, &col.DefaultValue, &col.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan column metadata: %w", err)
+		}
+
+		column := domain.NewColumnMetadata(col.Name, col.DataType, col.IsNullable == "YES", col.DefaultValue.String, col.Position)
+		columns = append(columns, *column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating column results: %w", err)
+	}
+
+	return columns, nil
+}
+
+// OpenSession takes a session out of the pool, kept until it is closed. Its
+// statements are canceled on the server with KILL QUERY, run on a connection
+// of its own to the host of the session. The driver has no server-side
+// cursors, the rows of a result are streamed from the server as its pages
+// are read, holding the session until the result is closed.
+func (s *MySQLService) OpenSession(ctx context.Context, c *domain.Connection) (domain.QuerySession, error) {
+	dbConn, release := s.sessions.Acquire(c)
+	if dbConn == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	session, err := dbConn.Conn(ctx)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	// KILL QUERY needs the id of the session's thread
+	var threadID int64
+	if err := session.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&threadID); err != nil {
+		session.Close()
+		release()
+		return nil, fmt.Errorf("failed to get connection id: %w", err)
+	}
+
+	cancelServer := s.sessions.Canceler(c, Vendor)
+	if cancelServer == nil {
+		session.Close()
+		release()
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	return domain.NewConnSession(session, Vendor.Dialect, func() error {
+		return cancelServer(fmt.Sprintf("KILL QUERY %d", threadID))
+	}, release), nil
+}
+
+// ExecQuery runs the query until it completes or ctx is done
+func (s *MySQLService) ExecQuery(ctx context.Context, c *domain.Connection, query string) (*domain.QueryResult, error) {
+	session, err := s.OpenSession(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	result, err := domain.RunQuery(ctx, session, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	return result, nil
+}
+
+func (s *MySQLService) GetTableMetadata(c *domain.Connection, tableName, schemaName string) (*domain.TableMetadata, error) {
+	db := s.pooledDBConn(c)
+	if db == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	metadata := domain.NewTableMetadata(tableName, schemaName)
+
+	query := `
+		SELECT 
+			column_name,
+			data_type,
+			is_nullable = 'YES' as is_nullable,
+			COALESCE(column_default, '') as column_default,
+			ordinal_position
+		FROM information_schema.columns 
+		WHERE table_schema = ? 
+		AND table_name = ?
+		ORDER BY ordinal_position
+	`
+
+	rows, err := db.Query(query, schemaName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns for table %s.%s: %w", schemaName, tableName, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, dataType, defaultValue string
+		var isNullable bool
+		var position int
+
+		if err := rows.Scan(&name, &dataType, &isNullable, &defaultValue, &position); err != nil {
+			return nil, fmt.Errorf("failed to scan column metadata: %w", err)
+		}
+
+		column := domain.NewColumnMetadata(name, dataType, isNullable, defaultValue, position)
+		metadata.AddColumn(column)
+	}
+
+	return metadata, rows.Err()
+}
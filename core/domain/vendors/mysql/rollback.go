@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"seagle/core/domain"
+)
+
+// tableEngineQuery reads the type of a table, its engine and whether the
+// engine supports transactions. Unqualified tables are looked for in the
+// database of the session.
+const tableEngineQuery = `
+	SELECT t.TABLE_TYPE, COALESCE(t.ENGINE, ''), COALESCE(e.TRANSACTIONS, '')
+	FROM information_schema.TABLES t
+	LEFT JOIN information_schema.ENGINES e ON e.ENGINE = t.ENGINE
+	WHERE t.TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())
+	AND t.TABLE_NAME = ?
+`
+
+// CheckRollback fails for tables of engines without transactions, as MyISAM
+// and MEMORY, whose changes are kept after a rollback, and for views, whose
+// tables are not looked up. Names missing from information_schema, as
+// aliases, are skipped.
+func (s *MySQLService) CheckRollback(c *domain.Connection, tables []domain.TableName) error {
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return fmt.Errorf("no active connection found")
+	}
+
+	for _, table := range tables {
+		var tableType, engine, transactions string
+		err := dbConn.QueryRow(tableEngineQuery, table.Schema, table.Name).Scan(&tableType, &engine, &transactions)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the engine of table %s: %w", table, err)
+		}
+
+		if tableType == "VIEW" {
+			return fmt.Errorf("%s is a view, the changes made through it may not roll back", table)
+		}
+		if transactions != "YES" {
+			return fmt.Errorf("table %s uses the %s engine, which has no transactions: its changes would not roll back", table, engine)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"crypto/tls"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+
+	"seagle/core/domain"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// peerStates keeps the last TLS handshake seen by every registered profile,
+// since the driver does not expose it
+var peerStates sync.Map
+
+// tlsProfile is the name the TLS settings of a connection are registered under
+func tlsProfile(c *domain.Connection) string {
+	return "seagle-" + c.ID()
+}
+
+// openConnector registers the TLS profile of the connection, if any, before
+// handing the DSN referencing it to the driver. Read-only connections get
+// their sessions switched to read-only as they are opened.
+func openConnector(c *domain.Connection) (driver.Connector, error) {
+	if t := c.TLS(); t.Enabled() {
+		cfg, err := t.ClientConfig(c.Host())
+		if err != nil {
+			return nil, err
+		}
+
+		profile := tlsProfile(c)
+		verify := cfg.VerifyConnection
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if verify != nil {
+				if err := verify(cs); err != nil {
+					return err
+				}
+			}
+			peerStates.Store(profile, domain.NewTLSState(cs))
+			return nil
+		}
+
+		if err := mysql.RegisterTLSConfig(profile, cfg); err != nil {
+			return nil, fmt.Errorf("failed to register TLS profile: %w", err)
+		}
+	}
+
+	connector, err := mysql.MySQLDriver{}.OpenConnector(buildConnectionString(c))
+	if err != nil {
+		return nil, err
+	}
+	if c.ReadOnly() {
+		return readOnlyConnector{Connector: connector}, nil
+	}
+	return connector, nil
+}
+
+// TLSState reports the TLS session of the open connection, as seen by the
+// server. The certificate is only known when the connection has TLS settings.
+func (s *MySQLService) TLSState(c *domain.Connection) (*domain.TLSState, error) {
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	rows, err := dbConn.Query("SHOW SESSION STATUS WHERE Variable_name IN ('Ssl_version', 'Ssl_cipher')")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TLS status: %w", err)
+	}
+	defer rows.Close()
+
+	status := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan TLS status: %w", err)
+		}
+		status[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read TLS status: %w", err)
+	}
+
+	state := domain.NewReportedTLSState(status["Ssl_version"], status["Ssl_cipher"])
+	if peer, ok := peerStates.Load(tlsProfile(c)); ok && state.Enabled && c.TLS().Enabled() {
+		state.Subject = peer.(*domain.TLSState).Subject
+		state.Issuer = peer.(*domain.TLSState).Issuer
+	}
+
+	return state, nil
+}
@@ -0,0 +1,260 @@
+package mysql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"seagle/core/domain"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// writeCertificate writes a self-signed certificate for db.internal and its
+// key, usable as CA bundle and client certificate alike
+func writeCertificate(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "db.internal"},
+		DNSNames:              []string{"db.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func newTLSConnection(t *testing.T, id string, cfg *domain.TLSConfig) *domain.Connection {
+	t.Helper()
+
+	c, err := domain.NewConnection(id, Vendor.Name, "10.0.0.5", 3306, "shop", "root", "secret", map[string]string{"tls": "preferred"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetTLS(cfg); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestBuildConnectionStringTLS(t *testing.T) {
+	tests := []struct {
+		name string
+		tls  *domain.TLSConfig
+		want string
+	}{
+		{
+			name: "driver default",
+			want: "root:secret@tcp(10.0.0.5:3306)/shop?tls=preferred",
+		},
+		{
+			name: "disable",
+			tls:  &domain.TLSConfig{Mode: "disable"},
+			want: "root:secret@tcp(10.0.0.5:3306)/shop?tls=false",
+		},
+		{
+			name: "require",
+			tls:  &domain.TLSConfig{Mode: "require"},
+			want: "root:secret@tcp(10.0.0.5:3306)/shop?tls=seagle-dsn",
+		},
+		{
+			name: "verify-full",
+			tls:  &domain.TLSConfig{Mode: "verify-full", ServerName: "db.internal"},
+			want: "root:secret@tcp(10.0.0.5:3306)/shop?tls=seagle-dsn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTLSConnection(t, "dsn", tt.tls)
+			if got := buildConnectionString(c); got != tt.want {
+				t.Errorf("buildConnectionString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenConnectorRegistersTLSProfile(t *testing.T) {
+	certFile, keyFile := writeCertificate(t)
+
+	tests := []struct {
+		name               string
+		tls                *domain.TLSConfig
+		serverName         string
+		insecureSkipVerify bool
+		verifiesChain      bool
+		roots              bool
+		certificates       int
+	}{
+		{
+			name:               "require",
+			tls:                &domain.TLSConfig{Mode: "require"},
+			serverName:         "10.0.0.5",
+			insecureSkipVerify: true,
+		},
+		{
+			name:               "verify-ca",
+			tls:                &domain.TLSConfig{Mode: "verify-ca", CAFile: certFile},
+			serverName:         "10.0.0.5",
+			insecureSkipVerify: true,
+			verifiesChain:      true,
+			roots:              true,
+		},
+		{
+			name:       "verify-full",
+			tls:        &domain.TLSConfig{Mode: "verify-full", CAFile: certFile},
+			serverName: "10.0.0.5",
+			roots:      true,
+		},
+		{
+			name:       "verify-full with a server name",
+			tls:        &domain.TLSConfig{Mode: "verify-full", CAFile: certFile, ServerName: "db.internal"},
+			serverName: "db.internal",
+			roots:      true,
+		},
+		{
+			name:         "client certificate",
+			tls:          &domain.TLSConfig{Mode: "verify-full", CertFile: certFile, KeyFile: keyFile},
+			serverName:   "10.0.0.5",
+			certificates: 1,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTLSConnection(t, fmt.Sprintf("profile-%d", i), tt.tls)
+			if _, err := openConnector(c); err != nil {
+				t.Fatalf("openConnector() error = %v", err)
+			}
+			t.Cleanup(func() { mysql.DeregisterTLSConfig(tlsProfile(c)) })
+
+			// The DSN names the registered profile, which the driver resolves
+			cfg, err := mysql.ParseDSN(buildConnectionString(c))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.TLS == nil {
+				t.Fatalf("the DSN %q has no TLS profile", buildConnectionString(c))
+			}
+			if cfg.TLS.ServerName != tt.serverName {
+				t.Errorf("ServerName = %q, want %q", cfg.TLS.ServerName, tt.serverName)
+			}
+			if cfg.TLS.InsecureSkipVerify != tt.insecureSkipVerify {
+				t.Errorf("InsecureSkipVerify = %v, want %v", cfg.TLS.InsecureSkipVerify, tt.insecureSkipVerify)
+			}
+			if roots := cfg.TLS.RootCAs != nil; roots != tt.roots {
+				t.Errorf("RootCAs set = %v, want %v", roots, tt.roots)
+			}
+			if len(cfg.TLS.Certificates) != tt.certificates {
+				t.Errorf("%d client certificates, want %d", len(cfg.TLS.Certificates), tt.certificates)
+			}
+			// Every profile records the handshake, verify-ca checks the chain
+			// there too, so only a failing check tells them apart
+			if cfg.TLS.VerifyConnection == nil {
+				t.Fatal("VerifyConnection is not set")
+			}
+			err = cfg.TLS.VerifyConnection(tls.ConnectionState{})
+			if failed := err != nil; failed != tt.verifiesChain {
+				t.Errorf("VerifyConnection() without certificates error = %v, want an error %v", err, tt.verifiesChain)
+			}
+		})
+	}
+}
+
+func TestOpenConnectorTLSErrors(t *testing.T) {
+	c := newTLSConnection(t, "missing-ca", &domain.TLSConfig{Mode: "verify-full", CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	if _, err := openConnector(c); err == nil {
+		t.Error("openConnector() with a missing CA bundle succeeded")
+	}
+}
+
+func TestPortableArguments(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{mode: "disable", want: "false"},
+		{mode: "require", want: "skip-verify"},
+		{mode: "verify-ca", want: "true"},
+		{mode: "verify-full", want: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			c := newTLSConnection(t, "portable", &domain.TLSConfig{Mode: tt.mode})
+			if got := portableArguments(c).Get("tls"); got != tt.want {
+				t.Errorf("tls = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTLSArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]string
+		want      *domain.TLSConfig
+		left      map[string]string
+		wantErr   bool
+	}{
+		{name: "false", arguments: map[string]string{"tls": "false"}, want: &domain.TLSConfig{Mode: "disable"}, left: map[string]string{}},
+		{name: "skip-verify", arguments: map[string]string{"tls": "skip-verify"}, want: &domain.TLSConfig{Mode: "require"}, left: map[string]string{}},
+		{name: "true", arguments: map[string]string{"tls": "true", "parseTime": "true"}, want: &domain.TLSConfig{Mode: "verify-full"}, left: map[string]string{"parseTime": "true"}},
+		{name: "no tls", arguments: map[string]string{"parseTime": "true"}, left: map[string]string{"parseTime": "true"}},
+		{name: "preferred", arguments: map[string]string{"tls": "preferred"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tlsArguments(tt.arguments)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tlsArguments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tlsArguments() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.arguments, tt.left) {
+				t.Errorf("arguments left = %v, want %v", tt.arguments, tt.left)
+			}
+		})
+	}
+}
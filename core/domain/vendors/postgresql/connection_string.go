@@ -0,0 +1,203 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+var keyValuePattern = regexp.MustCompile(`^\s*[A-Za-z_]+\s*=`)
+
+// isKeyValue reports whether s looks like a libpq key/value string, e.g.
+// host=localhost port=5432 dbname=app
+func isKeyValue(s string) bool {
+	return keyValuePattern.MatchString(s)
+}
+
+// parseKeyValue parses a libpq key/value string. Comma separated host and
+// port lists become failover hosts, the ssl keys the TLS settings and the
+// other keys driver arguments.
+func parseKeyValue(id, s string) (*domain.Connection, error) {
+	settings, err := splitKeyValue(s)
+	if err != nil {
+		return nil, err
+	}
+
+	hostList := settings["host"]
+	if hostList == "" {
+		hostList = settings["hostaddr"]
+	}
+	if hostList == "" {
+		return nil, fmt.Errorf("host is required in connection string")
+	}
+
+	hosts := strings.Split(hostList, ",")
+	ports := strings.Split(settings["port"], ",")
+	endpoints := make([]domain.Endpoint, len(hosts))
+	for i, host := range hosts {
+		// A single port applies to every host
+		portStr := ports[0]
+		if len(ports) == len(hosts) {
+			portStr = ports[i]
+		}
+
+		port := defaultPort
+		if portStr != "" {
+			port, err = strconv.Atoi(portStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port in connection string: %s", portStr)
+			}
+		}
+		endpoints[i] = domain.Endpoint{Host: host, Port: port}
+	}
+
+	arguments := make(map[string]string)
+	for key, value := range settings {
+		switch key {
+		case "host", "hostaddr", "port", "dbname", "user", "password":
+		default:
+			arguments[key] = value
+		}
+	}
+
+	return domain.NewParsedConnection(id, vendorName, endpoints, settings["dbname"], settings["user"], settings["password"], arguments)
+}
+
+// splitKeyValue splits key = value pairs separated by whitespace, where
+// values may be single quoted and use backslash escapes
+func splitKeyValue(s string) (map[string]string, error) {
+	settings := make(map[string]string)
+	r := []rune(s)
+	i := 0
+
+	skipSpaces := func() {
+		for i < len(r) && (r[i] == ' ' || r[i] == '\t' || r[i] == '\n' || r[i] == '\r') {
+			i++
+		}
+	}
+
+	for {
+		skipSpaces()
+		if i >= len(r) {
+			return settings, nil
+		}
+
+		start := i
+		for i < len(r) && r[i] != '=' && r[i] != ' ' && r[i] != '\t' {
+			i++
+		}
+		key := string(r[start:i])
+
+		skipSpaces()
+		if i >= len(r) || r[i] != '=' {
+			return nil, fmt.Errorf("missing \"=\" after %q in connection string", key)
+		}
+		i++
+		skipSpaces()
+
+		var value strings.Builder
+		if i < len(r) && r[i] == '\'' {
+			i++
+			closed := false
+			for i < len(r) {
+				if r[i] == '\\' && i+1 < len(r) {
+					value.WriteRune(r[i+1])
+					i += 2
+					continue
+				}
+				if r[i] == '\'' {
+					closed = true
+					i++
+					break
+				}
+				value.WriteRune(r[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated quoted value for %q in connection string", key)
+			}
+		} else {
+			for i < len(r) && r[i] != ' ' && r[i] != '\t' && r[i] != '\n' && r[i] != '\r' {
+				if r[i] == '\\' && i+1 < len(r) {
+					i++
+				}
+				value.WriteRune(r[i])
+				i++
+			}
+		}
+
+		settings[key] = value.String()
+	}
+}
+
+// renderKeyValue writes the connection as a libpq key/value string
+func renderKeyValue(c *domain.Connection, includePassword bool) string {
+	endpoints := c.Endpoints()
+	hosts := make([]string, len(endpoints))
+	ports := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		hosts[i] = endpoint.Host
+		ports[i] = strconv.Itoa(endpoint.Port)
+	}
+
+	pairs := []string{
+		"host=" + quoteKeyValue(strings.Join(hosts, ",")),
+		"port=" + strings.Join(ports, ","),
+		"dbname=" + quoteKeyValue(c.Database()),
+	}
+	if c.Username() != "" {
+		pairs = append(pairs, "user="+quoteKeyValue(c.Username()))
+	}
+	if includePassword && c.Password() != "" {
+		pairs = append(pairs, "password="+quoteKeyValue(c.Password()))
+	}
+
+	arguments := connectionArguments(c)
+	keys := make([]string, 0, len(arguments))
+	for k := range arguments {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+quoteKeyValue(arguments.Get(k)))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// quoteKeyValue quotes a value when it is empty or holds spaces, quotes or backslashes
+func quoteKeyValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\r'\\") {
+		return value
+	}
+	escaped := strings.ReplaceAll(value, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, `'`, `\'`)
+	return "'" + escaped + "'"
+}
+
+// tlsArguments takes the libpq sslmode, sslrootcert, sslcert and sslkey
+// options out of the arguments of a connection string
+func tlsArguments(arguments map[string]string) (*domain.TLSConfig, error) {
+	mode, ok := arguments["sslmode"]
+	cfg := &domain.TLSConfig{
+		Mode:     mode,
+		CAFile:   arguments["sslrootcert"],
+		CertFile: arguments["sslcert"],
+		KeyFile:  arguments["sslkey"],
+	}
+	if !ok && cfg.CAFile == "" && cfg.CertFile == "" && cfg.KeyFile == "" {
+		return nil, nil
+	}
+	if !ok {
+		return nil, fmt.Errorf("sslmode is required with sslrootcert, sslcert or sslkey")
+	}
+
+	for _, key := range []string{"sslmode", "sslrootcert", "sslcert", "sslkey"} {
+		delete(arguments, key)
+	}
+	return cfg, nil
+}
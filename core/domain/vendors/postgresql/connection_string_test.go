@@ -0,0 +1,213 @@
+package postgresql
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestParseKeyValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		endpoints []domain.Endpoint
+		database  string
+		username  string
+		password  string
+		arguments map[string]string
+		tls       *domain.TLSConfig
+	}{
+		{
+			name:      "single host",
+			s:         "host=localhost port=5433 dbname=orders user=app password=secret",
+			endpoints: []domain.Endpoint{{Host: "localhost", Port: 5433}},
+			database:  "orders",
+			username:  "app",
+			password:  "secret",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "default port and spaces around equals",
+			s:         "host = localhost dbname = orders",
+			endpoints: []domain.Endpoint{{Host: "localhost", Port: 5432}},
+			database:  "orders",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "quoted values with escapes",
+			s:         `host=localhost dbname='my db' password='it\'s \\ here'`,
+			endpoints: []domain.Endpoint{{Host: "localhost", Port: 5432}},
+			database:  "my db",
+			password:  `it's \ here`,
+			arguments: map[string]string{},
+		},
+		{
+			name:      "escape in unquoted value",
+			s:         `host=localhost password=a\ b`,
+			endpoints: []domain.Endpoint{{Host: "localhost", Port: 5432}},
+			password:  "a b",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "empty quoted value",
+			s:         "host=localhost dbname=orders password=''",
+			endpoints: []domain.Endpoint{{Host: "localhost", Port: 5432}},
+			database:  "orders",
+			arguments: map[string]string{},
+		},
+		{
+			name: "hosts with a port each",
+			s:    "host=primary,standby port=5432,5433 dbname=orders",
+			endpoints: []domain.Endpoint{
+				{Host: "primary", Port: 5432},
+				{Host: "standby", Port: 5433},
+			},
+			database:  "orders",
+			arguments: map[string]string{},
+		},
+		{
+			name: "one port for every host",
+			s:    "host=primary,standby port=6432 dbname=orders",
+			endpoints: []domain.Endpoint{
+				{Host: "primary", Port: 6432},
+				{Host: "standby", Port: 6432},
+			},
+			database:  "orders",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "hostaddr",
+			s:         "hostaddr=10.0.0.5 dbname=orders",
+			endpoints: []domain.Endpoint{{Host: "10.0.0.5", Port: 5432}},
+			database:  "orders",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "unix socket directory",
+			s:         "host=/var/run/postgresql dbname=orders",
+			endpoints: []domain.Endpoint{{Host: "/var/run/postgresql", Port: 5432}},
+			database:  "orders",
+			arguments: map[string]string{},
+		},
+		{
+			name:      "other keys are arguments",
+			s:         "host=localhost dbname=orders sslmode=require application_name='my app'",
+			endpoints: []domain.Endpoint{{Host: "localhost", Port: 5432}},
+			database:  "orders",
+			arguments: map[string]string{"application_name": "my app"},
+			tls:       &domain.TLSConfig{Mode: "require"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseKeyValue("id", tt.s)
+			if err != nil {
+				t.Fatalf("parseKeyValue(%q) error: %v", tt.s, err)
+			}
+			if got := c.Endpoints(); !reflect.DeepEqual(got, tt.endpoints) {
+				t.Errorf("endpoints = %v, want %v", got, tt.endpoints)
+			}
+			if got := c.Database(); got != tt.database {
+				t.Errorf("database = %q, want %q", got, tt.database)
+			}
+			if got := c.Username(); got != tt.username {
+				t.Errorf("username = %q, want %q", got, tt.username)
+			}
+			if got := c.Password(); got != tt.password {
+				t.Errorf("password = %q, want %q", got, tt.password)
+			}
+			if got := c.Arguments(); !reflect.DeepEqual(got, tt.arguments) {
+				t.Errorf("arguments = %v, want %v", got, tt.arguments)
+			}
+			if got := c.TLS(); !reflect.DeepEqual(got, tt.tls) {
+				t.Errorf("TLS = %+v, want %+v", got, tt.tls)
+			}
+		})
+	}
+}
+
+func TestParseKeyValueErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "no host", s: "dbname=orders user=app"},
+		{name: "missing equals", s: "host=localhost dbname"},
+		{name: "unterminated quote", s: "host=localhost password='secret"},
+		{name: "invalid port", s: "host=localhost port=abc"},
+		{name: "unsupported argument", s: "host=localhost target_session_attrs=any"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseKeyValue("id", tt.s); err == nil {
+				t.Errorf("parseKeyValue(%q) succeeded, want an error", tt.s)
+			}
+		})
+	}
+}
+
+func TestIsKeyValue(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{s: "host=localhost", want: true},
+		{s: "  dbname = orders", want: true},
+		{s: "postgresql://localhost/orders", want: false},
+		{s: "root@tcp(localhost)/shop", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isKeyValue(tt.s); got != tt.want {
+			t.Errorf("isKeyValue(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestRenderKeyValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "plain values", s: "host=localhost port=5432 dbname=orders user=app password=secret"},
+		{name: "quoted values", s: `host=localhost port=5432 dbname='my db' user=app password='it\'s \\ here'`},
+		{name: "empty database", s: "host=localhost port=5432 dbname=''"},
+		{name: "failover hosts", s: "host=primary,standby port=5432,5433 dbname=orders"},
+		{name: "unix socket", s: "host=/var/run/postgresql port=5432 dbname=orders"},
+		{name: "sorted arguments", s: "host=localhost port=5432 dbname=orders application_name='my app' connect_timeout=10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseKeyValue("id", tt.s)
+			if err != nil {
+				t.Fatalf("parseKeyValue(%q) error: %v", tt.s, err)
+			}
+			if got := renderKeyValue(c, true); got != tt.s {
+				t.Errorf("renderKeyValue() = %q, want %q", got, tt.s)
+			}
+		})
+	}
+}
+
+func TestQuoteKeyValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "orders", want: "orders"},
+		{value: "", want: "''"},
+		{value: "my db", want: "'my db'"},
+		{value: "it's", want: `'it\'s'`},
+		{value: `C:\data`, want: `'C:\\data'`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteKeyValue(tt.value); got != tt.want {
+			t.Errorf("quoteKeyValue(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
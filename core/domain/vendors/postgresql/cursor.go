@@ -0,0 +1,168 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"seagle/core/domain"
+)
+
+// session runs the statements of a script on a session of its own. A query
+// made of a single SELECT is read through a server-side cursor, page by page
+// with FETCH, so its rows wait on the server instead of in a result read
+// halfway.
+type session struct {
+	domain.QuerySession
+	conn         *sql.Conn
+	cancelServer func() error
+	// cursors counts the cursors declared, which are named after it
+	cursors int
+}
+
+func (s *session) Query(ctx context.Context, statement string, args ...interface{}) (domain.QueryCursor, error) {
+	query, ok := domain.PageableSelect(statement, Vendor.Dialect)
+	if !ok {
+		return s.QuerySession.Query(ctx, statement, args...)
+	}
+
+	unregister := domain.OnCancel(ctx, s.cancelServer)
+	cursor, err := s.declare(ctx, query, args)
+	if err != nil {
+		unregister()
+		return nil, err
+	}
+	cursor.unregister = unregister
+	return cursor, nil
+}
+
+// declare opens a cursor over the rows of the query. A cursor lives in a
+// transaction, one is begun for it when the session has none open and is
+// committed when the cursor is closed.
+func (s *session) declare(ctx context.Context, query string, args []interface{}) (*serverCursor, error) {
+	// Outside a transaction block every statement is a transaction of its
+	// own, begun when the statement is
+	var inBlock bool
+	if err := s.conn.QueryRowContext(ctx, "SELECT transaction_timestamp() <> statement_timestamp()").Scan(&inBlock); err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	s.cursors++
+	c := &serverCursor{
+		ctx:   ctx,
+		conn:  s.conn,
+		name:  fmt.Sprintf("seagle_cursor_%d", s.cursors),
+		owned: !inBlock,
+	}
+	if c.owned {
+		if _, err := s.conn.ExecContext(ctx, "BEGIN"); err != nil {
+			return nil, fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+
+	if _, err := s.conn.ExecContext(ctx, "DECLARE "+c.name+" NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to execute query: %w", err), c.end("ROLLBACK"))
+	}
+
+	// No rows are read, the columns are all that is needed
+	rows, err := s.conn.QueryContext(ctx, "FETCH FORWARD 0 FROM "+c.name)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to execute query: %w", err), c.end("ROLLBACK"))
+	}
+	cursor, err := domain.NewRowsCursor(rows, func() {})
+	if err != nil {
+		return nil, errors.Join(err, c.end("ROLLBACK"))
+	}
+	c.columns = cursor.Columns()
+	cursor.Close()
+
+	return c, nil
+}
+
+// serverCursor reads the rows of a cursor declared on the session, one row
+// ahead so it can tell whether more follow
+type serverCursor struct {
+	ctx  context.Context
+	conn *sql.Conn
+	name string
+	// owned is set when the transaction of the cursor was begun for it
+	owned      bool
+	columns    []domain.ColumnType
+	unregister func()
+	// next is the row read ahead, if any
+	next   []interface{}
+	done   bool
+	closed bool
+}
+
+func (c *serverCursor) Columns() []domain.ColumnType {
+	return c.columns
+}
+
+func (c *serverCursor) RowsAffected() int64 {
+	return 0
+}
+
+func (c *serverCursor) Fetch(n int) ([][]interface{}, bool, error) {
+	var page [][]interface{}
+	if c.next != nil {
+		page = append(page, c.next)
+		c.next = nil
+	}
+
+	if !c.done && len(page) <= n {
+		want := n + 1 - len(page)
+		rows, err := c.conn.QueryContext(c.ctx, fmt.Sprintf("FETCH FORWARD %d FROM %s", want, c.name))
+		if err != nil {
+			c.Close()
+			return nil, false, fmt.Errorf("failed to fetch rows: %w", err)
+		}
+		cursor, err := domain.NewRowsCursor(rows, func() {})
+		if err != nil {
+			c.Close()
+			return nil, false, err
+		}
+		fetched, _, err := cursor.Fetch(math.MaxInt32)
+		cursor.Close()
+		if err != nil {
+			c.Close()
+			return nil, false, err
+		}
+		c.done = len(fetched) < want
+		page = append(page, fetched...)
+	}
+
+	if len(page) > n {
+		c.next = page[n]
+		return page[:n], true, nil
+	}
+	return page, false, nil
+}
+
+// Close closes the cursor on the server and ends the transaction begun for
+// it. A failed cursor leaves the transaction aborted, which is rolled back.
+func (c *serverCursor) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	defer c.unregister()
+
+	if _, err := c.conn.ExecContext(context.Background(), "CLOSE "+c.name); err != nil {
+		return errors.Join(err, c.end("ROLLBACK"))
+	}
+	return c.end("COMMIT")
+}
+
+// end commits or rolls back the transaction begun for the cursor, if any. It
+// does not take the context of the query, so the transaction ends even when
+// it was canceled.
+func (c *serverCursor) end(statement string) error {
+	if !c.owned {
+		return nil
+	}
+	_, err := c.conn.ExecContext(context.Background(), statement)
+	return err
+}
@@ -0,0 +1,50 @@
+package postgresql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"seagle/core/domain"
+)
+
+// explainPlan is a node of the plan printed by EXPLAIN (FORMAT JSON)
+type explainPlan struct {
+	NodeType string        `json:"Node Type"`
+	PlanRows float64       `json:"Plan Rows"`
+	Plans    []explainPlan `json:"Plans"`
+}
+
+// EstimateRows asks the planner for the rows the statement touches. The
+// statement is planned, not run. The modifying node of an UPDATE or DELETE
+// reports no rows, so the largest estimate of the plan is taken.
+func (s *PostgreSQLService) EstimateRows(c *domain.Connection, statement string) (int64, error) {
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return 0, fmt.Errorf("no active connection found")
+	}
+
+	var raw []byte
+	if err := dbConn.QueryRow("EXPLAIN (FORMAT JSON) " + statement).Scan(&raw); err != nil {
+		return 0, fmt.Errorf("failed to explain statement: %w", err)
+	}
+
+	var plans []struct {
+		Plan explainPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return 0, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if len(plans) == 0 {
+		return 0, fmt.Errorf("empty plan")
+	}
+
+	return int64(maxPlanRows(plans[0].Plan)), nil
+}
+
+func maxPlanRows(plan explainPlan) float64 {
+	rows := plan.PlanRows
+	for _, child := range plan.Plans {
+		rows = max(rows, maxPlanRows(child))
+	}
+	return rows
+}
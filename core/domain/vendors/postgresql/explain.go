@@ -0,0 +1,164 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// planNode is a node of the plan printed by EXPLAIN (FORMAT JSON), with the
+// fields ANALYZE and BUFFERS add
+type planNode struct {
+	NodeType            string     `json:"Node Type"`
+	JoinType            string     `json:"Join Type"`
+	RelationName        string     `json:"Relation Name"`
+	CTEName             string     `json:"CTE Name"`
+	FunctionName        string     `json:"Function Name"`
+	IndexName           string     `json:"Index Name"`
+	StartupCost         *float64   `json:"Startup Cost"`
+	TotalCost           *float64   `json:"Total Cost"`
+	PlanRows            *float64   `json:"Plan Rows"`
+	ActualTotalTime     *float64   `json:"Actual Total Time"`
+	ActualRows          *float64   `json:"Actual Rows"`
+	ActualLoops         *float64   `json:"Actual Loops"`
+	RowsRemovedByFilter *float64   `json:"Rows Removed by Filter"`
+	IndexCond           string     `json:"Index Cond"`
+	HashCond            string     `json:"Hash Cond"`
+	MergeCond           string     `json:"Merge Cond"`
+	JoinFilter          string     `json:"Join Filter"`
+	Filter              string     `json:"Filter"`
+	RecheckCond         string     `json:"Recheck Cond"`
+	SortKey             []string   `json:"Sort Key"`
+	GroupKey            []string   `json:"Group Key"`
+	SharedHitBlocks     *int64     `json:"Shared Hit Blocks"`
+	SharedReadBlocks    int64      `json:"Shared Read Blocks"`
+	SharedDirtiedBlocks int64      `json:"Shared Dirtied Blocks"`
+	SharedWrittenBlocks int64      `json:"Shared Written Blocks"`
+	TempReadBlocks      int64      `json:"Temp Read Blocks"`
+	TempWrittenBlocks   int64      `json:"Temp Written Blocks"`
+	Plans               []planNode `json:"Plans"`
+}
+
+// ExplainQuery explains the statement with EXPLAIN (FORMAT JSON), adding
+// ANALYZE and BUFFERS when asked
+func (s *PostgreSQLService) ExplainQuery(ctx context.Context, session domain.QuerySession, statement string, args []interface{}, options domain.ExplainOptions) (*domain.QueryPlan, error) {
+	explain := "FORMAT JSON"
+	if options.Analyze {
+		explain += ", ANALYZE"
+	}
+	if options.Buffers {
+		explain += ", BUFFERS"
+	}
+
+	raw, err := domain.ReadPlanText(ctx, session, "EXPLAIN ("+explain+") "+statement, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []struct {
+		Plan          planNode `json:"Plan"`
+		PlanningTime  *float64 `json:"Planning Time"`
+		ExecutionTime *float64 `json:"Execution Time"`
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+
+	return domain.FinishPlan(&domain.QueryPlan{
+		Root:          plans[0].Plan.node(),
+		Analyzed:      options.Analyze,
+		PlanningTime:  plans[0].PlanningTime,
+		ExecutionTime: plans[0].ExecutionTime,
+		Raw:           raw,
+	}), nil
+}
+
+// node converts the node and its children to the plan tree of the domain
+func (p planNode) node() *domain.PlanNode {
+	node := &domain.PlanNode{
+		NodeType:      p.nodeType(),
+		Relation:      p.RelationName,
+		Index:         p.IndexName,
+		Detail:        p.detail(),
+		FullScan:      p.NodeType == "Seq Scan" || p.NodeType == "Parallel Seq Scan",
+		EstimatedRows: p.PlanRows,
+		ActualRows:    p.ActualRows,
+		Loops:         p.ActualLoops,
+		StartupCost:   p.StartupCost,
+		TotalCost:     p.TotalCost,
+	}
+	if node.Relation == "" {
+		node.Relation = p.CTEName
+	}
+	if node.Relation == "" {
+		node.Relation = p.FunctionName
+	}
+
+	// Actual times and rows are averages per loop
+	if p.ActualTotalTime != nil && p.ActualLoops != nil {
+		total := *p.ActualTotalTime * *p.ActualLoops
+		node.ActualTime = &total
+	}
+	if p.ActualRows != nil && p.ActualLoops != nil && node.FullScan {
+		scanned := *p.ActualRows * *p.ActualLoops
+		if p.RowsRemovedByFilter != nil {
+			scanned += *p.RowsRemovedByFilter * *p.ActualLoops
+		}
+		node.RowsScanned = &scanned
+	}
+
+	if p.SharedHitBlocks != nil {
+		node.Buffers = &domain.PlanBuffers{
+			SharedHit:     *p.SharedHitBlocks,
+			SharedRead:    p.SharedReadBlocks,
+			SharedDirtied: p.SharedDirtiedBlocks,
+			SharedWritten: p.SharedWrittenBlocks,
+			TempRead:      p.TempReadBlocks,
+			TempWritten:   p.TempWrittenBlocks,
+		}
+	}
+
+	for _, child := range p.Plans {
+		node.Children = append(node.Children, child.node())
+	}
+	return node
+}
+
+// nodeType names joins the way the text format does, e.g. Hash Left Join
+func (p planNode) nodeType() string {
+	switch p.NodeType {
+	case "Nested Loop", "Hash Join", "Merge Join":
+	default:
+		return p.NodeType
+	}
+	if p.JoinType == "" || p.JoinType == "Inner" {
+		return p.NodeType
+	}
+	return strings.TrimSuffix(p.NodeType, " Join") + " " + p.JoinType + " Join"
+}
+
+// detail joins the conditions and keys of the node
+func (p planNode) detail() string {
+	var parts []string
+	for _, c := range []struct{ name, value string }{
+		{"Index Cond", p.IndexCond},
+		{"Hash Cond", p.HashCond},
+		{"Merge Cond", p.MergeCond},
+		{"Join Filter", p.JoinFilter},
+		{"Filter", p.Filter},
+		{"Recheck Cond", p.RecheckCond},
+		{"Sort Key", strings.Join(p.SortKey, ", ")},
+		{"Group Key", strings.Join(p.GroupKey, ", ")},
+	} {
+		if c.value != "" {
+			parts = append(parts, c.name+": "+c.value)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
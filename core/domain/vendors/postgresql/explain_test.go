@@ -0,0 +1,443 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+// planSession answers every statement with a plan, the way the driver reads
+// the json column EXPLAIN (FORMAT JSON) returns
+type planSession struct {
+	plan       string
+	statements []string
+}
+
+func (s *planSession) Query(ctx context.Context, statement string, args ...interface{}) (domain.QueryCursor, error) {
+	s.statements = append(s.statements, statement)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(s.plan), &value); err != nil {
+		return nil, err
+	}
+	row := []interface{}{domain.TaggedValue{Type: "json", Value: value}}
+	return domain.NewStaticCursor([]domain.ColumnType{{Name: "QUERY PLAN", DatabaseType: "JSON"}}, [][]interface{}{row}, 0), nil
+}
+
+func (s *planSession) Close() error {
+	return nil
+}
+
+// node is what a test expects of a plan node
+type node struct {
+	nodeType      string
+	relation      string
+	index         string
+	detail        string
+	fullScan      bool
+	estimatedRows *float64
+	totalCost     *float64
+	actualRows    *float64
+	actualTime    *float64
+	rowsScanned   *float64
+	children      []node
+}
+
+func nodeOf(n *domain.PlanNode) node {
+	got := node{
+		nodeType:      n.NodeType,
+		relation:      n.Relation,
+		index:         n.Index,
+		detail:        n.Detail,
+		fullScan:      n.FullScan,
+		estimatedRows: n.EstimatedRows,
+		totalCost:     n.TotalCost,
+		actualRows:    n.ActualRows,
+		actualTime:    n.ActualTime,
+		rowsScanned:   n.RowsScanned,
+	}
+	for _, child := range n.Children {
+		got.children = append(got.children, nodeOf(child))
+	}
+	return got
+}
+
+func float(f float64) *float64 {
+	return &f
+}
+
+// Plans printed by PostgreSQL 16 for a shop schema of orders and customers
+const (
+	nestedLoopPlan = `[
+  {
+    "Plan": {
+      "Node Type": "Nested Loop",
+      "Parallel Aware": false,
+      "Async Capable": false,
+      "Join Type": "Left",
+      "Startup Cost": 0.29,
+      "Total Cost": 2958.50,
+      "Plan Rows": 2500,
+      "Plan Width": 45,
+      "Inner Unique": true,
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Parent Relationship": "Outer",
+          "Parallel Aware": false,
+          "Async Capable": false,
+          "Relation Name": "orders",
+          "Alias": "o",
+          "Startup Cost": 0.00,
+          "Total Cost": 2041.00,
+          "Plan Rows": 2500,
+          "Plan Width": 17,
+          "Filter": "(status = 'paid'::text)"
+        },
+        {
+          "Node Type": "Index Scan",
+          "Parent Relationship": "Inner",
+          "Parallel Aware": false,
+          "Async Capable": false,
+          "Scan Direction": "Forward",
+          "Index Name": "customers_pkey",
+          "Relation Name": "customers",
+          "Alias": "c",
+          "Startup Cost": 0.29,
+          "Total Cost": 0.37,
+          "Plan Rows": 1,
+          "Plan Width": 36,
+          "Index Cond": "(id = o.customer_id)"
+        }
+      ]
+    }
+  }
+]`
+
+	analyzedPlan = `[
+  {
+    "Plan": {
+      "Node Type": "Hash Join",
+      "Parallel Aware": false,
+      "Async Capable": false,
+      "Join Type": "Inner",
+      "Startup Cost": 30.50,
+      "Total Cost": 2289.51,
+      "Plan Rows": 500,
+      "Plan Width": 40,
+      "Actual Startup Time": 0.412,
+      "Actual Total Time": 24.318,
+      "Actual Rows": 48210,
+      "Actual Loops": 1,
+      "Inner Unique": true,
+      "Hash Cond": "(o.customer_id = c.id)",
+      "Shared Hit Blocks": 845,
+      "Shared Read Blocks": 12,
+      "Shared Dirtied Blocks": 0,
+      "Shared Written Blocks": 0,
+      "Local Hit Blocks": 0,
+      "Local Read Blocks": 0,
+      "Local Dirtied Blocks": 0,
+      "Local Written Blocks": 0,
+      "Temp Read Blocks": 0,
+      "Temp Written Blocks": 0,
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Parent Relationship": "Outer",
+          "Parallel Aware": false,
+          "Async Capable": false,
+          "Relation Name": "orders",
+          "Alias": "o",
+          "Startup Cost": 0.00,
+          "Total Cost": 2041.00,
+          "Plan Rows": 500,
+          "Plan Width": 16,
+          "Actual Startup Time": 0.010,
+          "Actual Total Time": 15.902,
+          "Actual Rows": 48210,
+          "Actual Loops": 1,
+          "Filter": "(total > '100'::numeric)",
+          "Rows Removed by Filter": 51790,
+          "Shared Hit Blocks": 833,
+          "Shared Read Blocks": 8,
+          "Shared Dirtied Blocks": 0,
+          "Shared Written Blocks": 0,
+          "Local Hit Blocks": 0,
+          "Local Read Blocks": 0,
+          "Local Dirtied Blocks": 0,
+          "Local Written Blocks": 0,
+          "Temp Read Blocks": 0,
+          "Temp Written Blocks": 0
+        },
+        {
+          "Node Type": "Hash",
+          "Parent Relationship": "Inner",
+          "Parallel Aware": false,
+          "Async Capable": false,
+          "Startup Cost": 18.00,
+          "Total Cost": 18.00,
+          "Plan Rows": 1000,
+          "Plan Width": 36,
+          "Actual Startup Time": 0.380,
+          "Actual Total Time": 0.380,
+          "Actual Rows": 1000,
+          "Actual Loops": 1,
+          "Hash Buckets": 1024,
+          "Original Hash Buckets": 1024,
+          "Hash Batches": 1,
+          "Original Hash Batches": 1,
+          "Peak Memory Usage": 73,
+          "Shared Hit Blocks": 12,
+          "Shared Read Blocks": 4,
+          "Shared Dirtied Blocks": 0,
+          "Shared Written Blocks": 0,
+          "Local Hit Blocks": 0,
+          "Local Read Blocks": 0,
+          "Local Dirtied Blocks": 0,
+          "Local Written Blocks": 0,
+          "Temp Read Blocks": 0,
+          "Temp Written Blocks": 0,
+          "Plans": [
+            {
+              "Node Type": "Seq Scan",
+              "Parent Relationship": "Outer",
+              "Parallel Aware": false,
+              "Async Capable": false,
+              "Relation Name": "customers",
+              "Alias": "c",
+              "Startup Cost": 0.00,
+              "Total Cost": 18.00,
+              "Plan Rows": 1000,
+              "Plan Width": 36,
+              "Actual Startup Time": 0.006,
+              "Actual Total Time": 0.180,
+              "Actual Rows": 1000,
+              "Actual Loops": 1,
+              "Shared Hit Blocks": 12,
+              "Shared Read Blocks": 4,
+              "Shared Dirtied Blocks": 0,
+              "Shared Written Blocks": 0,
+              "Local Hit Blocks": 0,
+              "Local Read Blocks": 0,
+              "Local Dirtied Blocks": 0,
+              "Local Written Blocks": 0,
+              "Temp Read Blocks": 0,
+              "Temp Written Blocks": 0
+            }
+          ]
+        }
+      ]
+    },
+    "Planning": {
+      "Shared Hit Blocks": 8,
+      "Shared Read Blocks": 0,
+      "Shared Dirtied Blocks": 0,
+      "Shared Written Blocks": 0,
+      "Local Hit Blocks": 0,
+      "Local Read Blocks": 0,
+      "Local Dirtied Blocks": 0,
+      "Local Written Blocks": 0,
+      "Temp Read Blocks": 0,
+      "Temp Written Blocks": 0
+    },
+    "Planning Time": 0.215,
+    "Triggers": [
+    ],
+    "Execution Time": 26.101
+  }
+]`
+
+	// Printed with COSTS OFF, so costs and estimates are missing, for a
+	// correlated subquery run once per customer
+	subPlan = `[
+  {
+    "Plan": {
+      "Node Type": "Seq Scan",
+      "Parallel Aware": false,
+      "Async Capable": false,
+      "Relation Name": "customers",
+      "Alias": "c",
+      "Actual Startup Time": 0.031,
+      "Actual Total Time": 5.250,
+      "Actual Rows": 1000,
+      "Actual Loops": 1,
+      "Plans": [
+        {
+          "Node Type": "Aggregate",
+          "Strategy": "Plain",
+          "Partial Mode": "Simple",
+          "Parent Relationship": "SubPlan",
+          "Subplan Name": "SubPlan 1",
+          "Parallel Aware": false,
+          "Async Capable": false,
+          "Actual Startup Time": 0.005,
+          "Actual Total Time": 0.005,
+          "Actual Rows": 1,
+          "Actual Loops": 1000,
+          "Plans": [
+            {
+              "Node Type": "Index Only Scan",
+              "Parent Relationship": "Outer",
+              "Parallel Aware": false,
+              "Async Capable": false,
+              "Scan Direction": "Forward",
+              "Index Name": "orders_customer_id_idx",
+              "Relation Name": "orders",
+              "Alias": "o",
+              "Actual Startup Time": 0.002,
+              "Actual Total Time": 0.004,
+              "Actual Rows": 48,
+              "Actual Loops": 1000,
+              "Index Cond": "(customer_id = c.id)",
+              "Rows Removed by Index Recheck": 0,
+              "Heap Fetches": 0
+            }
+          ]
+        }
+      ]
+    },
+    "Planning Time": 0.098,
+    "Triggers": [
+    ],
+    "Execution Time": 5.371
+  }
+]`
+)
+
+func TestExplainQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    string
+		options domain.ExplainOptions
+		query   string
+		// statement is the EXPLAIN run for the query
+		statement string
+		root      node
+		buffers   *domain.PlanBuffers
+		execution *float64
+		hotspots  []string
+	}{
+		{
+			name:      "nested loop",
+			plan:      nestedLoopPlan,
+			query:     "SELECT * FROM orders o LEFT JOIN customers c ON c.id = o.customer_id WHERE status = 'paid'",
+			statement: "EXPLAIN (FORMAT JSON) SELECT * FROM orders o LEFT JOIN customers c ON c.id = o.customer_id WHERE status = 'paid'",
+			root: node{
+				nodeType:      "Nested Loop Left Join",
+				estimatedRows: float(2500),
+				totalCost:     float(2958.50),
+				children: []node{
+					{nodeType: "Seq Scan", relation: "orders", detail: "Filter: (status = 'paid'::text)", fullScan: true, estimatedRows: float(2500), totalCost: float(2041)},
+					{nodeType: "Index Scan", relation: "customers", index: "customers_pkey", detail: "Index Cond: (id = o.customer_id)", estimatedRows: float(1), totalCost: float(0.37)},
+				},
+			},
+		},
+		{
+			name:      "analyzed hash join with buffers",
+			plan:      analyzedPlan,
+			options:   domain.ExplainOptions{Analyze: true, Buffers: true},
+			query:     "SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE total > 100",
+			statement: "EXPLAIN (FORMAT JSON, ANALYZE, BUFFERS) SELECT * FROM orders o JOIN customers c ON c.id = o.customer_id WHERE total > 100",
+			root: node{
+				nodeType:      "Hash Join",
+				detail:        "Hash Cond: (o.customer_id = c.id)",
+				estimatedRows: float(500),
+				totalCost:     float(2289.51),
+				actualRows:    float(48210),
+				actualTime:    float(24.318),
+				children: []node{
+					{
+						nodeType: "Seq Scan", relation: "orders", detail: "Filter: (total > '100'::numeric)", fullScan: true,
+						estimatedRows: float(500), totalCost: float(2041), actualRows: float(48210), actualTime: float(15.902), rowsScanned: float(100000),
+					},
+					{
+						nodeType: "Hash", estimatedRows: float(1000), totalCost: float(18), actualRows: float(1000), actualTime: float(0.380),
+						children: []node{
+							{nodeType: "Seq Scan", relation: "customers", fullScan: true, estimatedRows: float(1000), totalCost: float(18), actualRows: float(1000), actualTime: float(0.180), rowsScanned: float(1000)},
+						},
+					},
+				},
+			},
+			buffers:   &domain.PlanBuffers{SharedHit: 845, SharedRead: 12},
+			execution: float(26.101),
+			hotspots: []string{
+				"Seq Scan on orders takes 15.902 ms, 61% of the execution time",
+				"Hash Join takes 8.036 ms, 31% of the execution time",
+				"Hash Join returned 48210 rows where 500 were estimated, 96x more",
+				"Seq Scan on orders returned 48210 rows where 500 were estimated, 96x more",
+				"Seq Scan on orders reads 100000 rows, an index on the filtered columns may avoid it",
+			},
+		},
+		{
+			name:      "subplan run once per row without costs",
+			plan:      subPlan,
+			options:   domain.ExplainOptions{Analyze: true},
+			query:     "SELECT c.*, (SELECT count(*) FROM orders o WHERE o.customer_id = c.id) FROM customers c",
+			statement: "EXPLAIN (FORMAT JSON, ANALYZE) SELECT c.*, (SELECT count(*) FROM orders o WHERE o.customer_id = c.id) FROM customers c",
+			root: node{
+				nodeType: "Seq Scan", relation: "customers", fullScan: true, actualRows: float(1000), actualTime: float(5.250), rowsScanned: float(1000),
+				children: []node{
+					{
+						nodeType: "Aggregate", actualRows: float(1), actualTime: float(0.005 * 1000),
+						children: []node{
+							{nodeType: "Index Only Scan", relation: "orders", index: "orders_customer_id_idx", detail: "Index Cond: (customer_id = c.id)", actualRows: float(48), actualTime: float(0.004 * 1000)},
+						},
+					},
+				},
+			},
+			execution: float(5.371),
+			// Without costs there are no estimates to be off
+			hotspots: []string{
+				"Index Only Scan on orders takes 4.000 ms, 74% of the execution time",
+				"Aggregate takes 1.000 ms, 19% of the execution time",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &planSession{plan: tt.plan}
+			plan, err := (&PostgreSQLService{}).ExplainQuery(context.Background(), session, tt.query, nil, tt.options)
+			if err != nil {
+				t.Fatalf("ExplainQuery() error = %v", err)
+			}
+
+			if want := []string{tt.statement}; !reflect.DeepEqual(session.statements, want) {
+				t.Errorf("statements = %q, want %q", session.statements, want)
+			}
+			if plan.Analyzed != tt.options.Analyze {
+				t.Errorf("Analyzed = %v, want %v", plan.Analyzed, tt.options.Analyze)
+			}
+			if got := nodeOf(plan.Root); !reflect.DeepEqual(got, tt.root) {
+				t.Errorf("root = %+v\nwant %+v", got, tt.root)
+			}
+			if !reflect.DeepEqual(plan.Root.Buffers, tt.buffers) {
+				t.Errorf("buffers = %+v, want %+v", plan.Root.Buffers, tt.buffers)
+			}
+			if !reflect.DeepEqual(plan.ExecutionTime, tt.execution) {
+				t.Errorf("execution time = %v, want %v", plan.ExecutionTime, tt.execution)
+			}
+
+			var hotspots []string
+			for _, h := range plan.Hotspots {
+				hotspots = append(hotspots, h.Message)
+			}
+			if !reflect.DeepEqual(hotspots, tt.hotspots) {
+				t.Errorf("hotspots = %q\nwant %q", hotspots, tt.hotspots)
+			}
+		})
+	}
+}
+
+func TestExplainQueryRejectsBadPlans(t *testing.T) {
+	for _, plan := range []string{`[]`, `{"Plan": {}}`} {
+		session := &planSession{plan: plan}
+		if _, err := (&PostgreSQLService{}).ExplainQuery(context.Background(), session, "SELECT 1", nil, domain.ExplainOptions{}); err == nil {
+			t.Errorf("ExplainQuery() of %s returned no error", plan)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"seagle/core/domain"
+
+	"github.com/lib/pq"
+)
+
+const (
+	vendorName  = "postgresql"
+	defaultPort = 5432
+)
+
+// Vendor describes PostgreSQL to the vendor registry
+var Vendor = &domain.Vendor{
+	Name:        vendorName,
+	DisplayName: "PostgreSQL",
+	Schemes:     []string{"postgresql", "postgres"},
+	DefaultPort: defaultPort,
+	DriverName:  "postgres",
+	Fields: []domain.FormField{
+		{Name: "host", Label: "Host", Type: "text", Required: true, Placeholder: "localhost"},
+		{Name: "port", Label: "Port", Type: "number", Required: true, Placeholder: "5432"},
+		{Name: "database", Label: "Database", Type: "text", Required: true, Placeholder: "postgres"},
+		{Name: "username", Label: "Username", Type: "text", Required: true},
+		{Name: "password", Label: "Password", Type: "password"},
+	},
+	SSLModes: domain.TLSModes,
+	Arguments: []domain.ArgumentSpec{
+		{Name: "connect_timeout", Label: "Connect timeout", Description: "Seconds to wait while connecting, 0 waits forever", Type: "int", Default: "0"},
+		{Name: "application_name", Label: "Application name", Description: "Name reported in pg_stat_activity", Type: "string", Default: "seagle"},
+		{Name: "search_path", Label: "Search path", Description: "Schemas searched for unqualified names", Type: "string"},
+	},
+	SupportsSSH:   true,
+	BuildDSN:      buildConnectionString,
+	OpenConnector: openConnector,
+	TLSArguments:  tlsArguments,
+	NewService: func(sessions *domain.SessionManager) domain.DatabaseService {
+		return NewPostgreSQLService(sessions)
+	},
+	TablesQuery: func(c *domain.Connection) string {
+		return `
+			SELECT table_name, table_schema
+			FROM information_schema.tables 
+			WHERE table_schema = 'public' 
+			AND table_type = 'BASE TABLE'
+			ORDER BY table_name
+		`
+	},
+	QuoteIdentifier: quoteIdentifier,
+	Formats: []domain.ConnectionStringFormat{
+		{
+			Name:  domain.URLFormat,
+			Label: "URL",
+			Parse: domain.ParseNetworkURL,
+			Render: func(c *domain.Connection, includePassword bool) string {
+				return domain.RenderNetworkURL(c, "postgresql", connectionArguments(c), includePassword)
+			},
+		},
+		{
+			Name:   "keyvalue",
+			Label:  "libpq key/value",
+			Match:  isKeyValue,
+			Parse:  parseKeyValue,
+			Render: renderKeyValue,
+		},
+	},
+	PromptHints: []string{
+		"Consider PostgreSQL-specific features like SERIAL, LIMIT, and case-sensitive identifiers",
+		"Use double quotes for identifiers if needed",
+	},
+	Dialect: domain.SQLDialect{DollarQuoting: true, EscapeStrings: true, NestedComments: true, BlockBodies: true, NumberedPlaceholders: true, Returning: true, ModifyingCTEs: true, ReadOnlyBegin: "BEGIN READ ONLY"},
+}
+
+func init() {
+	domain.RegisterVendor(Vendor)
+}
+
+// buildConnectionString renders a postgresql:// URL understood by lib/pq
+func buildConnectionString(c *domain.Connection) string {
+	return buildURL(c, c.Host())
+}
+
+// connectionArguments returns the driver arguments of the connection. The TLS
+// settings are mapped to the libpq sslmode, sslrootcert, sslcert and sslkey
+// options and take precedence over the same raw arguments.
+func connectionArguments(c *domain.Connection) url.Values {
+	arguments := url.Values{}
+	for k, v := range c.Arguments() {
+		arguments.Set(k, v)
+	}
+	if t := c.TLS(); t != nil {
+		arguments.Set("sslmode", t.Mode)
+		for option, file := range map[string]string{"sslrootcert": t.CAFile, "sslcert": t.CertFile, "sslkey": t.KeyFile} {
+			if file != "" {
+				arguments.Set(option, file)
+			} else {
+				arguments.Del(option)
+			}
+		}
+	}
+	return arguments
+}
+
+// buildURL renders the connection URL for the given host. Unix socket
+// directories are passed as the host argument, as libpq expects. Read-only
+// connections start their sessions with default_transaction_read_only, which
+// lib/pq sends as a run-time parameter.
+func buildURL(c *domain.Connection, host string) string {
+	arguments := connectionArguments(c)
+	if c.ReadOnly() {
+		arguments.Set("default_transaction_read_only", "on")
+	}
+	if timeout := c.StatementTimeout(); timeout > 0 {
+		arguments.Set("statement_timeout", strconv.FormatInt(timeout.Milliseconds(), 10))
+	}
+
+	address := net.JoinHostPort(host, strconv.Itoa(c.Port()))
+	if c.UnixSocket() {
+		address = ""
+		arguments.Set("host", c.Host())
+		arguments.Set("port", strconv.Itoa(c.Port()))
+	}
+
+	dbURL := fmt.Sprintf("postgresql://%s:%s@%s/%s", url.QueryEscape(c.Username()), url.QueryEscape(c.Password()), address, c.Database())
+	if len(arguments) > 0 {
+		// Encode sorts the keys, so the same connection always renders the same URL
+		dbURL += "?" + arguments.Encode()
+	}
+	return dbURL
+}
+
+// openConnector creates a lib/pq connector. lib/pq verifies the server
+// certificate against the host it dials, so when a TLS server name is set
+// the URL carries that name and the dialer is pinned to the real address.
+func openConnector(c *domain.Connection) (driver.Connector, error) {
+	t := c.TLS()
+	if t == nil || t.ServerName == "" || t.ServerName == c.Host() || c.UnixSocket() {
+		return pq.NewConnector(buildConnectionString(c))
+	}
+
+	connector, err := pq.NewConnector(buildURL(c, t.ServerName))
+	if err != nil {
+		return nil, err
+	}
+	connector.Dialer(endpointDialer{address: net.JoinHostPort(c.Host(), strconv.Itoa(c.Port()))})
+
+	return connector, nil
+}
+
+// endpointDialer dials a fixed address whatever host lib/pq asks for
+type endpointDialer struct {
+	address string
+}
+
+func (d endpointDialer) Dial(network, _ string) (net.Conn, error) {
+	return net.Dial(network, d.address)
+}
+
+func (d endpointDialer) DialTimeout(network, _ string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout(network, d.address, timeout)
+}
+
+func (d endpointDialer) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, d.address)
+}
+
+// quoteIdentifier quotes a PostgreSQL identifier with double quotes
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -1,64 +1,39 @@
-package domain
+package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"time"
+
+	"seagle/core/domain"
 
 	_ "github.com/lib/pq"
 )
 
 type PostgreSQLService struct {
-	pool map[string]*sql.DB
+	sessions *domain.SessionManager
 }
 
-func NewPostgreSQLService() *PostgreSQLService {
+func NewPostgreSQLService(sessions *domain.SessionManager) *PostgreSQLService {
 	return &PostgreSQLService{
-		pool: make(map[string]*sql.DB),
+		sessions: sessions,
 	}
 }
 
-func (s *PostgreSQLService) pooledDBConn(c *Connection) *sql.DB {
-	if db, exists := s.pool[c.ID()]; exists {
-		return db
-	}
-	return nil
+func (s *PostgreSQLService) pooledDBConn(c *domain.Connection) *sql.DB {
+	return s.sessions.Get(c)
 }
 
-func (s *PostgreSQLService) Connect(c *Connection) error {
-	dbConn := s.pooledDBConn(c)
-	if dbConn == nil {
-		db, err := sql.Open("postgres", c.connectionString())
-		if err != nil {
-			return fmt.Errorf("failed to open database connection: %w", err)
-		}
-
-		dbConn = db
-	}
-
-	if err := dbConn.Ping(); err != nil {
-		dbConn.Close()
-		return fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	s.pool[c.ID()] = dbConn
-
-	return nil
+func (s *PostgreSQLService) Connect(c *domain.Connection) error {
+	_, err := s.sessions.Open(c, Vendor)
+	return err
 }
 
-func (s *PostgreSQLService) Disconnect(c *Connection) error {
-	dbConn := s.pooledDBConn(c)
-
-	if dbConn != nil {
-		if err := dbConn.Close(); err != nil {
-			return fmt.Errorf("failed to close database connection: %w", err)
-		}
-		delete(s.pool, c.ID())
-	}
-	return nil
+func (s *PostgreSQLService) Disconnect(c *domain.Connection) error {
+	return s.sessions.Close(c)
 }
 
-func (s *PostgreSQLService) GetDatabaseNames(c *Connection) ([]string, error) {
+func (s *PostgreSQLService) GetDatabaseNames(c *domain.Connection) ([]string, error) {
 	query := `
 		SELECT datname 
 		FROM pg_database 
@@ -93,7 +68,7 @@ func (s *PostgreSQLService) GetDatabaseNames(c *Connection) ([]string, error) {
 	return databases, nil
 }
 
-func (s *PostgreSQLService) GetTableNames(c *Connection, databaseName string) ([]string, error) {
+func (s *PostgreSQLService) GetTableNames(c *domain.Connection, databaseName string) ([]string, error) {
 	query := `
 		SELECT table_name 
 		FROM information_schema.tables 
@@ -129,7 +104,7 @@ func (s *PostgreSQLService) GetTableNames(c *Connection, databaseName string) ([
 	return tables, nil
 }
 
-func (s *PostgreSQLService) GetTableColumns(c *Connection, databaseName, tableName string) ([]ColumnMetadata, error) {
+func (s *PostgreSQLService) GetTableColumns(c *domain.Connection, databaseName, tableName string) ([]domain.ColumnMetadata, error) {
 	query := `
 		SELECT column_name, data_type, is_nullable, column_default, ordinal_position
 		FROM information_schema.columns
@@ -156,21 +131,15 @@ func (s *PostgreSQLService) GetTableColumns(c *Connection, databaseName, tableNa
 		Position     int
 	}
 
-	var columns []ColumnMetadata
+	var columns []domain.ColumnMetadata
 	for rows.Next() {
 		var col Col
 		if err := rows.Scan(&col.Name, &col.DataType, &col.IsNullable, &col.DefaultValue, &col.Position); err != nil {
 			return nil, fmt.Errorf("failed to scan column metadata: %w", err)
 		}
 
-		column := ColumnMetadata{
-			name:         col.Name,
-			dataType:     col.DataType,
-			isNullable:   col.IsNullable == "YES",
-			defaultValue: col.DefaultValue.String,
-			position:     col.Position,
-		}
-		columns = append(columns, column)
+		column := domain.NewColumnMetadata(col.Name, col.DataType, col.IsNullable == "YES", col.DefaultValue.String, col.Position)
+		columns = append(columns, *column)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -180,91 +149,68 @@ func (s *PostgreSQLService) GetTableColumns(c *Connection, databaseName, tableNa
 	return columns, nil
 }
 
-func (s *PostgreSQLService) ExecQuery(c *Connection, query string) (*QueryResult, error) {
-	dbConn := s.pooledDBConn(c)
+// OpenSession takes a session out of the pool, kept until it is closed. Its
+// statements are canceled on the server with pg_cancel_backend, run on a
+// connection of its own to the host of the session.
+func (s *PostgreSQLService) OpenSession(ctx context.Context, c *domain.Connection) (domain.QuerySession, error) {
+	dbConn, release := s.sessions.Acquire(c)
 	if dbConn == nil {
 		return nil, fmt.Errorf("no active connection found")
 	}
 
-	start := time.Now()
-
-	// Check if it's a SELECT query or DML/DDL
-	rows, err := dbConn.Query(query)
+	conn, err := dbConn.Conn(ctx)
 	if err != nil {
-		// If Query fails, try Exec for DML/DDL statements
-		result, execErr := dbConn.Exec(query)
-		if execErr != nil {
-			return nil, fmt.Errorf("failed to execute query: %w", execErr)
-		}
-
-		rowsAffected, _ := result.RowsAffected()
-		duration := time.Since(start).Milliseconds()
-
-		return &QueryResult{
-			Columns:      []string{},
-			Rows:         [][]interface{}{},
-			RowsAffected: rowsAffected,
-			Duration:     duration,
-		}, nil
+		release()
+		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
-	defer rows.Close()
 
-	// Get column information
-	columns, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %w", err)
+	// pg_cancel_backend needs the process serving the session
+	var pid int
+	if err := conn.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&pid); err != nil {
+		conn.Close()
+		release()
+		return nil, fmt.Errorf("failed to get backend pid: %w", err)
 	}
 
-	// Prepare result structure
-	var resultRows [][]interface{}
-
-	for rows.Next() {
-		// Create a slice of interface{} to hold the values
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-
-		for i := range values {
-			valuePtrs[i] = &values[i]
-		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-
-		// Convert []byte to string for display
-		row := make([]interface{}, len(values))
-		for i, val := range values {
-			if b, ok := val.([]byte); ok {
-				row[i] = string(b)
-			} else {
-				row[i] = val
-			}
-		}
-
-		resultRows = append(resultRows, row)
+	cancelServer := s.sessions.Canceler(c, Vendor)
+	if cancelServer == nil {
+		conn.Close()
+		release()
+		return nil, fmt.Errorf("no active connection found")
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+	cancel := func() error {
+		return cancelServer("SELECT pg_cancel_backend($1)", pid)
 	}
+	return &session{
+		QuerySession: domain.NewConnSession(conn, Vendor.Dialect, cancel, release),
+		conn:         conn,
+		cancelServer: cancel,
+	}, nil
+}
 
-	duration := time.Since(start).Milliseconds()
+// ExecQuery runs the query until it completes or ctx is done
+func (s *PostgreSQLService) ExecQuery(ctx context.Context, c *domain.Connection, query string) (*domain.QueryResult, error) {
+	session, err := s.OpenSession(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
 
-	return &QueryResult{
-		Columns:      columns,
-		Rows:         resultRows,
-		RowsAffected: int64(len(resultRows)),
-		Duration:     duration,
-	}, nil
+	result, err := domain.RunQuery(ctx, session, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	return result, nil
 }
 
-func (s *PostgreSQLService) GetTableMetadata(c *Connection, tableName, schemaName string) (*TableMetadata, error) {
+func (s *PostgreSQLService) GetTableMetadata(c *domain.Connection, tableName, schemaName string) (*domain.TableMetadata, error) {
 	db := s.pooledDBConn(c)
 	if db == nil {
 		return nil, fmt.Errorf("no active connection found")
 	}
 
-	metadata := NewTableMetadata(tableName, schemaName)
+	metadata := domain.NewTableMetadata(tableName, schemaName)
 
 	query := `
 		SELECT 
@@ -294,9 +240,9 @@ func (s *PostgreSQLService) GetTableMetadata(c *Connection, tableName, schemaNam
 			return nil, fmt.Errorf("failed to scan column metadata: %w", err)
 		}
 
-		column := NewColumnMetadata(name, dataType, isNullable, defaultValue, position)
+		column := domain.NewColumnMetadata(name, dataType, isNullable, defaultValue, position)
 		metadata.AddColumn(column)
 	}
 
 	return metadata, rows.Err()
-}
\ No newline at end of file
+}
@@ -0,0 +1,100 @@
+package postgresql
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"seagle/core/domain"
+)
+
+// sslRequestCode asks the server to switch the connection to TLS
+const sslRequestCode = 80877103
+
+const probeTimeout = 10 * time.Second
+
+// TLSState reports the TLS session of the open connection. The version and
+// cipher come from pg_stat_ssl. lib/pq does not expose the server
+// certificate, so it is read through a separate TLS handshake with the same
+// endpoint.
+func (s *PostgreSQLService) TLSState(c *domain.Connection) (*domain.TLSState, error) {
+	dbConn := s.pooledDBConn(c)
+	if dbConn == nil {
+		return nil, fmt.Errorf("no active connection found")
+	}
+
+	var enabled bool
+	var version, cipher string
+	err := dbConn.QueryRow(`
+		SELECT ssl, COALESCE(version, ''), COALESCE(cipher, '')
+		FROM pg_stat_ssl
+		WHERE pid = pg_backend_pid()
+	`).Scan(&enabled, &version, &cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TLS status: %w", err)
+	}
+	if !enabled {
+		return &domain.TLSState{}, nil
+	}
+
+	state := domain.NewReportedTLSState(version, cipher)
+
+	endpoint, ok := s.sessions.Endpoint(c)
+	if !ok {
+		return state, nil
+	}
+	if peer, err := probeCertificate(c, endpoint); err == nil {
+		state.Subject = peer.Subject
+		state.Issuer = peer.Issuer
+	}
+
+	return state, nil
+}
+
+// probeCertificate performs the PostgreSQL SSLRequest exchange followed by a
+// TLS handshake and returns what it learned about the server certificate.
+// The certificate is not verified here, lib/pq already did when connecting.
+func probeCertificate(c *domain.Connection, endpoint string) (*domain.TLSState, error) {
+	cfg := &tls.Config{ServerName: c.Host()}
+	if t := c.TLS(); t != nil {
+		clientConfig, err := t.ClientConfig(c.Host())
+		if err != nil {
+			return nil, err
+		}
+		cfg = clientConfig
+		cfg.VerifyConnection = nil
+	}
+	cfg.InsecureSkipVerify = true
+
+	conn, err := net.DialTimeout("tcp", endpoint, probeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(probeTimeout))
+
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], sslRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	answer := make([]byte, 1)
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return nil, err
+	}
+	if answer[0] != 'S' {
+		return nil, fmt.Errorf("server refused TLS")
+	}
+
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+
+	return domain.NewTLSState(tlsConn.ConnectionState()), nil
+}
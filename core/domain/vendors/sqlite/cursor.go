@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// session runs the statements of a script on a connection of its own, taken
+// out of the pool of the file, so its transaction and open results are not
+// shared with other sessions. Rows are read from the statement as pages are
+// fetched, the connection being held until the cursor is closed.
+type session struct {
+	domain.QuerySession
+	service *SQLiteService
+	db      *sql.DB
+	conn    *sql.Conn
+}
+
+// OpenSession takes a connection out of the pool of the file, kept until the
+// session is closed. The driver interrupts a statement once its context is
+// done, so there is nothing more to cancel.
+func (s *SQLiteService) OpenSession(ctx context.Context, c *domain.Connection) (domain.QuerySession, error) {
+	db, conn, release, err := s.takeConn(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	cancel := func() error { return nil }
+	return &session{
+		QuerySession: domain.NewConnSession(conn, Vendor.Dialect, cancel, release),
+		service:      s,
+		db:           db,
+		conn:         conn,
+	}, nil
+}
+
+// Query runs the statement, recording the databases it attached or detached
+// so the other connections of the pool see them too
+func (s *session) Query(ctx context.Context, statement string, args ...interface{}) (domain.QueryCursor, error) {
+	cursor, err := s.QuerySession.Query(ctx, statement, args...)
+	if err != nil || len(cursor.Columns()) > 0 || !changesAttachments(statement) {
+		return cursor, err
+	}
+
+	if err := s.service.recordAttached(ctx, s.db, s.conn); err != nil {
+		cursor.Close()
+		return nil, err
+	}
+	return cursor, nil
+}
+
+// changesAttachments reports whether the statement may attach or detach a
+// database. A false positive only costs reading the database list.
+func changesAttachments(statement string) bool {
+	statement = strings.ToUpper(statement)
+	return strings.Contains(statement, "ATTACH") || strings.Contains(statement, "DETACH")
+}
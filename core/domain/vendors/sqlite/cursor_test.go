@@ -0,0 +1,164 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+// plainSecrets resolves every value to itself
+type plainSecrets struct{}
+
+func (plainSecrets) Resolve(value string) (string, error) {
+	return value, nil
+}
+
+// openTestService connects to a new file holding a table t of five rows
+func openTestService(t *testing.T) (*SQLiteService, *domain.Connection) {
+	t.Helper()
+
+	sessions := domain.NewSessionManager(domain.SessionOptions{MaxOpenConns: 4, MaxIdleConns: 4}, plainSecrets{})
+	t.Cleanup(sessions.Shutdown)
+
+	c, err := domain.NewConnection("id", vendorName, filepath.Join(t.TempDir(), "app.db"), 0, mainDatabase, "", "", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSQLiteService(sessions)
+	if err := s.Connect(c); err != nil {
+		t.Fatal(err)
+	}
+
+	exec(t, s, c, "CREATE TABLE t (id INTEGER PRIMARY KEY)")
+	exec(t, s, c, "INSERT INTO t (id) VALUES (1), (2), (3), (4), (5)")
+	return s, c
+}
+
+func exec(t *testing.T, s *SQLiteService, c *domain.Connection, query string) *domain.QueryResult {
+	t.Helper()
+
+	result, err := s.ExecQuery(context.Background(), c, query)
+	if err != nil {
+		t.Fatalf("ExecQuery(%q) error = %v", query, err)
+	}
+	return result
+}
+
+func openSession(t *testing.T, s *SQLiteService, c *domain.Connection) domain.QuerySession {
+	t.Helper()
+
+	session, err := s.OpenSession(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestSessionReadsRowsPageByPage(t *testing.T) {
+	s, c := openTestService(t)
+	session := openSession(t, s, c)
+
+	for _, query := range []string{"SELECT id FROM t ORDER BY id", "DELETE FROM t RETURNING id"} {
+		cursor, err := session.Query(context.Background(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []interface{}
+		for more := true; more; {
+			var page [][]interface{}
+			if page, more, err = cursor.Fetch(2); err != nil {
+				t.Fatal(err)
+			}
+			if len(page) > 2 {
+				t.Fatalf("%q: Fetch(2) returned %d rows", query, len(page))
+			}
+			for _, row := range page {
+				ids = append(ids, row[0])
+			}
+		}
+		cursor.Close()
+
+		if want := []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5)}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("%q read %v, want %v", query, ids, want)
+		}
+	}
+}
+
+func TestSessionsHoldConnectionsOfTheirOwn(t *testing.T) {
+	s, c := openTestService(t)
+	tx := openSession(t, s, c)
+	other := openSession(t, s, c)
+
+	// A result left open on one session does not hold up the other
+	cursor, err := tx.Query(context.Background(), "SELECT id FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cursor.Fetch(1); err != nil {
+		t.Fatal(err)
+	}
+	cursor.Close()
+
+	for _, statement := range []string{"BEGIN", "DELETE FROM t"} {
+		cursor, err := tx.Query(context.Background(), statement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor.Close()
+	}
+
+	// The open transaction does not take in the statements of other sessions
+	result, err := domain.RunQuery(context.Background(), other, "SELECT COUNT(*) FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := result.Rows[0][0]; count != int64(5) {
+		t.Errorf("another session counted %v rows during the transaction, want 5", count)
+	}
+
+	if _, err := domain.RunQuery(context.Background(), tx, "ROLLBACK"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSessionsShareAttachedDatabases(t *testing.T) {
+	s, c := openTestService(t)
+	file := filepath.Join(t.TempDir(), "archive.db")
+
+	session := openSession(t, s, c)
+	if _, err := domain.RunQuery(context.Background(), session, "ATTACH DATABASE '"+file+"' AS archive"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := domain.RunQuery(context.Background(), session, "CREATE TABLE archive.old (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another connection of the pool attaches the database too
+	other := openSession(t, s, c)
+	if _, err := domain.RunQuery(context.Background(), other, "SELECT * FROM archive.old"); err != nil {
+		t.Errorf("another session cannot read the attached database: %v", err)
+	}
+
+	databases, err := s.GetDatabaseNames(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"main", "archive"}; !reflect.DeepEqual(databases, want) {
+		t.Errorf("GetDatabaseNames() = %v, want %v", databases, want)
+	}
+
+	if _, err := domain.RunQuery(context.Background(), session, "DETACH DATABASE archive"); err != nil {
+		t.Fatal(err)
+	}
+	if databases, err = s.GetDatabaseNames(c); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"main"}; !reflect.DeepEqual(databases, want) {
+		t.Errorf("GetDatabaseNames() after DETACH = %v, want %v", databases, want)
+	}
+}
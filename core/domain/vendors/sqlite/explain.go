@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// ExplainQuery explains the statement with EXPLAIN QUERY PLAN. SQLite tells
+// how it reads the tables but neither costs nor row estimates, and it cannot
+// measure a run.
+func (s *SQLiteService) ExplainQuery(ctx context.Context, session domain.QuerySession, statement string, args []interface{}, options domain.ExplainOptions) (*domain.QueryPlan, error) {
+	if options.Analyze {
+		return nil, fmt.Errorf("SQLite cannot analyze a statement, only explain its plan")
+	}
+
+	rows, err := domain.ReadPlanRows(ctx, session, "EXPLAIN QUERY PLAN "+statement, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are id, parent, notused and detail, parents before their children
+	root := &domain.PlanNode{NodeType: "Query plan"}
+	nodes := map[int64]*domain.PlanNode{0: root}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 4 {
+			return nil, fmt.Errorf("failed to parse plan: unexpected row %v", row)
+		}
+		id, _ := row[0].(int64)
+		parentID, _ := row[1].(int64)
+		detail := fmt.Sprint(row[3])
+		lines = append(lines, fmt.Sprintf("%d|%d|%s", id, parentID, detail))
+
+		node := planNode(detail)
+		parent, ok := nodes[parentID]
+		if !ok {
+			parent = root
+		}
+		parent.Children = append(parent.Children, node)
+		nodes[id] = node
+	}
+	if len(root.Children) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+
+	return domain.FinishPlan(&domain.QueryPlan{Root: root, Raw: strings.Join(lines, "\n")}), nil
+}
+
+// planNode parses a line of EXPLAIN QUERY PLAN, e.g.
+// SEARCH orders USING INDEX orders_customer (customer_id=?)
+func planNode(detail string) *domain.PlanNode {
+	node := &domain.PlanNode{NodeType: detail}
+
+	access, rest, _ := strings.Cut(detail, " ")
+	if (access != "SCAN" && access != "SEARCH") || rest == "CONSTANT ROW" {
+		return node
+	}
+	// Versions before 3.36 print SCAN TABLE t
+	rest = strings.TrimPrefix(rest, "TABLE ")
+	node.Relation, rest, _ = strings.Cut(rest, " ")
+	// Aliased tables are printed as t AS o
+	if after, ok := strings.CutPrefix(rest, "AS "); ok {
+		_, rest, _ = strings.Cut(after, " ")
+	}
+
+	covering := false
+	switch {
+	case strings.HasPrefix(rest, "USING COVERING INDEX "):
+		covering = true
+		node.Index, rest, _ = strings.Cut(strings.TrimPrefix(rest, "USING COVERING INDEX "), " ")
+	case strings.HasPrefix(rest, "USING INDEX "):
+		node.Index, rest, _ = strings.Cut(strings.TrimPrefix(rest, "USING INDEX "), " ")
+	case strings.HasPrefix(rest, "USING INTEGER PRIMARY KEY"), strings.HasPrefix(rest, "USING PRIMARY KEY"):
+		node.Index = "PRIMARY KEY"
+		_, rest, _ = strings.Cut(rest, "PRIMARY KEY")
+	}
+	node.Detail = strings.TrimSpace(rest)
+
+	switch {
+	case access == "SCAN" && node.Index == "":
+		node.NodeType = "Table scan"
+		node.FullScan = true
+	case access == "SCAN" && covering:
+		node.NodeType = "Covering index scan"
+	case access == "SCAN":
+		node.NodeType = "Index scan"
+	case covering:
+		node.NodeType = "Covering index lookup"
+	default:
+		node.NodeType = "Index lookup"
+	}
+	return node
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+const (
+	vendorName = "sqlite"
+	// mainDatabase is the schema name SQLite gives to the opened file
+	mainDatabase = "main"
+)
+
+// Vendor describes SQLite to the vendor registry. The connection host holds
+// the database file path and the database is a schema name.
+var Vendor = &domain.Vendor{
+	Name:            vendorName,
+	DisplayName:     "SQLite",
+	Schemes:         []string{"sqlite", "sqlite3"},
+	DefaultDatabase: mainDatabase,
+	DriverName:      "sqlite",
+	Fields: []domain.FormField{
+		{Name: "host", Label: "Database file", Type: "file", Required: true, Placeholder: "/path/to/file.db"},
+	},
+	Arguments: []domain.ArgumentSpec{
+		{Name: "mode", Label: "Open mode", Description: "ro opens the file read-only, rwc creates it when missing", Type: "enum", Options: []string{"ro", "rw", "rwc"}, Default: "rwc"},
+		{Name: "_txlock", Label: "Transaction lock", Description: "Locking mode used by BEGIN", Type: "enum", Options: []string{"deferred", "immediate", "exclusive"}, Default: "deferred"},
+	},
+	BuildDSN: buildConnectionString,
+	NewService: func(sessions *domain.SessionManager) domain.DatabaseService {
+		return NewSQLiteService(sessions)
+	},
+	TablesQuery: func(c *domain.Connection) string {
+		return fmt.Sprintf(`
+			SELECT name, '%s'
+			FROM %s.sqlite_master
+			WHERE type = 'table'
+			AND name NOT LIKE 'sqlite_%%'
+			ORDER BY name
+		`, strings.ReplaceAll(c.Database(), "'", "''"), quoteIdentifier(c.Database()))
+	},
+	QuoteIdentifier: quoteIdentifier,
+	PromptHints: []string{
+		"Consider SQLite-specific features like type affinity, INTEGER PRIMARY KEY rowids, LIMIT, and the lack of RIGHT/FULL JOIN before SQLite 3.39",
+		"Use double quotes for identifiers if needed and prefix tables of attached databases with their schema name",
+	},
+	Dialect: domain.SQLDialect{BracketIdentifiers: true, BlockBodies: true, QuestionPlaceholders: true, Returning: true},
+	Formats: []domain.ConnectionStringFormat{
+		{
+			Name:   domain.URLFormat,
+			Label:  "URL",
+			Parse:  parseURL,
+			Render: renderURL,
+		},
+		{
+			Name:  "file",
+			Label: "File URI",
+			Match: func(s string) bool {
+				return strings.HasPrefix(s, "file:")
+			},
+			Parse: parseFileURI,
+			Render: func(c *domain.Connection, _ bool) string {
+				uri := "file:" + c.Host()
+				if len(c.Arguments()) > 0 {
+					uri += "?" + c.EncodedArguments()
+				}
+				return uri
+			},
+		},
+	},
+	Validate: func(c *domain.Connection) error {
+		if c.Host() == "" {
+			return fmt.Errorf("database file path is required")
+		}
+		return nil
+	},
+}
+
+func init() {
+	domain.RegisterVendor(Vendor)
+}
+
+// buildConnectionString renders the file path, as a file: URI when there are
+// arguments. Read-only connections open the file in ro mode.
+func buildConnectionString(c *domain.Connection) string {
+	arguments := url.Values{}
+	for k, v := range c.Arguments() {
+		arguments.Set(k, v)
+	}
+	if c.ReadOnly() {
+		arguments.Set("mode", "ro")
+	}
+
+	if len(arguments) == 0 {
+		return c.Host()
+	}
+
+	// Encode sorts the keys, so the same connection always renders the same URI
+	return "file:" + c.Host() + "?" + arguments.Encode()
+}
+
+// parseURL parses sqlite:///path/to/file.db, where the file path is kept as
+// the connection host
+func parseURL(id, connStr string) (*domain.Connection, error) {
+	parsedURL, err := url.Parse(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection string format: %v", err)
+	}
+
+	path := parsedURL.Host + parsedURL.Path
+	// sqlite:///C:/data/app.db has a leading slash before the drive letter
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+
+	return newConnection(id, path, parsedURL.Query())
+}
+
+// parseFileURI parses the file:path?args URIs the driver accepts
+func parseFileURI(id, connStr string) (*domain.Connection, error) {
+	path, query, _ := strings.Cut(strings.TrimPrefix(connStr, "file:"), "?")
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection string format: %v", err)
+	}
+
+	return newConnection(id, path, values)
+}
+
+func newConnection(id, path string, values url.Values) (*domain.Connection, error) {
+	arguments := make(map[string]string)
+	for key, v := range values {
+		if len(v) > 0 {
+			arguments[key] = v[0]
+		}
+	}
+
+	return domain.NewConnection(id, vendorName, path, 0, mainDatabase, "", "", arguments)
+}
+
+// renderURL writes the connection as sqlite:///path/to/file.db
+func renderURL(c *domain.Connection, _ bool) string {
+	path := c.Host()
+	if len(path) > 1 && path[1] == ':' {
+		path = "/" + path
+	}
+
+	connURL := "sqlite://" + path
+	if len(c.Arguments()) > 0 {
+		connURL += "?" + c.EncodedArguments()
+	}
+	return connURL
+}
+
+// quoteIdentifier quotes a schema or table name for use in SQLite statements
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
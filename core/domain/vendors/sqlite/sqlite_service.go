@@ -0,0 +1,359 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sync"
+
+	"seagle/core/domain"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteService implements DatabaseService for SQLite files. The connection
+// host holds the file path and the database is the schema name, either
+// "main" or the name of an attached database.
+type SQLiteService struct {
+	sessions *domain.SessionManager
+
+	mu sync.Mutex
+	// attached lists the databases attached by sessions, per pool. Attached
+	// databases only exist in the SQLite connection that attached them, so
+	// every connection taken out of the pool attaches them as well.
+	attached map[*sql.DB][]attachment
+}
+
+// attachment is a database attached under a schema name
+type attachment struct {
+	name string
+	file string
+}
+
+func NewSQLiteService(sessions *domain.SessionManager) *SQLiteService {
+	return &SQLiteService{
+		sessions: sessions,
+		attached: make(map[*sql.DB][]attachment),
+	}
+}
+
+// mainConnection returns the connection whose session is shared by every
+// schema of the file, so databases attached with ATTACH stay visible
+func (s *SQLiteService) mainConnection(c *domain.Connection) *domain.Connection {
+	return domain.CopyConnection(c, mainDatabase)
+}
+
+// Connect opens the pool of the file. Sessions and results each hold a
+// connection of their own; in rollback journal mode a result left open keeps
+// the file locked for reading, so a commit of another session waits for it
+// until the busy timeout runs out.
+func (s *SQLiteService) Connect(c *domain.Connection) error {
+	db, err := s.sessions.Open(s.mainConnection(c), Vendor)
+	if err != nil {
+		return err
+	}
+
+	// Every connection to an in-memory database opens a database of its own,
+	// so its sessions take turns on a single, never expiring connection
+	if inMemory(c) {
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxIdleTime(0)
+	}
+
+	return nil
+}
+
+// inMemory reports whether the connection opens an in-memory database
+func inMemory(c *domain.Connection) bool {
+	return c.Host() == ":memory:" || c.Arguments()["mode"] == "memory"
+}
+
+func (s *SQLiteService) Disconnect(c *domain.Connection) error {
+	if db := s.sessions.Get(s.mainConnection(c)); db != nil {
+		s.mu.Lock()
+		delete(s.attached, db)
+		s.mu.Unlock()
+	}
+	return s.sessions.Close(c)
+}
+
+// takeConn takes a connection out of the pool of the file, with the databases
+// attached by sessions attached to it. The caller closes the connection, then
+// calls release to give back the pool.
+func (s *SQLiteService) takeConn(ctx context.Context, c *domain.Connection) (*sql.DB, *sql.Conn, func(), error) {
+	db, release := s.sessions.Acquire(s.mainConnection(c))
+	if db == nil {
+		return nil, nil, nil, fmt.Errorf("no active connection found")
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		release()
+		return nil, nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if err := s.attach(ctx, db, conn); err != nil {
+		conn.Close()
+		release()
+		return nil, nil, nil, err
+	}
+	return db, conn, release, nil
+}
+
+// attach brings the databases attached to conn in line with those attached
+// by the sessions of the pool. Statements wait for the locks other
+// connections hold rather than failing at once.
+func (s *SQLiteService) attach(ctx context.Context, db *sql.DB, conn *sql.Conn) error {
+	if _, err := conn.ExecContext(ctx, "PRAGMA busy_timeout = 5000"); err != nil {
+		return fmt.Errorf("failed to set busy timeout: %w", err)
+	}
+
+	current, err := attachedDatabases(ctx, conn)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	wanted := s.attached[db]
+	s.mu.Unlock()
+
+	for _, a := range current {
+		if !slices.Contains(wanted, a) {
+			if _, err := conn.ExecContext(ctx, "DETACH DATABASE "+quoteIdentifier(a.name)); err != nil {
+				return fmt.Errorf("failed to detach database %s: %w", a.name, err)
+			}
+		}
+	}
+	for _, a := range wanted {
+		if !slices.Contains(current, a) {
+			if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS "+quoteIdentifier(a.name), a.file); err != nil {
+				return fmt.Errorf("failed to attach database %s: %w", a.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// recordAttached records the databases attached to conn as those of the pool
+func (s *SQLiteService) recordAttached(ctx context.Context, db *sql.DB, conn *sql.Conn) error {
+	attached, err := attachedDatabases(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attached[db] = attached
+	return nil
+}
+
+// attachedDatabases lists the files attached to conn. In-memory and temporary
+// databases have no file and cannot be shared with other connections.
+func attachedDatabases(ctx context.Context, conn *sql.Conn) ([]attachment, error) {
+	query := `
+		SELECT name, file
+		FROM pragma_database_list
+		WHERE name NOT IN ('main', 'temp')
+		AND file <> ''
+		ORDER BY seq
+	`
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attached databases: %w", err)
+	}
+	defer rows.Close()
+
+	var attached []attachment
+	for rows.Next() {
+		var a attachment
+		if err := rows.Scan(&a.name, &a.file); err != nil {
+			return nil, fmt.Errorf("failed to scan attached database: %w", err)
+		}
+		attached = append(attached, a)
+	}
+	return attached, rows.Err()
+}
+
+func (s *SQLiteService) GetDatabaseNames(c *domain.Connection) ([]string, error) {
+	query := `
+		SELECT name
+		FROM pragma_database_list
+		WHERE name <> 'temp'
+		ORDER BY seq
+	`
+
+	ctx := context.Background()
+	_, conn, release, err := s.takeConn(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query databases: %w", err)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var dbName string
+		if err := rows.Scan(&dbName); err != nil {
+			return nil, fmt.Errorf("failed to scan database name: %w", err)
+		}
+		databases = append(databases, dbName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating database results: %w", err)
+	}
+
+	return databases, nil
+}
+
+func (s *SQLiteService) GetTableNames(c *domain.Connection, databaseName string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT name
+		FROM %s.sqlite_master
+		WHERE type = 'table'
+		AND name NOT LIKE 'sqlite_%%'
+		ORDER BY name
+	`, quoteIdentifier(databaseName))
+
+	ctx := context.Background()
+	_, conn, release, err := s.takeConn(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tables for database %s: %w", databaseName, err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating table results: %w", err)
+	}
+
+	return tables, nil
+}
+
+func (s *SQLiteService) GetTableColumns(c *domain.Connection, databaseName, tableName string) ([]domain.ColumnMetadata, error) {
+	query := `
+		SELECT name, type, "notnull", dflt_value, cid
+		FROM pragma_table_info(?, ?)
+		ORDER BY cid
+	`
+	ctx := context.Background()
+	_, conn, release, err := s.takeConn(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, query, tableName, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns for table %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	type Col struct {
+		Name         string
+		DataType     string
+		NotNull      bool
+		DefaultValue sql.NullString
+		Position     int
+	}
+
+	var columns []domain.ColumnMetadata
+	for rows.Next() {
+		var col Col
+		if err := rows.Scan(&col.Name, &col.DataType, &col.NotNull, &col.DefaultValue, &col.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan column metadata: %w", err)
+		}
+
+		column := domain.NewColumnMetadata(col.Name, col.DataType, !col.NotNull, col.DefaultValue.String, col.Position+1)
+		columns = append(columns, *column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating column results: %w", err)
+	}
+
+	return columns, nil
+}
+
+// ExecQuery runs the query until it completes or ctx is done
+func (s *SQLiteService) ExecQuery(ctx context.Context, c *domain.Connection, query string) (*domain.QueryResult, error) {
+	session, err := s.OpenSession(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	result, err := domain.RunQuery(ctx, session, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	return result, nil
+}
+
+func (s *SQLiteService) GetTableMetadata(c *domain.Connection, tableName, schemaName string) (*domain.TableMetadata, error) {
+	ctx := context.Background()
+	_, conn, release, err := s.takeConn(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer conn.Close()
+
+	metadata := domain.NewTableMetadata(tableName, schemaName)
+
+	query := `
+		SELECT
+			name,
+			type,
+			"notnull" = 0 as is_nullable,
+			COALESCE(dflt_value, '') as column_default,
+			cid + 1 as ordinal_position
+		FROM pragma_table_info(?, ?)
+		ORDER BY cid
+	`
+
+	rows, err := conn.QueryContext(ctx, query, tableName, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns for table %s.%s: %w", schemaName, tableName, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, dataType, defaultValue string
+		var isNullable bool
+		var position int
+
+		if err := rows.Scan(&name, &dataType, &isNullable, &defaultValue, &position); err != nil {
+			return nil, fmt.Errorf("failed to scan column metadata: %w", err)
+		}
+
+		column := domain.NewColumnMetadata(name, dataType, isNullable, defaultValue, position)
+		metadata.AddColumn(column)
+	}
+
+	return metadata, rows.Err()
+}
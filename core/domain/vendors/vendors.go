@@ -0,0 +1,9 @@
+// Package vendors registers every database engine supported by the application.
+// Importing it for side effects makes the engines available to the domain.
+package vendors
+
+import (
+	_ "seagle/core/domain/vendors/mysql"
+	_ "seagle/core/domain/vendors/postgresql"
+	_ "seagle/core/domain/vendors/sqlite"
+)
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// BeginTransactionInput represents the input for the BeginTransaction handler
+type BeginTransactionInput struct {
+	ID       string `json:"id"`
+	Database string `json:"database"`
+}
+
+// BeginTransactionOutput represents the output for the BeginTransaction handler
+type BeginTransactionOutput struct {
+	Success     bool                    `json:"success"`
+	Message     string                  `json:"message,omitempty"`
+	Transaction *types.TransactionState `json:"transaction,omitempty"`
+}
+
+// BeginTransactionHandler handles requests to open a transaction left open across queries
+type BeginTransactionHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewBeginTransactionHandler creates a new BeginTransactionHandler instance
+func NewBeginTransactionHandler(connectionService *services.ConnectionService) *BeginTransactionHandler {
+	return &BeginTransactionHandler{
+		connectionService: connectionService,
+	}
+}
+
+// BeginTransaction opens a transaction on the database, queries run in it until it is
+// committed or rolled back
+func (h *BeginTransactionHandler) BeginTransaction(input BeginTransactionInput) (*BeginTransactionOutput, error) {
+	if input.ID == "" {
+		return &BeginTransactionOutput{
+			Success: false,
+			Message: "Connection ID cannot be empty",
+		}, nil
+	}
+
+	transaction, err := h.connectionService.BeginTransaction(input.ID, input.Database)
+	if err != nil {
+		return &BeginTransactionOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &BeginTransactionOutput{
+		Success:     true,
+		Message:     "Transaction started",
+		Transaction: transaction,
+	}, nil
+}
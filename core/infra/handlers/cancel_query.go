@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"seagle/core/services"
+)
+
+// CancelQueryInput represents the input for the CancelQuery handler
+type CancelQueryInput struct {
+	ExecutionID string `json:"executionId"`
+}
+
+// CancelQueryOutput represents the output for the CancelQuery handler
+type CancelQueryOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// CancelQueryHandler handles query cancellation requests
+type CancelQueryHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewCancelQueryHandler creates a new CancelQueryHandler instance
+func NewCancelQueryHandler(connectionService *services.ConnectionService) *CancelQueryHandler {
+	return &CancelQueryHandler{
+		connectionService: connectionService,
+	}
+}
+
+// CancelQuery stops the query running under the execution ID
+func (h *CancelQueryHandler) CancelQuery(input CancelQueryInput) (*CancelQueryOutput, error) {
+	if input.ExecutionID == "" {
+		return &CancelQueryOutput{
+			Success: false,
+			Message: "Execution ID cannot be empty",
+		}, nil
+	}
+
+	if err := h.connectionService.CancelQuery(input.ExecutionID); err != nil {
+		return &CancelQueryOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &CancelQueryOutput{
+		Success: true,
+		Message: "Query canceled successfully",
+	}, nil
+}
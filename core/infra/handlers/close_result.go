@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"seagle/core/services"
+)
+
+// CloseResultInput represents the input for the CloseResult handler
+type CloseResultInput struct {
+	ResultID string `json:"resultId"`
+}
+
+// CloseResultOutput represents the output for the CloseResult handler
+type CloseResultOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// CloseResultHandler handles requests to stop reading a query result
+type CloseResultHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewCloseResultHandler creates a new CloseResultHandler instance
+func NewCloseResultHandler(connectionService *services.ConnectionService) *CloseResultHandler {
+	return &CloseResultHandler{
+		connectionService: connectionService,
+	}
+}
+
+// CloseResult releases the session held by an open result
+func (h *CloseResultHandler) CloseResult(input CloseResultInput) (*CloseResultOutput, error) {
+	h.connectionService.CloseResult(input.ResultID)
+
+	return &CloseResultOutput{
+		Success: true,
+		Message: "Result closed successfully",
+	}, nil
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// CommitTransactionInput represents the input for the CommitTransaction handler
+type CommitTransactionInput struct {
+	ID       string `json:"id"`
+	Database string `json:"database"`
+}
+
+// CommitTransactionOutput represents the output for the CommitTransaction handler
+type CommitTransactionOutput struct {
+	Success     bool                    `json:"success"`
+	Message     string                  `json:"message,omitempty"`
+	Transaction *types.TransactionState `json:"transaction,omitempty"`
+}
+
+// CommitTransactionHandler handles requests to commit an open transaction
+type CommitTransactionHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewCommitTransactionHandler creates a new CommitTransactionHandler instance
+func NewCommitTransactionHandler(connectionService *services.ConnectionService) *CommitTransactionHandler {
+	return &CommitTransactionHandler{
+		connectionService: connectionService,
+	}
+}
+
+// CommitTransaction commits the open transaction of the database
+func (h *CommitTransactionHandler) CommitTransaction(input CommitTransactionInput) (*CommitTransactionOutput, error) {
+	if input.ID == "" {
+		return &CommitTransactionOutput{
+			Success: false,
+			Message: "Connection ID cannot be empty",
+		}, nil
+	}
+
+	transaction, err := h.connectionService.Commit(input.ID, input.Database)
+	if err != nil {
+		if errors.Is(err, services.ErrNoTransaction) {
+			return &CommitTransactionOutput{
+				Success: false,
+				Message: "There is no open transaction to commit",
+			}, nil
+		}
+		return &CommitTransactionOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &CommitTransactionOutput{
+		Success:     true,
+		Message:     "Transaction committed",
+		Transaction: transaction,
+	}, nil
+}
@@ -7,14 +7,30 @@ import (
 
 // ConnectInput represents the input for the Connect handler
 type ConnectInput struct {
-	Host                string `json:"host"`
-	Port                int    `json:"port"`
-	Database            string `json:"database"`
-	Username            string `json:"username"`
-	Password            string `json:"password"`
-	SSLMode             string `json:"sslmode"`
-	ConnectionString    string `json:"connectionString"`
-	UseConnectionString bool   `json:"useConnectionString"`
+	Vendor              string                 `json:"vendor"`
+	Host                string                 `json:"host"`
+	Port                int                    `json:"port"`
+	Database            string                 `json:"database"`
+	Username            string                 `json:"username"`
+	Password            string                 `json:"password"`
+	PassCommand         string                 `json:"passCommand,omitempty"`
+	PasswordRef         string                 `json:"passwordRef,omitempty"`
+	PromptPassword      bool                   `json:"promptPassword,omitempty"`
+	ReadOnly            bool                   `json:"readOnly,omitempty"`
+	ManualCommit        bool                   `json:"manualCommit,omitempty"`
+	StatementTimeout    int                    `json:"statementTimeout,omitempty"`
+	SSLMode             string                 `json:"sslmode"`
+	Arguments           map[string]string      `json:"arguments,omitempty"`
+	SSH                 *types.SSHTunnelConfig `json:"ssh,omitempty"`
+	TLS                 *types.TLSConfig       `json:"tls,omitempty"`
+	FailoverHosts       []types.Endpoint       `json:"failoverHosts,omitempty"`
+	Name                string                 `json:"name,omitempty"`
+	Folder              string                 `json:"folder,omitempty"`
+	Tags                []string               `json:"tags,omitempty"`
+	Environment         string                 `json:"environment,omitempty"`
+	Color               string                 `json:"color,omitempty"`
+	ConnectionString    string                 `json:"connectionString"`
+	UseConnectionString bool                   `json:"useConnectionString"`
 }
 
 // ConnectOutput represents the output for the Connect handler
@@ -40,12 +56,28 @@ func NewConnectHandler(connectionService *services.ConnectionService) *ConnectHa
 // Connect processes the connection request
 func (h *ConnectHandler) Connect(input ConnectInput) (*ConnectOutput, error) {
 	res, err := h.connectionService.Connect(types.DatabaseConfig{
+		Vendor:              input.Vendor,
 		Host:                input.Host,
 		Port:                input.Port,
 		Database:            input.Database,
 		Username:            input.Username,
 		Password:            input.Password,
+		PassCommand:         input.PassCommand,
+		PasswordRef:         input.PasswordRef,
+		PromptPassword:      input.PromptPassword,
+		ReadOnly:            input.ReadOnly,
+		ManualCommit:        input.ManualCommit,
+		StatementTimeout:    input.StatementTimeout,
 		SSLMode:             input.SSLMode,
+		Arguments:           input.Arguments,
+		SSH:                 input.SSH,
+		TLS:                 input.TLS,
+		FailoverHosts:       input.FailoverHosts,
+		Name:                input.Name,
+		Folder:              input.Folder,
+		Tags:                input.Tags,
+		Environment:         input.Environment,
+		Color:               input.Color,
 		ConnectionString:    input.ConnectionString,
 		UseConnectionString: input.UseConnectionString,
 	})
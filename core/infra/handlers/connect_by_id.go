@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"errors"
+	"time"
+
 	"seagle/core/services"
 )
 
@@ -16,12 +19,18 @@ func NewConnectByIDHandler(connectionService *services.ConnectionService) *Conne
 
 type ConnectByIDInput struct {
 	ID string `json:"id"`
+	// Password is supplied for connections that do not save it
+	Password string `json:"password,omitempty"`
+	// RememberFor keeps the supplied password in memory after disconnecting, in seconds
+	RememberFor int `json:"rememberFor,omitempty"`
 }
 
 type ConnectByIDOutput struct {
-	Success   bool     `json:"success"`
-	Message   string   `json:"message"`
-	Databases []string `json:"databases,omitempty"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// CredentialsRequired asks for the password and a new call supplying it
+	CredentialsRequired bool     `json:"credentialsRequired,omitempty"`
+	Databases           []string `json:"databases,omitempty"`
 }
 
 func (h *ConnectByIDHandler) ConnectByID(input ConnectByIDInput) (*ConnectByIDOutput, error) {
@@ -32,11 +41,12 @@ func (h *ConnectByIDHandler) ConnectByID(input ConnectByIDInput) (*ConnectByIDOu
 		}, nil
 	}
 
-	_, err := h.connectionService.ConnectByID(input.ID)
+	_, err := h.connectionService.ConnectByID(input.ID, input.Password, time.Duration(input.RememberFor)*time.Second)
 	if err != nil {
 		return &ConnectByIDOutput{
-			Success: false,
-			Message: err.Error(),
+			Success:             false,
+			Message:             err.Error(),
+			CredentialsRequired: errors.Is(err, services.ErrCredentialsRequired),
 		}, nil
 	}
 
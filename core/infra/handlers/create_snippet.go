@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// CreateSnippetInput represents the input for the CreateSnippet handler
+type CreateSnippetInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Folder is a path of folder names separated by /
+	Folder string `json:"folder,omitempty"`
+	// ConnectionID scopes the snippet to a connection, empty for a global one
+	ConnectionID string `json:"connectionId,omitempty"`
+	// Query may hold ${name} variables, bound to values when the snippet runs
+	Query string `json:"query"`
+}
+
+// CreateSnippetOutput represents the output for the CreateSnippet handler
+type CreateSnippetOutput struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message,omitempty"`
+	Snippet *types.Snippet `json:"snippet,omitempty"`
+}
+
+// CreateSnippetHandler handles requests to save a new query snippet
+type CreateSnippetHandler struct {
+	snippetService *services.SnippetService
+}
+
+// NewCreateSnippetHandler creates a new CreateSnippetHandler instance
+func NewCreateSnippetHandler(snippetService *services.SnippetService) *CreateSnippetHandler {
+	return &CreateSnippetHandler{
+		snippetService: snippetService,
+	}
+}
+
+// CreateSnippet saves a new snippet
+func (h *CreateSnippetHandler) CreateSnippet(input CreateSnippetInput) (*CreateSnippetOutput, error) {
+	snippet, err := h.snippetService.CreateSnippet(types.SnippetInput{
+		Name:         input.Name,
+		Description:  input.Description,
+		Folder:       input.Folder,
+		ConnectionID: input.ConnectionID,
+		Query:        input.Query,
+	})
+	if err != nil {
+		return &CreateSnippetOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &CreateSnippetOutput{
+		Success: true,
+		Message: "Snippet saved",
+		Snippet: snippet,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"seagle/core/services"
+)
+
+// CreateVaultInput represents the input for the CreateVault handler
+type CreateVaultInput struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// CreateVaultOutput represents the output for the CreateVault handler
+type CreateVaultOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// CreateVaultHandler creates the vault with a master passphrase
+type CreateVaultHandler struct {
+	vaultService *services.VaultService
+}
+
+// NewCreateVaultHandler creates a new CreateVaultHandler instance
+func NewCreateVaultHandler(vaultService *services.VaultService) *CreateVaultHandler {
+	return &CreateVaultHandler{
+		vaultService: vaultService,
+	}
+}
+
+// CreateVault processes the CreateVault request
+func (h *CreateVaultHandler) CreateVault(input CreateVaultInput) (*CreateVaultOutput, error) {
+	if err := h.vaultService.Create(input.Passphrase); err != nil {
+		return &CreateVaultOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &CreateVaultOutput{
+		Success: true,
+		Message: "Vault created successfully",
+	}, nil
+}
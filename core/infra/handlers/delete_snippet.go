@@ -0,0 +1,48 @@
+package handlers
+
+import "seagle/core/services"
+
+// DeleteSnippetInput represents the input for the DeleteSnippet handler
+type DeleteSnippetInput struct {
+	ID string `json:"id"`
+}
+
+// DeleteSnippetOutput represents the output for the DeleteSnippet handler
+type DeleteSnippetOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// DeleteSnippetHandler handles requests to remove a saved query snippet
+type DeleteSnippetHandler struct {
+	snippetService *services.SnippetService
+}
+
+// NewDeleteSnippetHandler creates a new DeleteSnippetHandler instance
+func NewDeleteSnippetHandler(snippetService *services.SnippetService) *DeleteSnippetHandler {
+	return &DeleteSnippetHandler{
+		snippetService: snippetService,
+	}
+}
+
+// DeleteSnippet removes a saved snippet
+func (h *DeleteSnippetHandler) DeleteSnippet(input DeleteSnippetInput) (*DeleteSnippetOutput, error) {
+	if input.ID == "" {
+		return &DeleteSnippetOutput{
+			Success: false,
+			Message: "Snippet ID cannot be empty",
+		}, nil
+	}
+
+	if err := h.snippetService.DeleteSnippet(input.ID); err != nil {
+		return &DeleteSnippetOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &DeleteSnippetOutput{
+		Success: true,
+		Message: "Snippet deleted",
+	}, nil
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// DuplicateConnectionInput represents the input for the DuplicateConnection handler
+type DuplicateConnectionInput struct {
+	ID string `json:"id"`
+	// Config replaces the settings of the copy, which clones the source when empty
+	Config *types.DatabaseConfig `json:"config,omitempty"`
+	// Test connects with the copy before saving it
+	Test bool `json:"test"`
+}
+
+// DuplicateConnectionOutput represents the output for the DuplicateConnection handler
+type DuplicateConnectionOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	ID      string `json:"id"`
+}
+
+// DuplicateConnectionHandler handles saved connection copies
+type DuplicateConnectionHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewDuplicateConnectionHandler creates a new DuplicateConnectionHandler instance
+func NewDuplicateConnectionHandler(connectionService *services.ConnectionService) *DuplicateConnectionHandler {
+	return &DuplicateConnectionHandler{
+		connectionService: connectionService,
+	}
+}
+
+// DuplicateConnection processes the DuplicateConnection request
+func (h *DuplicateConnectionHandler) DuplicateConnection(input DuplicateConnectionInput) (*DuplicateConnectionOutput, error) {
+	res, err := h.connectionService.DuplicateConnection(input.ID, input.Config, input.Test)
+	if err != nil {
+		return &DuplicateConnectionOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &DuplicateConnectionOutput{
+		Success: true,
+		Message: "Connection duplicated successfully",
+		ID:      res.ID,
+	}, nil
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+
 	"seagle/core/services"
 	"seagle/core/services/types"
 )
@@ -10,13 +13,35 @@ type ExecuteQueryInput struct {
 	ID       string `json:"id"`
 	Database string `json:"database"`
 	Query    string `json:"query"`
+	// ExecutionID names the execution, so CancelQuery can stop it
+	ExecutionID string `json:"executionId,omitempty"`
+	// PageSize is the rows of the first page, the rest are read with FetchResultPage
+	PageSize int `json:"pageSize,omitempty"`
+	// ConfirmationToken confirms the destructive statements of the query
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+	// ContinueOnError runs the rest of the script after a statement fails
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// Parameters are the values bound to the placeholders of the query
+	Parameters []types.QueryParameterValue `json:"parameters,omitempty"`
+	// DryRun previews what the UPDATE, DELETE and INSERT statements of the
+	// query change, rolling the changes back
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ExecuteQueryOutput represents the output for the ExecuteQuery handler
 type ExecuteQueryOutput struct {
-	Success bool               `json:"success"`
-	Message string             `json:"message,omitempty"`
-	Result  *types.QueryResult `json:"result,omitempty"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	// Result is the result of the last statement that ran successfully
+	Result *types.QueryResult `json:"result,omitempty"`
+	// Statements holds the outcome of every statement of the script that ran
+	Statements []types.StatementResult `json:"statements,omitempty"`
+	// Confirmation is set when the query was not run because its destructive
+	// statements need confirmation
+	Confirmation *types.QueryConfirmation `json:"confirmation,omitempty"`
+	// Transaction is the transaction the query ran in, with its uncommitted
+	// changes
+	Transaction *types.TransactionState `json:"transaction,omitempty"`
 }
 
 // ExecuteQueryHandler handles query execution requests
@@ -40,17 +65,72 @@ func (h *ExecuteQueryHandler) ExecuteQuery(input ExecuteQueryInput) (*ExecuteQue
 		}, nil
 	}
 
-	result, err := h.connectionService.ExecuteQuery(input.ID, input.Database, input.Query)
+	result, err := h.connectionService.ExecuteQuery(types.QueryRequest{
+		ConnectionID:      input.ID,
+		Database:          input.Database,
+		Query:             input.Query,
+		ExecutionID:       input.ExecutionID,
+		PageSize:          input.PageSize,
+		ConfirmationToken: input.ConfirmationToken,
+		ContinueOnError:   input.ContinueOnError,
+		Parameters:        input.Parameters,
+		DryRun:            input.DryRun,
+	})
+	return executeQueryOutput(result, err, input.DryRun), nil
+}
+
+// executeQueryOutput reports the outcome of a script, the result of its last
+// successful statement and the first statement that failed
+func executeQueryOutput(result *types.ScriptResult, err error, dryRun bool) *ExecuteQueryOutput {
+	var confirmationErr *services.ConfirmationRequiredError
+	if errors.As(err, &confirmationErr) {
+		return &ExecuteQueryOutput{
+			Success:      false,
+			Message:      err.Error(),
+			Confirmation: &confirmationErr.Confirmation,
+		}
+	}
 	if err != nil {
 		return &ExecuteQueryOutput{
 			Success: false,
 			Message: err.Error(),
-		}, nil
+		}
+	}
+
+	output := &ExecuteQueryOutput{
+		Success:     true,
+		Message:     "Query executed successfully",
+		Statements:  result.Statements,
+		Transaction: result.Transaction,
+	}
+	if result.Total > 1 {
+		output.Message = fmt.Sprintf("%d statements executed successfully", result.Total)
+	}
+	if dryRun {
+		var affected int64
+		for _, statement := range result.Statements {
+			if statement.Preview != nil {
+				affected += statement.Preview.RowsAffected
+			}
+		}
+		output.Message = fmt.Sprintf("Dry run: %d rows affected, the changes were rolled back", affected)
+	}
+
+	failed := 0
+	for i, statement := range result.Statements {
+		if statement.Error == "" {
+			output.Result = statement.Result
+			continue
+		}
+		if failed == 0 {
+			output.Success = false
+			output.Message = fmt.Sprintf("Statement %d of %d failed: %s", i+1, result.Total, statement.Error)
+		}
+		failed++
+	}
+	if failed > 1 {
+		output.Message += fmt.Sprintf(" (%d statements failed)", failed)
 	}
 
-	return &ExecuteQueryOutput{
-		Success: true,
-		Message: "Query executed successfully",
-		Result:  result,
-	}, nil
+	return output
 }
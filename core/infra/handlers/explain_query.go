@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// ExplainQueryInput represents the input for the ExplainQuery handler
+type ExplainQueryInput struct {
+	ID       string `json:"id"`
+	Database string `json:"database"`
+	Query    string `json:"query"`
+	// Analyze runs the statement to measure it, the changes are rolled back
+	Analyze bool `json:"analyze,omitempty"`
+	// Buffers reports the blocks read and written, PostgreSQL only
+	Buffers     bool                        `json:"buffers,omitempty"`
+	Parameters  []types.QueryParameterValue `json:"parameters,omitempty"`
+	ExecutionID string                      `json:"executionId,omitempty"`
+	// ConfirmationToken confirms analyzing a destructive statement
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+}
+
+// ExplainQueryOutput represents the output for the ExplainQuery handler
+type ExplainQueryOutput struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message,omitempty"`
+	Plan    *types.QueryPlan `json:"plan,omitempty"`
+	// Confirmation is set when the statement was not analyzed because it is
+	// destructive and needs confirmation
+	Confirmation *types.QueryConfirmation `json:"confirmation,omitempty"`
+}
+
+// ExplainQueryHandler handles requests to explain a statement
+type ExplainQueryHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewExplainQueryHandler creates a new ExplainQueryHandler instance
+func NewExplainQueryHandler(connectionService *services.ConnectionService) *ExplainQueryHandler {
+	return &ExplainQueryHandler{
+		connectionService: connectionService,
+	}
+}
+
+// ExplainQuery returns the plan of a statement with its hotspots
+func (h *ExplainQueryHandler) ExplainQuery(input ExplainQueryInput) (*ExplainQueryOutput, error) {
+	if input.ID == "" {
+		return &ExplainQueryOutput{
+			Success: false,
+			Message: "Connection ID cannot be empty",
+		}, nil
+	}
+
+	if input.Query == "" {
+		return &ExplainQueryOutput{
+			Success: false,
+			Message: "Query cannot be empty",
+		}, nil
+	}
+
+	plan, err := h.connectionService.ExplainQuery(types.ExplainRequest{
+		ConnectionID:      input.ID,
+		Database:          input.Database,
+		Query:             input.Query,
+		Analyze:           input.Analyze,
+		Buffers:           input.Buffers,
+		Parameters:        input.Parameters,
+		ExecutionID:       input.ExecutionID,
+		ConfirmationToken: input.ConfirmationToken,
+	})
+	var confirmationErr *services.ConfirmationRequiredError
+	if errors.As(err, &confirmationErr) {
+		return &ExplainQueryOutput{
+			Success:      false,
+			Message:      err.Error(),
+			Confirmation: &confirmationErr.Confirmation,
+		}, nil
+	}
+	if err != nil {
+		return &ExplainQueryOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &ExplainQueryOutput{
+		Success: true,
+		Plan:    plan,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// FetchResultPageInput represents the input for the FetchResultPage handler
+type FetchResultPageInput struct {
+	ResultID string `json:"resultId"`
+	PageSize int    `json:"pageSize,omitempty"`
+}
+
+// FetchResultPageOutput represents the output for the FetchResultPage handler
+type FetchResultPageOutput struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message,omitempty"`
+	Result  *types.QueryResult `json:"result,omitempty"`
+}
+
+// FetchResultPageHandler handles requests for the next rows of a query result
+type FetchResultPageHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewFetchResultPageHandler creates a new FetchResultPageHandler instance
+func NewFetchResultPageHandler(connectionService *services.ConnectionService) *FetchResultPageHandler {
+	return &FetchResultPageHandler{
+		connectionService: connectionService,
+	}
+}
+
+// FetchResultPage reads the next page of rows of an open result
+func (h *FetchResultPageHandler) FetchResultPage(input FetchResultPageInput) (*FetchResultPageOutput, error) {
+	if input.ResultID == "" {
+		return &FetchResultPageOutput{
+			Success: false,
+			Message: "Result ID cannot be empty",
+		}, nil
+	}
+
+	result, err := h.connectionService.FetchResultPage(input.ResultID, input.PageSize)
+	if err != nil {
+		return &FetchResultPageOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &FetchResultPageOutput{
+		Success: true,
+		Result:  result,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"seagle/core/services"
+)
+
+// FormatConnectionStringInput represents the input for the FormatConnectionString handler
+type FormatConnectionStringInput struct {
+	ID              string `json:"id"`
+	Format          string `json:"format"`
+	IncludePassword bool   `json:"includePassword"`
+}
+
+// FormatConnectionStringOutput represents the output for the FormatConnectionString handler
+type FormatConnectionStringOutput struct {
+	Success          bool   `json:"success"`
+	Message          string `json:"message,omitempty"`
+	ConnectionString string `json:"connectionString,omitempty"`
+}
+
+// FormatConnectionStringHandler handles connection string rendering requests
+type FormatConnectionStringHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewFormatConnectionStringHandler creates a new FormatConnectionStringHandler instance
+func NewFormatConnectionStringHandler(connectionService *services.ConnectionService) *FormatConnectionStringHandler {
+	return &FormatConnectionStringHandler{
+		connectionService: connectionService,
+	}
+}
+
+// FormatConnectionString renders a saved connection in one of the formats
+// listed by its vendor capabilities
+func (h *FormatConnectionStringHandler) FormatConnectionString(input FormatConnectionStringInput) (*FormatConnectionStringOutput, error) {
+	connStr, err := h.connectionService.FormatConnectionString(input.ID, input.Format, input.IncludePassword)
+	if err != nil {
+		return &FormatConnectionStringOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &FormatConnectionStringOutput{
+		Success:          true,
+		Message:          "Connection string rendered successfully",
+		ConnectionString: connStr,
+	}, nil
+}
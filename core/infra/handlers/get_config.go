@@ -21,7 +21,9 @@ type GetConfigOutput struct {
 }
 
 type AppConfig struct {
-	OpenAIAPIKey string `json:"openAIAPIKey"`
+	OpenAIAPIKey   string `json:"openAIAPIKey"`
+	MaxResultRows  int    `json:"maxResultRows"`
+	MaxResultBytes int64  `json:"maxResultBytes"`
 }
 
 func (h *GetConfigHandler) GetConfig() (*GetConfigOutput, error) {
@@ -37,7 +39,9 @@ func (h *GetConfigHandler) GetConfig() (*GetConfigOutput, error) {
 		Success: true,
 		Message: "Configuration updated successfully",
 		Config: AppConfig{
-			OpenAIAPIKey: cfg.OpenAIAPIKey,
+			OpenAIAPIKey:   cfg.OpenAIAPIKey,
+			MaxResultRows:  cfg.MaxResultRows,
+			MaxResultBytes: cfg.MaxResultBytes,
 		},
 	}, nil
 }
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// GetQueryParametersInput represents the input for the GetQueryParameters handler
+type GetQueryParametersInput struct {
+	ID    string `json:"id"`
+	Query string `json:"query"`
+}
+
+// GetQueryParametersOutput represents the output for the GetQueryParameters handler
+type GetQueryParametersOutput struct {
+	Success    bool                   `json:"success"`
+	Message    string                 `json:"message,omitempty"`
+	Parameters []types.QueryParameter `json:"parameters"`
+}
+
+// GetQueryParametersHandler handles requests for the placeholders of a query
+type GetQueryParametersHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewGetQueryParametersHandler creates a new GetQueryParametersHandler instance
+func NewGetQueryParametersHandler(connectionService *services.ConnectionService) *GetQueryParametersHandler {
+	return &GetQueryParametersHandler{
+		connectionService: connectionService,
+	}
+}
+
+// GetQueryParameters returns the placeholders of the query, :name, $1 or ?,
+// so the UI can ask their values before running it
+func (h *GetQueryParametersHandler) GetQueryParameters(input GetQueryParametersInput) (*GetQueryParametersOutput, error) {
+	parameters, err := h.connectionService.QueryParameters(input.ID, input.Query)
+	if err != nil {
+		return &GetQueryParametersOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &GetQueryParametersOutput{
+		Success:    true,
+		Parameters: parameters,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// GetVaultStatusOutput represents the output for the GetVaultStatus handler
+type GetVaultStatusOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message,omitempty"`
+	Status  types.VaultStatus `json:"status"`
+}
+
+// GetVaultStatusHandler tells whether the vault must be created or unlocked
+type GetVaultStatusHandler struct {
+	vaultService *services.VaultService
+}
+
+// NewGetVaultStatusHandler creates a new GetVaultStatusHandler instance
+func NewGetVaultStatusHandler(vaultService *services.VaultService) *GetVaultStatusHandler {
+	return &GetVaultStatusHandler{
+		vaultService: vaultService,
+	}
+}
+
+// GetVaultStatus returns whether the vault exists and is unlocked
+func (h *GetVaultStatusHandler) GetVaultStatus() (*GetVaultStatusOutput, error) {
+	return &GetVaultStatusOutput{
+		Success: true,
+		Status:  h.vaultService.Status(),
+	}, nil
+}
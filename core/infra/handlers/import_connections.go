@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// ImportConnectionsInput represents the input for the ImportConnections handler
+type ImportConnectionsInput struct {
+	Sources []types.ImportRequest `json:"sources"`
+	// Keys are the keys of the previewed candidates to import
+	Keys []string `json:"keys"`
+}
+
+// ImportConnectionsOutput represents the output for the ImportConnections handler
+type ImportConnectionsOutput struct {
+	Success     bool                      `json:"success"`
+	Message     string                    `json:"message,omitempty"`
+	Connections []types.ConnectionSummary `json:"connections"`
+	Errors      []types.ImportError       `json:"errors"`
+}
+
+// ImportConnectionsHandler saves previewed connections
+type ImportConnectionsHandler struct {
+	importService *services.ImportService
+}
+
+// NewImportConnectionsHandler creates a new ImportConnectionsHandler instance
+func NewImportConnectionsHandler(importService *services.ImportService) *ImportConnectionsHandler {
+	return &ImportConnectionsHandler{
+		importService: importService,
+	}
+}
+
+// ImportConnections processes the ImportConnections request
+func (h *ImportConnectionsHandler) ImportConnections(input ImportConnectionsInput) (*ImportConnectionsOutput, error) {
+	connections, importErrors, err := h.importService.ImportConnections(input.Sources, input.Keys)
+	if err != nil {
+		return &ImportConnectionsOutput{
+			Success:     false,
+			Message:     err.Error(),
+			Connections: []types.ConnectionSummary{},
+			Errors:      []types.ImportError{},
+		}, nil
+	}
+
+	return &ImportConnectionsOutput{
+		Success:     true,
+		Message:     "Connections imported successfully",
+		Connections: connections,
+		Errors:      importErrors,
+	}, nil
+}
@@ -15,14 +15,29 @@ func NewListConnectionsHandler(connectionService *services.ConnectionService) *L
 	}
 }
 
+type ListConnectionsInput struct {
+	Folder      string `json:"folder,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Search      string `json:"search,omitempty"`
+	GroupBy     string `json:"groupBy,omitempty"`
+}
+
 type ListConnectionsOutput struct {
 	Success     bool                      `json:"success"`
 	Message     string                    `json:"message"`
 	Connections []types.ConnectionSummary `json:"connections"`
+	Groups      []types.ConnectionGroup   `json:"groups,omitempty"`
 }
 
-func (h *ListConnectionsHandler) ListConnections() (*ListConnectionsOutput, error) {
-	connections, err := h.connectionService.ListConnections()
+func (h *ListConnectionsHandler) ListConnections(input ListConnectionsInput) (*ListConnectionsOutput, error) {
+	connections, groups, err := h.connectionService.ListConnections(types.ConnectionFilter{
+		Folder:      input.Folder,
+		Tag:         input.Tag,
+		Environment: input.Environment,
+		Search:      input.Search,
+		GroupBy:     input.GroupBy,
+	})
 	if err != nil {
 		return &ListConnectionsOutput{
 			Success:     false,
@@ -35,5 +50,6 @@ func (h *ListConnectionsHandler) ListConnections() (*ListConnectionsOutput, erro
 		Success:     true,
 		Message:     "Connections listed successfully",
 		Connections: connections,
+		Groups:      groups,
 	}, nil
 }
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// ListImportSourcesOutput represents the output for the ListImportSources handler
+type ListImportSourcesOutput struct {
+	Success bool                 `json:"success"`
+	Message string               `json:"message,omitempty"`
+	Sources []types.ImportSource `json:"sources"`
+}
+
+// ListImportSourcesHandler lists the tools connections can be imported from
+type ListImportSourcesHandler struct {
+	importService *services.ImportService
+}
+
+// NewListImportSourcesHandler creates a new ListImportSourcesHandler instance
+func NewListImportSourcesHandler(importService *services.ImportService) *ListImportSourcesHandler {
+	return &ListImportSourcesHandler{
+		importService: importService,
+	}
+}
+
+// ListImportSources returns every import source with its default path
+func (h *ListImportSourcesHandler) ListImportSources() (*ListImportSourcesOutput, error) {
+	return &ListImportSourcesOutput{
+		Success: true,
+		Message: "Import sources listed successfully",
+		Sources: h.importService.ListSources(),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// ListVendorsOutput represents the output for the ListVendors handler
+type ListVendorsOutput struct {
+	Success bool                       `json:"success"`
+	Message string                     `json:"message,omitempty"`
+	Vendors []types.VendorCapabilities `json:"vendors"`
+}
+
+// ListVendorsHandler handles vendor capability listing requests
+type ListVendorsHandler struct {
+	vendorService *services.VendorService
+}
+
+// NewListVendorsHandler creates a new ListVendorsHandler instance
+func NewListVendorsHandler(vendorService *services.VendorService) *ListVendorsHandler {
+	return &ListVendorsHandler{
+		vendorService: vendorService,
+	}
+}
+
+// ListVendors returns the connection form capabilities of every supported vendor
+func (h *ListVendorsHandler) ListVendors() (*ListVendorsOutput, error) {
+	return &ListVendorsOutput{
+		Success: true,
+		Message: "Vendors listed successfully",
+		Vendors: h.vendorService.ListVendors(),
+	}, nil
+}
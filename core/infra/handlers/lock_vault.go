@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"seagle/core/services"
+)
+
+// LockVaultOutput represents the output for the LockVault handler
+type LockVaultOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// LockVaultHandler locks the vault before its relock timeout
+type LockVaultHandler struct {
+	vaultService *services.VaultService
+}
+
+// NewLockVaultHandler creates a new LockVaultHandler instance
+func NewLockVaultHandler(vaultService *services.VaultService) *LockVaultHandler {
+	return &LockVaultHandler{
+		vaultService: vaultService,
+	}
+}
+
+// LockVault drops the vault key from memory
+func (h *LockVaultHandler) LockVault() (*LockVaultOutput, error) {
+	h.vaultService.Lock()
+
+	return &LockVaultOutput{
+		Success: true,
+		Message: "Vault locked successfully",
+	}, nil
+}
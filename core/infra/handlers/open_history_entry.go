@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// OpenHistoryEntryInput represents the input for the OpenHistoryEntry handler
+type OpenHistoryEntryInput struct {
+	ID string `json:"id"`
+}
+
+// OpenHistoryEntryOutput represents the output for the OpenHistoryEntry handler
+type OpenHistoryEntryOutput struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message,omitempty"`
+	Entry   *types.HistoryEntry `json:"entry,omitempty"`
+}
+
+// OpenHistoryEntryHandler handles requests to open a query of the history again
+type OpenHistoryEntryHandler struct {
+	historyService *services.HistoryService
+}
+
+// NewOpenHistoryEntryHandler creates a new OpenHistoryEntryHandler instance
+func NewOpenHistoryEntryHandler(historyService *services.HistoryService) *OpenHistoryEntryHandler {
+	return &OpenHistoryEntryHandler{
+		historyService: historyService,
+	}
+}
+
+// OpenHistoryEntry returns an entry of the history with its connection,
+// database and SQL text, to load it in the editor again
+func (h *OpenHistoryEntryHandler) OpenHistoryEntry(input OpenHistoryEntryInput) (*OpenHistoryEntryOutput, error) {
+	if input.ID == "" {
+		return &OpenHistoryEntryOutput{
+			Success: false,
+			Message: "History entry ID cannot be empty",
+		}, nil
+	}
+
+	entry, err := h.historyService.GetHistoryEntry(input.ID)
+	if err != nil {
+		return &OpenHistoryEntryOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &OpenHistoryEntryOutput{
+		Success: true,
+		Entry:   entry,
+	}, nil
+}
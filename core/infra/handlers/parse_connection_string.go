@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// ParseConnectionStringInput represents the input for the ParseConnectionString handler
+type ParseConnectionStringInput struct {
+	ConnectionString string `json:"connectionString"`
+}
+
+// ParseConnectionStringOutput represents the output for the ParseConnectionString handler
+type ParseConnectionStringOutput struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message,omitempty"`
+	Config  *types.DatabaseConfig `json:"config,omitempty"`
+}
+
+// ParseConnectionStringHandler handles connection string parsing requests
+type ParseConnectionStringHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewParseConnectionStringHandler creates a new ParseConnectionStringHandler instance
+func NewParseConnectionStringHandler(connectionService *services.ConnectionService) *ParseConnectionStringHandler {
+	return &ParseConnectionStringHandler{
+		connectionService: connectionService,
+	}
+}
+
+// ParseConnectionString normalizes a URL, Go MySQL DSN or libpq key/value
+// string into connection form fields
+func (h *ParseConnectionStringHandler) ParseConnectionString(input ParseConnectionStringInput) (*ParseConnectionStringOutput, error) {
+	config, err := h.connectionService.ParseConnectionString(input.ConnectionString)
+	if err != nil {
+		return &ParseConnectionStringOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &ParseConnectionStringOutput{
+		Success: true,
+		Message: "Connection string parsed successfully",
+		Config:  config,
+	}, nil
+}
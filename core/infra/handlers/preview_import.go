@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// PreviewImportInput represents the input for the PreviewImport handler
+type PreviewImportInput struct {
+	Sources []types.ImportRequest `json:"sources"`
+}
+
+// PreviewImportOutput represents the output for the PreviewImport handler
+type PreviewImportOutput struct {
+	Success    bool                    `json:"success"`
+	Message    string                  `json:"message,omitempty"`
+	Candidates []types.ImportCandidate `json:"candidates"`
+	Errors     []types.ImportError     `json:"errors"`
+}
+
+// PreviewImportHandler shows what an import would bring in before saving anything
+type PreviewImportHandler struct {
+	importService *services.ImportService
+}
+
+// NewPreviewImportHandler creates a new PreviewImportHandler instance
+func NewPreviewImportHandler(importService *services.ImportService) *PreviewImportHandler {
+	return &PreviewImportHandler{
+		importService: importService,
+	}
+}
+
+// PreviewImport processes the PreviewImport request
+func (h *PreviewImportHandler) PreviewImport(input PreviewImportInput) (*PreviewImportOutput, error) {
+	candidates, importErrors, err := h.importService.PreviewImport(input.Sources)
+	if err != nil {
+		return &PreviewImportOutput{
+			Success:    false,
+			Message:    err.Error(),
+			Candidates: []types.ImportCandidate{},
+			Errors:     []types.ImportError{},
+		}, nil
+	}
+
+	return &PreviewImportOutput{
+		Success:    true,
+		Message:    "Import preview ready",
+		Candidates: candidates,
+		Errors:     importErrors,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// PruneHistoryInput represents the input for the PruneHistory handler
+type PruneHistoryInput struct {
+	// OlderThanDays removes the entries started longer ago
+	OlderThanDays int `json:"olderThanDays,omitempty"`
+	// MaxBytes removes the oldest entries until the history fits
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+// PruneHistoryOutput represents the output for the PruneHistory handler
+type PruneHistoryOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Removed int    `json:"removed"`
+}
+
+// PruneHistoryHandler handles requests to remove old entries of the query history
+type PruneHistoryHandler struct {
+	historyService *services.HistoryService
+}
+
+// NewPruneHistoryHandler creates a new PruneHistoryHandler instance
+func NewPruneHistoryHandler(historyService *services.HistoryService) *PruneHistoryHandler {
+	return &PruneHistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// PruneHistory removes the entries of the history older than an age or past
+// a size
+func (h *PruneHistoryHandler) PruneHistory(input PruneHistoryInput) (*PruneHistoryOutput, error) {
+	removed, err := h.historyService.PruneHistory(types.HistoryPrune{
+		OlderThanDays: input.OlderThanDays,
+		MaxBytes:      input.MaxBytes,
+	})
+	if err != nil {
+		return &PruneHistoryOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &PruneHistoryOutput{
+		Success: true,
+		Message: fmt.Sprintf("%d history entries removed", removed),
+		Removed: removed,
+	}, nil
+}
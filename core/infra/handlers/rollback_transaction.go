@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// RollbackTransactionInput represents the input for the RollbackTransaction handler
+type RollbackTransactionInput struct {
+	ID       string `json:"id"`
+	Database string `json:"database"`
+}
+
+// RollbackTransactionOutput represents the output for the RollbackTransaction handler
+type RollbackTransactionOutput struct {
+	Success     bool                    `json:"success"`
+	Message     string                  `json:"message,omitempty"`
+	Transaction *types.TransactionState `json:"transaction,omitempty"`
+}
+
+// RollbackTransactionHandler handles requests to roll back an open transaction
+type RollbackTransactionHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewRollbackTransactionHandler creates a new RollbackTransactionHandler instance
+func NewRollbackTransactionHandler(connectionService *services.ConnectionService) *RollbackTransactionHandler {
+	return &RollbackTransactionHandler{
+		connectionService: connectionService,
+	}
+}
+
+// RollbackTransaction rolls back the open transaction of the database, discarding
+// its uncommitted changes
+func (h *RollbackTransactionHandler) RollbackTransaction(input RollbackTransactionInput) (*RollbackTransactionOutput, error) {
+	if input.ID == "" {
+		return &RollbackTransactionOutput{
+			Success: false,
+			Message: "Connection ID cannot be empty",
+		}, nil
+	}
+
+	transaction, err := h.connectionService.Rollback(input.ID, input.Database)
+	if err != nil {
+		if errors.Is(err, services.ErrNoTransaction) {
+			return &RollbackTransactionOutput{
+				Success: false,
+				Message: "There is no open transaction to roll back",
+			}, nil
+		}
+		return &RollbackTransactionOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &RollbackTransactionOutput{
+		Success:     true,
+		Message:     "Transaction rolled back",
+		Transaction: transaction,
+	}, nil
+}
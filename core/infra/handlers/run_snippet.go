@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// RunSnippetInput represents the input for the RunSnippet handler
+type RunSnippetInput struct {
+	SnippetID string `json:"snippetId"`
+	// ID is the connection to run the snippet on
+	ID       string `json:"id"`
+	Database string `json:"database"`
+	// Variables are the values of the ${name} variables of the snippet, bound
+	// as text parameters
+	Variables map[string]string `json:"variables,omitempty"`
+	// ExecutionID names the execution, so CancelQuery can stop it
+	ExecutionID string `json:"executionId,omitempty"`
+	// PageSize is the rows of the first page, the rest are read with FetchResultPage
+	PageSize int `json:"pageSize,omitempty"`
+	// ConfirmationToken confirms the destructive statements of the query
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+	// ContinueOnError runs the rest of the script after a statement fails
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// Parameters are the values bound to the placeholders of the query
+	Parameters []types.QueryParameterValue `json:"parameters,omitempty"`
+}
+
+// RunSnippetOutput represents the output for the RunSnippet handler
+type RunSnippetOutput struct {
+	ExecuteQueryOutput
+	// Query is the snippet as it ran, its variables rewritten into :name
+	// placeholders
+	Query string `json:"query,omitempty"`
+}
+
+// RunSnippetHandler handles requests to run a saved query snippet
+type RunSnippetHandler struct {
+	snippetService *services.SnippetService
+}
+
+// NewRunSnippetHandler creates a new RunSnippetHandler instance
+func NewRunSnippetHandler(snippetService *services.SnippetService) *RunSnippetHandler {
+	return &RunSnippetHandler{
+		snippetService: snippetService,
+	}
+}
+
+// RunSnippet runs a snippet like ExecuteQuery, with the values of its
+// variables bound as query parameters
+func (h *RunSnippetHandler) RunSnippet(input RunSnippetInput) (*RunSnippetOutput, error) {
+	if input.SnippetID == "" {
+		return &RunSnippetOutput{
+			ExecuteQueryOutput: ExecuteQueryOutput{
+				Success: false,
+				Message: "Snippet ID cannot be empty",
+			},
+		}, nil
+	}
+
+	query, result, err := h.snippetService.RunSnippet(types.SnippetRun{
+		SnippetID:         input.SnippetID,
+		ConnectionID:      input.ID,
+		Database:          input.Database,
+		Variables:         input.Variables,
+		ExecutionID:       input.ExecutionID,
+		PageSize:          input.PageSize,
+		ConfirmationToken: input.ConfirmationToken,
+		ContinueOnError:   input.ContinueOnError,
+		Parameters:        input.Parameters,
+	})
+
+	return &RunSnippetOutput{
+		ExecuteQueryOutput: *executeQueryOutput(result, err, false),
+		Query:              query,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// SearchHistoryInput represents the input for the SearchHistory handler
+type SearchHistoryInput struct {
+	Text         string `json:"text,omitempty"`
+	ConnectionID string `json:"connectionId,omitempty"`
+	// From and To bound the start time in milliseconds since the epoch
+	From  int64 `json:"from,omitempty"`
+	To    int64 `json:"to,omitempty"`
+	Limit int   `json:"limit,omitempty"`
+}
+
+// SearchHistoryOutput represents the output for the SearchHistory handler
+type SearchHistoryOutput struct {
+	Success bool                 `json:"success"`
+	Message string               `json:"message,omitempty"`
+	Entries []types.HistoryEntry `json:"entries"`
+}
+
+// SearchHistoryHandler handles requests to search the query history
+type SearchHistoryHandler struct {
+	historyService *services.HistoryService
+}
+
+// NewSearchHistoryHandler creates a new SearchHistoryHandler instance
+func NewSearchHistoryHandler(historyService *services.HistoryService) *SearchHistoryHandler {
+	return &SearchHistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// SearchHistory returns the queries that ran, newest first, by text,
+// connection and date
+func (h *SearchHistoryHandler) SearchHistory(input SearchHistoryInput) (*SearchHistoryOutput, error) {
+	entries, err := h.historyService.SearchHistory(types.HistorySearch{
+		Text:         input.Text,
+		ConnectionID: input.ConnectionID,
+		From:         input.From,
+		To:           input.To,
+		Limit:        input.Limit,
+	})
+	if err != nil {
+		return &SearchHistoryOutput{
+			Success: false,
+			Message: err.Error(),
+			Entries: []types.HistoryEntry{},
+		}, nil
+	}
+
+	return &SearchHistoryOutput{
+		Success: true,
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// SearchSnippetsInput represents the input for the SearchSnippets handler
+type SearchSnippetsInput struct {
+	// Text is searched in the name, description, folder and query
+	Text string `json:"text,omitempty"`
+	// ConnectionID keeps the global snippets and those of the connection
+	ConnectionID string `json:"connectionId,omitempty"`
+	// Folder keeps the snippets of the folder and its subfolders
+	Folder string `json:"folder,omitempty"`
+}
+
+// SearchSnippetsOutput represents the output for the SearchSnippets handler
+type SearchSnippetsOutput struct {
+	Success  bool            `json:"success"`
+	Message  string          `json:"message,omitempty"`
+	Snippets []types.Snippet `json:"snippets"`
+}
+
+// SearchSnippetsHandler handles requests to list and search saved query snippets
+type SearchSnippetsHandler struct {
+	snippetService *services.SnippetService
+}
+
+// NewSearchSnippetsHandler creates a new SearchSnippetsHandler instance
+func NewSearchSnippetsHandler(snippetService *services.SnippetService) *SearchSnippetsHandler {
+	return &SearchSnippetsHandler{
+		snippetService: snippetService,
+	}
+}
+
+// SearchSnippets returns the snippets passing the search, every snippet when
+// it is empty
+func (h *SearchSnippetsHandler) SearchSnippets(input SearchSnippetsInput) (*SearchSnippetsOutput, error) {
+	snippets, err := h.snippetService.SearchSnippets(types.SnippetSearch{
+		Text:         input.Text,
+		ConnectionID: input.ConnectionID,
+		Folder:       input.Folder,
+	})
+	if err != nil {
+		return &SearchSnippetsOutput{
+			Success:  false,
+			Message:  err.Error(),
+			Snippets: []types.Snippet{},
+		}, nil
+	}
+
+	return &SearchSnippetsOutput{
+		Success:  true,
+		Snippets: snippets,
+	}, nil
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"seagle/core/services"
+	"seagle/core/services/types"
 )
 
 type SetConfigHandler struct {
@@ -15,7 +16,9 @@ func NewSetConfigHandler(configService *services.ConfigService) *SetConfigHandle
 }
 
 type SetConfigInput struct {
-	OpenAIAPIKey string `json:"openAIAPIKey"`
+	OpenAIAPIKey   string `json:"openAIAPIKey"`
+	MaxResultRows  int    `json:"maxResultRows,omitempty"`
+	MaxResultBytes int64  `json:"maxResultBytes,omitempty"`
 }
 
 type SetConfigOutput struct {
@@ -24,7 +27,11 @@ type SetConfigOutput struct {
 }
 
 func (h *SetConfigHandler) SetConfig(input SetConfigInput) (*SetConfigOutput, error) {
-	err := h.configService.SetConfig(input.OpenAIAPIKey)
+	err := h.configService.SetConfig(types.Config{
+		OpenAIAPIKey:   input.OpenAIAPIKey,
+		MaxResultRows:  input.MaxResultRows,
+		MaxResultBytes: input.MaxResultBytes,
+	})
 	if err != nil {
 		return &SetConfigOutput{
 			Success: false,
@@ -7,14 +7,37 @@ import (
 
 // TestConnectionInput represents the input for the TestConnection handler
 type TestConnectionInput struct {
-	Host                string `json:"host"`
-	Port                int    `json:"port"`
-	Database            string `json:"database"`
-	Username            string `json:"username"`
-	Password            string `json:"password"`
-	SSLMode             string `json:"sslmode"`
-	ConnectionString    string `json:"connectionString"`
-	UseConnectionString bool   `json:"useConnectionString"`
+	Vendor              string                 `json:"vendor"`
+	Host                string                 `json:"host"`
+	Port                int                    `json:"port"`
+	Database            string                 `json:"database"`
+	Username            string                 `json:"username"`
+	Password            string                 `json:"password"`
+	PassCommand         string                 `json:"passCommand,omitempty"`
+	PasswordRef         string                 `json:"passwordRef,omitempty"`
+	PromptPassword      bool                   `json:"promptPassword,omitempty"`
+	ReadOnly            bool                   `json:"readOnly,omitempty"`
+	ManualCommit        bool                   `json:"manualCommit,omitempty"`
+	StatementTimeout    int                    `json:"statementTimeout,omitempty"`
+	SSLMode             string                 `json:"sslmode"`
+	Arguments           map[string]string      `json:"arguments,omitempty"`
+	SSH                 *types.SSHTunnelConfig `json:"ssh,omitempty"`
+	TLS                 *types.TLSConfig       `json:"tls,omitempty"`
+	FailoverHosts       []types.Endpoint       `json:"failoverHosts,omitempty"`
+	Name                string                 `json:"name,omitempty"`
+	Folder              string                 `json:"folder,omitempty"`
+	Tags                []string               `json:"tags,omitempty"`
+	Environment         string                 `json:"environment,omitempty"`
+	Color               string                 `json:"color,omitempty"`
+	ConnectionString    string                 `json:"connectionString"`
+	UseConnectionString bool                   `json:"useConnectionString"`
+}
+
+// TestConnectionOutput represents the output for the TestConnection handler
+type TestConnectionOutput struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message,omitempty"`
+	TLS     *types.TLSStatus `json:"tls,omitempty"`
 }
 
 // TestConnectionHandler handles database connection testing requests
@@ -30,20 +53,48 @@ func NewTestConnectionHandler(connectionService *services.ConnectionService) *Te
 }
 
 // TestConnection processes the test connection request
-func (h *TestConnectionHandler) TestConnection(input TestConnectionInput) error {
-	err := h.connectionService.TestConnection(types.DatabaseConfig{
+func (h *TestConnectionHandler) TestConnection(input TestConnectionInput) (*TestConnectionOutput, error) {
+	tlsStatus, err := h.connectionService.TestConnection(types.DatabaseConfig{
+		Vendor:              input.Vendor,
 		Host:                input.Host,
 		Port:                input.Port,
 		Database:            input.Database,
 		Username:            input.Username,
 		Password:            input.Password,
+		PassCommand:         input.PassCommand,
+		PasswordRef:         input.PasswordRef,
+		PromptPassword:      input.PromptPassword,
+		ReadOnly:            input.ReadOnly,
+		ManualCommit:        input.ManualCommit,
+		StatementTimeout:    input.StatementTimeout,
 		SSLMode:             input.SSLMode,
+		Arguments:           input.Arguments,
+		SSH:                 input.SSH,
+		TLS:                 input.TLS,
+		FailoverHosts:       input.FailoverHosts,
+		Name:                input.Name,
+		Folder:              input.Folder,
+		Tags:                input.Tags,
+		Environment:         input.Environment,
+		Color:               input.Color,
 		ConnectionString:    input.ConnectionString,
 		UseConnectionString: input.UseConnectionString,
 	})
 	if err != nil {
-		return err
+		return &TestConnectionOutput{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	message := "Connection test successful"
+	if tlsStatus.Enabled {
+		message += " over " + tlsStatus.Version
 	}
 
-	return nil
+	return &TestConnectionOutput{
+		Success: true,
+		Message: message,
+		TLS:     tlsStatus,
+	}, nil
 }
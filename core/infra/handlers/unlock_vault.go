@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"seagle/core/services"
+)
+
+// UnlockVaultInput represents the input for the UnlockVault handler
+type UnlockVaultInput struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// UnlockVaultOutput represents the output for the UnlockVault handler
+type UnlockVaultOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// UnlockVaultHandler unlocks the vault with the master passphrase
+type UnlockVaultHandler struct {
+	vaultService *services.VaultService
+}
+
+// NewUnlockVaultHandler creates a new UnlockVaultHandler instance
+func NewUnlockVaultHandler(vaultService *services.VaultService) *UnlockVaultHandler {
+	return &UnlockVaultHandler{
+		vaultService: vaultService,
+	}
+}
+
+// UnlockVault processes the UnlockVault request
+func (h *UnlockVaultHandler) UnlockVault(input UnlockVaultInput) (*UnlockVaultOutput, error) {
+	if err := h.vaultService.Unlock(input.Passphrase); err != nil {
+		return &UnlockVaultOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &UnlockVaultOutput{
+		Success: true,
+		Message: "Vault unlocked successfully",
+	}, nil
+}
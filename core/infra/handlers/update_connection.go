@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// UpdateConnectionInput represents the input for the UpdateConnection handler
+type UpdateConnectionInput struct {
+	ID     string               `json:"id"`
+	Config types.DatabaseConfig `json:"config"`
+	// Test connects with the new config before saving it
+	Test bool `json:"test"`
+}
+
+// UpdateConnectionOutput represents the output for the UpdateConnection handler
+type UpdateConnectionOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	ID      string `json:"id"`
+}
+
+// UpdateConnectionHandler handles saved connection edits
+type UpdateConnectionHandler struct {
+	connectionService *services.ConnectionService
+}
+
+// NewUpdateConnectionHandler creates a new UpdateConnectionHandler instance
+func NewUpdateConnectionHandler(connectionService *services.ConnectionService) *UpdateConnectionHandler {
+	return &UpdateConnectionHandler{
+		connectionService: connectionService,
+	}
+}
+
+// UpdateConnection processes the UpdateConnection request
+func (h *UpdateConnectionHandler) UpdateConnection(input UpdateConnectionInput) (*UpdateConnectionOutput, error) {
+	res, err := h.connectionService.UpdateConnection(input.ID, input.Config, input.Test)
+	if err != nil {
+		return &UpdateConnectionOutput{
+			Success: false,
+			Message: err.Error(),
+			ID:      input.ID,
+		}, nil
+	}
+
+	return &UpdateConnectionOutput{
+		Success: true,
+		Message: "Connection updated successfully",
+		ID:      res.ID,
+	}, nil
+}
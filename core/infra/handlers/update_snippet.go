@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"seagle/core/services"
+	"seagle/core/services/types"
+)
+
+// UpdateSnippetInput represents the input for the UpdateSnippet handler
+type UpdateSnippetInput struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Folder is a path of folder names separated by /
+	Folder string `json:"folder,omitempty"`
+	// ConnectionID scopes the snippet to a connection, empty for a global one
+	ConnectionID string `json:"connectionId,omitempty"`
+	// Query may hold ${name} variables, bound to values when the snippet runs
+	Query string `json:"query"`
+}
+
+// UpdateSnippetOutput represents the output for the UpdateSnippet handler
+type UpdateSnippetOutput struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message,omitempty"`
+	Snippet *types.Snippet `json:"snippet,omitempty"`
+}
+
+// UpdateSnippetHandler handles requests to change a saved query snippet
+type UpdateSnippetHandler struct {
+	snippetService *services.SnippetService
+}
+
+// NewUpdateSnippetHandler creates a new UpdateSnippetHandler instance
+func NewUpdateSnippetHandler(snippetService *services.SnippetService) *UpdateSnippetHandler {
+	return &UpdateSnippetHandler{
+		snippetService: snippetService,
+	}
+}
+
+// UpdateSnippet replaces the fields of a saved snippet
+func (h *UpdateSnippetHandler) UpdateSnippet(input UpdateSnippetInput) (*UpdateSnippetOutput, error) {
+	if input.ID == "" {
+		return &UpdateSnippetOutput{
+			Success: false,
+			Message: "Snippet ID cannot be empty",
+		}, nil
+	}
+
+	snippet, err := h.snippetService.UpdateSnippet(input.ID, types.SnippetInput{
+		Name:         input.Name,
+		Description:  input.Description,
+		Folder:       input.Folder,
+		ConnectionID: input.ConnectionID,
+		Query:        input.Query,
+	})
+	if err != nil {
+		return &UpdateSnippetOutput{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &UpdateSnippetOutput{
+		Success: true,
+		Message: "Snippet saved",
+		Snippet: snippet,
+	}, nil
+}
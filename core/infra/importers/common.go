@@ -0,0 +1,116 @@
+package importers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// homeFile returns a path under the home directory
+func homeFile(parts ...string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(parts...)
+	}
+	return filepath.Join(append([]string{homeDir}, parts...)...)
+}
+
+// origin formats where a connection was found
+func origin(path string, line int) string {
+	return path + ":" + strconv.Itoa(line)
+}
+
+// newConnection creates a connection without ID, using the vendor default
+// port when none is given. The password is kept as written: secret
+// references have a field of their own, which imports never set.
+func newConnection(vendor, host string, port int, database, username, password string, arguments map[string]string) (*domain.Connection, error) {
+	if port == 0 {
+		v, err := domain.LookupVendor(vendor)
+		if err != nil {
+			return nil, err
+		}
+		port = v.DefaultPort
+	}
+	if arguments == nil {
+		arguments = make(map[string]string)
+	}
+	return domain.NewConnection("", vendor, host, port, database, username, password, arguments)
+}
+
+// parsePort parses an optional port
+func parsePort(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port: %s", value)
+	}
+	return port, nil
+}
+
+// iniSection is a [name] section of an INI style file
+type iniSection struct {
+	Name   string
+	Line   int
+	Values map[string]string
+}
+
+// parseINI reads the sections of pg_service.conf and my.cnf style files.
+// Keys are lower cased with dashes turned into underscores, as MySQL option
+// files accept both, and quoted values are unquoted.
+func parseINI(data []byte) []iniSection {
+	var sections []iniSection
+	var current *iniSection
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' || line[0] == '!' {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections = append(sections, iniSection{
+				Name:   strings.TrimSpace(line[1 : len(line)-1]),
+				Line:   lineNo,
+				Values: make(map[string]string),
+			})
+			current = &sections[len(sections)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, "=")
+		key = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		current.Values[key] = value
+	}
+
+	return sections
+}
+
+// connectionFromURL parses a connection URL, accepting the JDBC URLs saved by
+// Java based tools, e.g. jdbc:postgresql://host/db or jdbc:sqlite:/path/app.db
+func connectionFromURL(rawURL string) (*domain.Connection, error) {
+	rawURL = strings.TrimPrefix(strings.TrimSpace(rawURL), "jdbc:")
+
+	if path, ok := strings.CutPrefix(rawURL, "sqlite:"); ok && !strings.HasPrefix(path, "//") {
+		return newConnection("sqlite", path, 0, "", "", "", nil)
+	}
+
+	return domain.NewConnectionFromString("", rawURL)
+}
@@ -0,0 +1,110 @@
+package importers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"seagle/core/domain"
+)
+
+// DataGrip reads the dataSources.xml file of a DataGrip or IntelliJ project.
+// User names live in the dataSources.local.xml file next to it, which is
+// read when present. Passwords are kept in the OS keychain and not imported.
+type DataGrip struct{}
+
+// NewDataGrip creates a new DataGrip source
+func NewDataGrip() *DataGrip {
+	return &DataGrip{}
+}
+
+type dataGripProject struct {
+	Components []struct {
+		DataSources []dataGripDataSource `xml:"data-source"`
+	} `xml:"component"`
+}
+
+type dataGripDataSource struct {
+	Name     string `xml:"name,attr"`
+	UUID     string `xml:"uuid,attr"`
+	Group    string `xml:"group,attr"`
+	JDBCURL  string `xml:"jdbc-url"`
+	UserName string `xml:"user-name"`
+}
+
+func (s *DataGrip) Kind() string {
+	return "datagrip"
+}
+
+func (s *DataGrip) Label() string {
+	return "DataGrip"
+}
+
+func (s *DataGrip) DefaultPath() string {
+	return filepath.Join(".idea", "dataSources.xml")
+}
+
+func (s *DataGrip) Read(path string) ([]domain.ImportedConnection, error) {
+	dataSources, err := readDataGripFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]string)
+	localPath := filepath.Join(filepath.Dir(path), "dataSources.local.xml")
+	if _, err := os.Stat(localPath); err == nil {
+		localSources, err := readDataGripFile(localPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range localSources {
+			users[source.UUID] = source.UserName
+		}
+	}
+
+	var imported []domain.ImportedConnection
+	for _, source := range dataSources {
+		if source.JDBCURL == "" {
+			continue
+		}
+
+		conn, err := connectionFromURL(source.JDBCURL)
+		if err != nil {
+			// Engines seagle does not support
+			continue
+		}
+
+		if user := users[source.UUID]; user != "" && conn.Username() == "" {
+			conn.SetCredentials(user, conn.Password())
+		}
+
+		if err := conn.SetProfile(domain.ConnectionProfile{
+			Name:   source.Name,
+			Folder: source.Group,
+		}); err != nil {
+			return nil, err
+		}
+		imported = append(imported, domain.ImportedConnection{Connection: conn, Origin: path + "#" + source.UUID})
+	}
+
+	return imported, nil
+}
+
+func readDataGripFile(path string) ([]dataGripDataSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var project dataGripProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var dataSources []dataGripDataSource
+	for _, component := range project.Components {
+		dataSources = append(dataSources, component.DataSources...)
+	}
+	return dataSources, nil
+}
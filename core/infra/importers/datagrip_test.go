@@ -0,0 +1,56 @@
+package importers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDataGripRead(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "dataSources.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="DataSourceManagerImpl" format="xml" multifile-model="true">
+    <data-source source="LOCAL" name="app@db" uuid="1111" group="Work">
+      <driver-ref>postgresql</driver-ref>
+      <jdbc-url>jdbc:postgresql://db.example.com:6432/app</jdbc-url>
+    </data-source>
+    <data-source source="LOCAL" name="local" uuid="2222">
+      <jdbc-url>jdbc:sqlite:/data/app.db</jdbc-url>
+    </data-source>
+    <data-source source="LOCAL" name="oracle" uuid="3333">
+      <jdbc-url>jdbc:oracle:thin:@ora:1521:orcl</jdbc-url>
+    </data-source>
+    <data-source source="LOCAL" name="no url" uuid="4444" />
+  </component>
+</project>`)
+	writeFile(t, dir, "dataSources.local.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="dataSourceStorageLocal">
+    <data-source name="app@db" uuid="1111">
+      <user-name>admin</user-name>
+    </data-source>
+  </component>
+</project>`)
+
+	got, err := NewDataGrip().Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []imported{
+		{Vendor: "postgresql", Host: "db.example.com", Port: 6432, Database: "app", Username: "admin", Name: "app@db", Origin: path + "#1111"},
+		{Vendor: "sqlite", Host: "/data/app.db", Database: "main", Name: "local", Origin: path + "#2222"},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Fatalf("Read() = %+v, want %+v", s, want)
+	}
+	if folder := got[0].Connection.Profile().Folder; folder != "Work" {
+		t.Errorf("Profile().Folder = %q, want Work", folder)
+	}
+}
+
+func TestDataGripReadInvalid(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "dataSources.xml", "<project><component>")
+	if _, err := NewDataGrip().Read(path); err == nil {
+		t.Error("Read() succeeded")
+	}
+}
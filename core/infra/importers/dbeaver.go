@@ -0,0 +1,129 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// dbeaverEnvironments maps DBeaver connection types to environment labels
+var dbeaverEnvironments = map[string]string{
+	"dev":  domain.EnvironmentDevelopment,
+	"test": domain.EnvironmentStaging,
+	"prod": domain.EnvironmentProduction,
+}
+
+// DBeaver reads the data-sources.json file of a DBeaver workspace. DBeaver
+// keeps passwords encrypted in a separate file, so they are not imported.
+type DBeaver struct{}
+
+// NewDBeaver creates a new DBeaver source
+func NewDBeaver() *DBeaver {
+	return &DBeaver{}
+}
+
+type dbeaverDataSources struct {
+	Connections map[string]dbeaverConnection `json:"connections"`
+}
+
+type dbeaverConnection struct {
+	Provider      string `json:"provider"`
+	Driver        string `json:"driver"`
+	Name          string `json:"name"`
+	Folder        string `json:"folder"`
+	Configuration struct {
+		Host     string `json:"host"`
+		Port     string `json:"port"`
+		Database string `json:"database"`
+		URL      string `json:"url"`
+		User     string `json:"user"`
+		Password string `json:"password"`
+		Type     string `json:"type"`
+	} `json:"configuration"`
+}
+
+func (s *DBeaver) Kind() string {
+	return "dbeaver"
+}
+
+func (s *DBeaver) Label() string {
+	return "DBeaver"
+}
+
+func (s *DBeaver) DefaultPath() string {
+	workspace := filepath.Join("DBeaverData", "workspace6", "General", ".dbeaver", "data-sources.json")
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("APPDATA"), workspace)
+	case "darwin":
+		return homeFile("Library", workspace)
+	default:
+		return homeFile(".local", "share", workspace)
+	}
+}
+
+func (s *DBeaver) Read(path string) ([]domain.ImportedConnection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var sources dbeaverDataSources
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	ids := make([]string, 0, len(sources.Connections))
+	for id := range sources.Connections {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var imported []domain.ImportedConnection
+	for _, id := range ids {
+		source := sources.Connections[id]
+		cfg := source.Configuration
+
+		var conn *domain.Connection
+		switch {
+		case source.Provider == "postgresql" || source.Provider == "mysql":
+			port, err := parsePort(cfg.Port)
+			if err != nil {
+				return nil, fmt.Errorf("%s: connection %s: %w", path, id, err)
+			}
+			conn, err = newConnection(source.Provider, cfg.Host, port, cfg.Database, cfg.User, cfg.Password, nil)
+			if err != nil {
+				return nil, fmt.Errorf("%s: connection %s: %w", path, id, err)
+			}
+		case strings.Contains(source.Driver, "sqlite"):
+			file := cfg.Database
+			if file == "" {
+				file = strings.TrimPrefix(cfg.URL, "jdbc:sqlite:")
+			}
+			conn, err = newConnection("sqlite", file, 0, "", "", "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("%s: connection %s: %w", path, id, err)
+			}
+		default:
+			// Engines seagle does not support
+			continue
+		}
+
+		if err := conn.SetProfile(domain.ConnectionProfile{
+			Name:        source.Name,
+			Folder:      source.Folder,
+			Environment: dbeaverEnvironments[cfg.Type],
+		}); err != nil {
+			return nil, err
+		}
+		imported = append(imported, domain.ImportedConnection{Connection: conn, Origin: path + "#" + id})
+	}
+
+	return imported, nil
+}
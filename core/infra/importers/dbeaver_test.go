@@ -0,0 +1,81 @@
+package importers
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestDBeaverRead(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "data-sources.json", `{
+	"folders": {},
+	"connections": {
+		"postgres-jdbc-1": {
+			"provider": "postgresql",
+			"driver": "postgres-jdbc",
+			"name": "Production",
+			"folder": "Clients",
+			"configuration": {
+				"host": "db.example.com",
+				"port": "6432",
+				"database": "app",
+				"user": "admin",
+				"type": "prod"
+			}
+		},
+		"mysql8-1": {
+			"provider": "mysql",
+			"driver": "mysql8",
+			"name": "Shop",
+			"configuration": {"host": "localhost", "database": "shop", "user": "root", "type": "dev"}
+		},
+		"sqlite-jdbc-1": {
+			"provider": "generic",
+			"driver": "sqlite_jdbc",
+			"name": "Local",
+			"configuration": {"url": "jdbc:sqlite:/data/app.db"}
+		},
+		"oracle-1": {
+			"provider": "oracle",
+			"driver": "oracle_thin",
+			"name": "Unsupported",
+			"configuration": {"host": "ora"}
+		}
+	}
+}`)
+
+	got, err := NewDBeaver().Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []imported{
+		{Vendor: "mysql", Host: "localhost", Port: 3306, Database: "shop", Username: "root", Name: "Shop", Origin: path + "#mysql8-1"},
+		{Vendor: "postgresql", Host: "db.example.com", Port: 6432, Database: "app", Username: "admin", Name: "Production", Origin: path + "#postgres-jdbc-1"},
+		{Vendor: "sqlite", Host: "/data/app.db", Database: "main", Name: "Local", Origin: path + "#sqlite-jdbc-1"},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Fatalf("Read() = %+v, want %+v", s, want)
+	}
+
+	profile := got[1].Connection.Profile()
+	if profile.Folder != "Clients" || profile.Environment != domain.EnvironmentProduction {
+		t.Errorf("Profile() = %+v", profile)
+	}
+}
+
+func TestDBeaverReadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid JSON": "{",
+		"invalid port": `{"connections": {"a": {"provider": "mysql", "configuration": {"host": "db", "port": "abc"}}}}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "data-sources.json", content)
+			if _, err := NewDBeaver().Read(path); err == nil {
+				t.Error("Read() succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package importers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// DotEnv reads DATABASE_URL entries of a .env file, including prefixed ones
+// like ANALYTICS_DATABASE_URL, which also name the connection. URLs of other
+// databases, like CACHE_DATABASE_URL=redis://..., are skipped and reported.
+type DotEnv struct{}
+
+// NewDotEnv creates a new DotEnv source
+func NewDotEnv() *DotEnv {
+	return &DotEnv{}
+}
+
+func (s *DotEnv) Kind() string {
+	return "dotenv"
+}
+
+func (s *DotEnv) Label() string {
+	return ".env file"
+}
+
+func (s *DotEnv) DefaultPath() string {
+	return ".env"
+}
+
+func (s *DotEnv) Read(path string) ([]domain.ImportedConnection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var imported []domain.ImportedConnection
+	var skipped []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || (key != "DATABASE_URL" && !strings.HasSuffix(key, "_DATABASE_URL")) {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		conn, err := connectionFromURL(value)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", origin(path, lineNo), err))
+			continue
+		}
+		if err := conn.SetProfile(domain.ConnectionProfile{Name: key}); err != nil {
+			return nil, err
+		}
+		imported = append(imported, domain.ImportedConnection{Connection: conn, Origin: origin(path, lineNo)})
+	}
+
+	if len(skipped) > 0 {
+		return imported, &domain.SkippedEntries{Reasons: skipped}
+	}
+	return imported, nil
+}
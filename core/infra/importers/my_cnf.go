@@ -0,0 +1,68 @@
+package importers
+
+import (
+	"fmt"
+	"os"
+
+	"seagle/core/domain"
+)
+
+// MyCnf reads the client sections of a MySQL option file. Every section with
+// a user becomes a connection, reaching localhost when it has no host.
+type MyCnf struct{}
+
+// NewMyCnf creates a new MyCnf source
+func NewMyCnf() *MyCnf {
+	return &MyCnf{}
+}
+
+func (s *MyCnf) Kind() string {
+	return "my_cnf"
+}
+
+func (s *MyCnf) Label() string {
+	return "MySQL option file"
+}
+
+func (s *MyCnf) DefaultPath() string {
+	return homeFile(".my.cnf")
+}
+
+func (s *MyCnf) Read(path string) ([]domain.ImportedConnection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var imported []domain.ImportedConnection
+	for _, section := range parseINI(data) {
+		values := section.Values
+		if values["user"] == "" {
+			continue
+		}
+
+		host := values["host"]
+		if values["socket"] != "" && (host == "" || host == "localhost") {
+			host = values["socket"]
+		}
+		if host == "" {
+			host = "localhost"
+		}
+
+		port, err := parsePort(values["port"])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, section.Line), err)
+		}
+
+		conn, err := newConnection("mysql", host, port, values["database"], values["user"], values["password"], nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, section.Line), err)
+		}
+		if err := conn.SetProfile(domain.ConnectionProfile{Name: "my.cnf [" + section.Name + "]"}); err != nil {
+			return nil, err
+		}
+		imported = append(imported, domain.ImportedConnection{Connection: conn, Origin: origin(path, section.Line)})
+	}
+
+	return imported, nil
+}
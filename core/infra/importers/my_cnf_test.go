@@ -0,0 +1,47 @@
+package importers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMyCnfRead(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, ".my.cnf", `[client]
+user=admin
+password="s3cret"
+host=db.example.com
+port=3307
+database=shop
+
+[mysqldump]
+user=backup
+socket=/var/run/mysqld/mysqld.sock
+
+[mysqld]
+datadir=/var/lib/mysql
+
+[mysql]
+user=local
+`)
+
+	got, err := NewMyCnf().Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []imported{
+		{Vendor: "mysql", Host: "db.example.com", Port: 3307, Database: "shop", Username: "admin", Password: "s3cret", Name: "my.cnf [client]", Origin: origin(path, 1)},
+		{Vendor: "mysql", Host: "/var/run/mysqld/mysqld.sock", Port: 3306, Username: "backup", Name: "my.cnf [mysqldump]", Origin: origin(path, 8)},
+		{Vendor: "mysql", Host: "localhost", Port: 3306, Username: "local", Name: "my.cnf [mysql]", Origin: origin(path, 15)},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Errorf("Read() = %+v, want %+v", s, want)
+	}
+}
+
+func TestMyCnfReadInvalidPort(t *testing.T) {
+	path := writeFile(t, t.TempDir(), ".my.cnf", "[client]\nuser=admin\nport=abc\n")
+	if _, err := NewMyCnf().Read(path); err == nil {
+		t.Error("Read() succeeded")
+	}
+}
@@ -0,0 +1,112 @@
+package importers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"seagle/core/domain"
+)
+
+// PgService reads the [service] sections of a PostgreSQL connection service
+// file. The ssl keys become the TLS settings and the keys seagle has an
+// argument for are kept as driver arguments, other libpq keys are dropped.
+type PgService struct{}
+
+// NewPgService creates a new PgService source
+func NewPgService() *PgService {
+	return &PgService{}
+}
+
+func (s *PgService) Kind() string {
+	return "pg_service"
+}
+
+func (s *PgService) Label() string {
+	return "PostgreSQL service file"
+}
+
+func (s *PgService) DefaultPath() string {
+	if path := os.Getenv("PGSERVICEFILE"); path != "" {
+		return path
+	}
+	if dir := os.Getenv("PGSYSCONFDIR"); dir != "" {
+		return filepath.Join(dir, "pg_service.conf")
+	}
+	return homeFile(".pg_service.conf")
+}
+
+func (s *PgService) Read(path string) ([]domain.ImportedConnection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var imported []domain.ImportedConnection
+	for _, section := range parseINI(data) {
+		values := section.Values
+		if values["host"] == "" {
+			continue
+		}
+
+		port, err := parsePort(values["port"])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, section.Line), err)
+		}
+
+		arguments := make(map[string]string)
+		for key, value := range values {
+			switch key {
+			case "host", "port", "dbname", "user", "password":
+			default:
+				arguments[key] = value
+			}
+		}
+		tlsConfig, err := serviceArguments(arguments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, section.Line), err)
+		}
+
+		conn, err := newConnection("postgresql", values["host"], port, values["dbname"], values["user"], values["password"], arguments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, section.Line), err)
+		}
+		if err := conn.SetTLS(tlsConfig); err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, section.Line), err)
+		}
+		if err := conn.SetProfile(domain.ConnectionProfile{Name: section.Name}); err != nil {
+			return nil, err
+		}
+		imported = append(imported, domain.ImportedConnection{Connection: conn, Origin: origin(path, section.Line)})
+	}
+
+	return imported, nil
+}
+
+// serviceArguments takes the TLS settings out of the keys of a service and
+// drops the keys PostgreSQL connections have no argument for
+func serviceArguments(arguments map[string]string) (*domain.TLSConfig, error) {
+	v, err := domain.LookupVendor("postgresql")
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := v.TLSArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+	for key := range arguments {
+		if _, ok := v.Argument(key); !ok {
+			delete(arguments, key)
+		}
+	}
+
+	sslMode := ""
+	if tlsConfig != nil {
+		sslMode = tlsConfig.Mode
+	}
+	if err := v.ValidateArguments(sslMode, arguments); err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
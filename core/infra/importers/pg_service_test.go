@@ -0,0 +1,66 @@
+package importers
+
+import (
+	"reflect"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func TestPgServiceRead(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "pg_service.conf", `[main]
+host=db.example.com
+port=6432
+dbname=app
+user=admin
+password=s3cret
+sslmode=verify-full
+sslrootcert=/etc/ssl/ca.pem
+connect_timeout=10
+keepalives=1
+target_session_attrs=read-write
+
+[nohost]
+dbname=app
+`)
+
+	got, err := NewPgService().Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []imported{
+		{Vendor: "postgresql", Host: "db.example.com", Port: 6432, Database: "app", Username: "admin", Password: "s3cret", Name: "main", Origin: origin(path, 1)},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Fatalf("Read() = %+v, want %+v", s, want)
+	}
+
+	conn := got[0].Connection
+	wantTLS := &domain.TLSConfig{Mode: "verify-full", CAFile: "/etc/ssl/ca.pem"}
+	if !reflect.DeepEqual(conn.TLS(), wantTLS) {
+		t.Errorf("TLS() = %+v, want %+v", conn.TLS(), wantTLS)
+	}
+	// libpq keys without a seagle argument are dropped
+	wantArguments := map[string]string{"connect_timeout": "10"}
+	if !reflect.DeepEqual(conn.Arguments(), wantArguments) {
+		t.Errorf("Arguments() = %v, want %v", conn.Arguments(), wantArguments)
+	}
+}
+
+func TestPgServiceReadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid port":         "[s]\nhost=db\nport=abc\n",
+		"invalid SSL mode":     "[s]\nhost=db\nsslmode=sometimes\n",
+		"invalid argument":     "[s]\nhost=db\nconnect_timeout=soon\n",
+		"certificate, no mode": "[s]\nhost=db\nsslrootcert=/etc/ssl/ca.pem\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "pg_service.conf", content)
+			if _, err := NewPgService().Read(path); err == nil {
+				t.Error("Read() succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package importers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"seagle/core/domain"
+)
+
+// PgPass reads the hostname:port:database:username:password lines of the
+// PostgreSQL password file. Entries with a wildcard host cannot be reached
+// and are skipped, a wildcard port or database falls back to the defaults.
+// Lines libpq could never match, with a wrong number of fields or a port
+// that is not a number, are skipped as libpq skips them.
+type PgPass struct{}
+
+// NewPgPass creates a new PgPass source
+func NewPgPass() *PgPass {
+	return &PgPass{}
+}
+
+func (s *PgPass) Kind() string {
+	return "pgpass"
+}
+
+func (s *PgPass) Label() string {
+	return "PostgreSQL password file"
+}
+
+func (s *PgPass) DefaultPath() string {
+	if path := os.Getenv("PGPASSFILE"); path != "" {
+		return path
+	}
+	return homeFile(".pgpass")
+}
+
+func (s *PgPass) Read(path string) ([]domain.ImportedConnection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var imported []domain.ImportedConnection
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		fields := splitPgPassLine(line)
+		if len(fields) != 5 || fields[0] == "*" {
+			continue
+		}
+
+		for i := 1; i < 4; i++ {
+			if fields[i] == "*" {
+				fields[i] = ""
+			}
+		}
+
+		port, err := parsePort(fields[1])
+		if err != nil {
+			continue
+		}
+
+		conn, err := newConnection("postgresql", fields[0], port, fields[2], fields[3], fields[4], nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", origin(path, lineNo), err)
+		}
+		imported = append(imported, domain.ImportedConnection{Connection: conn, Origin: origin(path, lineNo)})
+	}
+
+	return imported, nil
+}
+
+// splitPgPassLine splits on colons, where \: and \\ stand for a literal
+// colon and backslash
+func splitPgPassLine(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+	return append(fields, field.String())
+}
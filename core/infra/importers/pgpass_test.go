@@ -0,0 +1,35 @@
+package importers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPgPassRead(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, ".pgpass", `# host:port:database:username:password
+db.example.com:6432:app:admin:s3cret
+localhost:*:*:postgres:with\:colon\\
+*:*:*:anyone:skipped
+malformed:line
+db.example.com:not-a-port:app:admin:skipped
+`)
+
+	got, err := NewPgPass().Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []imported{
+		{Vendor: "postgresql", Host: "db.example.com", Port: 6432, Database: "app", Username: "admin", Password: "s3cret", Origin: origin(path, 2)},
+		{Vendor: "postgresql", Host: "localhost", Port: 5432, Username: "postgres", Password: `with:colon\`, Origin: origin(path, 3)},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Errorf("Read() = %+v, want %+v", s, want)
+	}
+}
+
+func TestPgPassReadMissingFile(t *testing.T) {
+	if _, err := NewPgPass().Read(t.TempDir() + "/.pgpass"); err == nil {
+		t.Error("Read() of a missing file succeeded")
+	}
+}
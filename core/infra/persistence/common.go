@@ -5,19 +5,46 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"seagle/core/domain"
 )
 
 func saveDataToFile(filename string, data map[string]interface{}) error {
-	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writePrivateFile(filename, jsonData)
+}
+
+// writePrivateFile replaces the file with data readable only by the current
+// user. The data is written to a temporary file first so a failed write
+// never leaves a truncated file behind.
+func writePrivateFile(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+"-*")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
 
-	return os.WriteFile(filename, jsonData, 0644)
+	return os.Rename(tmp.Name(), filename)
 }
 
 func loadDataFromFile(filename string) (map[string]interface{}, error) {
@@ -42,3 +69,57 @@ func loadDataFromFile(filename string) (map[string]interface{}, error) {
 
 	return data, nil
 }
+
+// sealSecret stores the secret found under key in the vault and leaves its
+// reference in place. A reference to the secret another owner stored for the
+// same field, as duplicated connections hold, has the secret copied so each
+// owner keeps its own; sibling tells the vault names of those secrets, nil
+// when the field has a single owner. Any other value is the secret itself,
+// sealed as given even when it starts with the reference prefix. An empty
+// value drops the stored secret.
+//
+// Without a vault, or while it is locked, the secret is kept in plaintext as
+// before the vault existed; creating or unlocking the vault seals it.
+func sealSecret(vault domain.Vault, data map[string]interface{}, key, name string, sibling func(name string) bool) error {
+	value, _ := data[key].(string)
+	switch {
+	case value == "":
+		return vault.Delete(name)
+	case value == domain.VaultRef(name):
+		return nil
+	}
+
+	ref, isRef := strings.CutPrefix(value, domain.VaultRefPrefix)
+	if !vault.Initialized() || vault.Locked() {
+		if isRef {
+			// Kept as is, it would read as a reference
+			return fmt.Errorf("failed to store secret: %w", vaultUnavailable(vault))
+		}
+		// A secret sealed before is replaced by the plaintext one
+		return vault.Delete(name)
+	}
+
+	secret := value
+	if isRef && sibling != nil && sibling(ref) {
+		var err error
+		if secret, err = vault.Resolve(value); err != nil {
+			return fmt.Errorf("failed to store secret: %w", err)
+		}
+	}
+
+	ref, err := vault.Put(name, secret)
+	if err != nil {
+		return fmt.Errorf("failed to store secret: %w", err)
+	}
+
+	data[key] = ref
+	return nil
+}
+
+// vaultUnavailable returns why secrets cannot be sealed in the vault
+func vaultUnavailable(vault domain.Vault) error {
+	if !vault.Initialized() {
+		return domain.ErrVaultNotInitialized
+	}
+	return domain.ErrVaultLocked
+}
@@ -2,18 +2,28 @@ package persistence
 
 import "seagle/core/domain"
 
+// openAIAPIKeySecret is the vault name of the OpenAI API key
+const openAIAPIKeySecret = "config/openai-api-key"
+
+// ConfigRepo stores the configuration in a JSON file, with the OpenAI API
+// key kept in the vault
 type ConfigRepo struct {
 	filename string
+	vault    domain.Vault
 }
 
-func NewConfigRepo(filename string) *ConfigRepo {
+func NewConfigRepo(filename string, vault domain.Vault) *ConfigRepo {
 	return &ConfigRepo{
 		filename: filename,
+		vault:    vault,
 	}
 }
 
 func (r *ConfigRepo) Save(cfg *domain.Config) error {
 	data := cfg.ToMap()
+	if err := sealSecret(r.vault, data, "openAIAPIKey", openAIAPIKeySecret, nil); err != nil {
+		return err
+	}
 	return saveDataToFile(r.filename, data)
 }
 
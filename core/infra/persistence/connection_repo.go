@@ -1,18 +1,25 @@
 package persistence
 
 import (
+	"fmt"
+	"strings"
+
 	"seagle/core/domain"
 
 	"github.com/google/uuid"
 )
 
+// ConnectionRepo stores connections in a JSON file. Passwords and SSH secrets
+// are kept in the vault and the file holds references to them.
 type ConnectionRepo struct {
 	filename string
+	vault    domain.Vault
 }
 
-func NewConnection(filename string) *ConnectionRepo {
+func NewConnection(filename string, vault domain.Vault) *ConnectionRepo {
 	return &ConnectionRepo{
 		filename: filename,
+		vault:    vault,
 	}
 }
 
@@ -39,7 +46,12 @@ func (r *ConnectionRepo) Save(connection *domain.Connection) error {
 		connections = append(connections, connection)
 	}
 
-	return saveDataToFile(r.filename, r.toDataMap(connections))
+	data, err := r.toDataMap(connections)
+	if err != nil {
+		return err
+	}
+
+	return saveDataToFile(r.filename, data)
 }
 
 func (r *ConnectionRepo) List() ([]*domain.Connection, error) {
@@ -74,7 +86,22 @@ func (r *ConnectionRepo) DeleteByID(id string) error {
 		}
 	}
 
-	return saveDataToFile(r.filename, r.toDataMap(updatedConnections))
+	data, err := r.toDataMap(updatedConnections)
+	if err != nil {
+		return err
+	}
+
+	if err := saveDataToFile(r.filename, data); err != nil {
+		return err
+	}
+
+	for _, name := range connectionSecretNames(id) {
+		if err := r.vault.Delete(name); err != nil {
+			return fmt.Errorf("failed to delete connection secrets: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func (r *ConnectionRepo) load() ([]*domain.Connection, error) {
@@ -95,12 +122,58 @@ func (r *ConnectionRepo) load() ([]*domain.Connection, error) {
 	return connections, nil
 }
 
-func (r *ConnectionRepo) toDataMap(connections []*domain.Connection) map[string]interface{} {
+func (r *ConnectionRepo) toDataMap(connections []*domain.Connection) (map[string]interface{}, error) {
 	connMaps := make([]map[string]interface{}, len(connections))
 	for i, conn := range connections {
-		connMaps[i] = conn.Map()
+		connMap := conn.Map()
+		if err := r.sealSecrets(conn.ID(), connMap); err != nil {
+			return nil, err
+		}
+		connMaps[i] = connMap
 	}
 	return map[string]interface{}{
 		"connections": connMaps,
+	}, nil
+}
+
+// sealSecrets moves the password and SSH secrets of a persisted connection
+// into the vault, leaving references in their place
+func (r *ConnectionRepo) sealSecrets(id string, connMap map[string]interface{}) error {
+	names := connectionSecretNames(id)
+
+	if err := sealSecret(r.vault, connMap, "password", names[0], connectionSecret(0)); err != nil {
+		return err
+	}
+
+	ssh, _ := connMap["ssh"].(map[string]interface{})
+	if ssh == nil {
+		ssh = map[string]interface{}{}
+	}
+	if err := sealSecret(r.vault, ssh, "password", names[1], connectionSecret(1)); err != nil {
+		return err
+	}
+	return sealSecret(r.vault, ssh, "passphrase", names[2], connectionSecret(2))
+}
+
+// connectionSecretPrefix starts the vault names of connection secrets
+const connectionSecretPrefix = "connections/"
+
+// connectionSecretNames returns the vault names of the password, SSH password
+// and SSH key passphrase of a connection
+func connectionSecretNames(id string) []string {
+	prefix := connectionSecretPrefix + id + "/"
+	return []string{prefix + "password", prefix + "ssh-password", prefix + "ssh-passphrase"}
+}
+
+// connectionSecret returns whether a vault name is the one a connection stores
+// its secret at index i of connectionSecretNames under
+func connectionSecret(i int) func(name string) bool {
+	return func(name string) bool {
+		id, ok := strings.CutPrefix(name, connectionSecretPrefix)
+		if !ok {
+			return false
+		}
+		id, _, _ = strings.Cut(id, "/")
+		return id != "" && name == connectionSecretNames(id)[i]
 	}
 }
@@ -0,0 +1,179 @@
+package persistence
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"seagle/core/domain"
+	_ "seagle/core/domain/vendors"
+)
+
+func newTestConnectionRepo(t *testing.T) (*ConnectionRepo, *Vault) {
+	t.Helper()
+	v := newTestVault(t)
+	return NewConnection(filepath.Join(t.TempDir(), "connections.json"), v), v
+}
+
+func saveTestConnection(t *testing.T, repo *ConnectionRepo, id, password string) *domain.Connection {
+	t.Helper()
+	conn, err := domain.NewConnection(id, "postgresql", "localhost", 5432, "app", "admin", password, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return conn
+}
+
+// storedPassword returns the password the repository reads back and the
+// secret it resolves to
+func storedPassword(t *testing.T, repo *ConnectionRepo, v *Vault, id string) (string, string) {
+	t.Helper()
+	conn, err := repo.FindByID(id)
+	if err != nil || conn == nil {
+		t.Fatalf("FindByID() = %v, %v", conn, err)
+	}
+	secret, err := v.Resolve(conn.Password())
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	return conn.Password(), secret
+}
+
+// readPassword returns the password the repository reads back, unresolved
+func readPassword(t *testing.T, repo *ConnectionRepo, id string) string {
+	t.Helper()
+	conn, err := repo.FindByID(id)
+	if err != nil || conn == nil {
+		t.Fatalf("FindByID() = %v, %v", conn, err)
+	}
+	return conn.Password()
+}
+
+func TestConnectionRepoSealsPlaintextSecrets(t *testing.T) {
+	repo, v := newTestConnectionRepo(t)
+
+	// A file written before the vault existed holds the password itself
+	plaintext := `{"connections": [{"id": "a", "vendor": "postgresql", "host": "localhost", "port": 5432, "database": "app", "username": "admin", "password": "s3cret"}]}`
+	if err := os.WriteFile(repo.filename, []byte(plaintext), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	conns, err := repo.List()
+	if err != nil || len(conns) != 1 {
+		t.Fatalf("List() = %v, %v", conns, err)
+	}
+	if err := repo.Save(conns[0]); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(repo.filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "s3cret") {
+		t.Errorf("connections file still holds the password: %s", data)
+	}
+	ref, secret := storedPassword(t, repo, v, "a")
+	if ref != domain.VaultRef("connections/a/password") || secret != "s3cret" {
+		t.Errorf("password = %q resolving to %q", ref, secret)
+	}
+}
+
+func TestConnectionRepoKeepsLiteralPasswords(t *testing.T) {
+	repo, v := newTestConnectionRepo(t)
+
+	// Passwords looking like references are secrets like any other, unless
+	// they reference the password of another connection
+	for _, tt := range []struct{ id, password string }{
+		{"a", "vault:hunter2"},
+		{"b", "vault:connections/a/ssh-password"},
+		{"c", "vault:config/openai-api-key"},
+	} {
+		saveTestConnection(t, repo, tt.id, tt.password)
+		if _, secret := storedPassword(t, repo, v, tt.id); secret != tt.password {
+			t.Errorf("password of %s = %q, want %q", tt.id, secret, tt.password)
+		}
+	}
+
+	// Saving again leaves the stored secret unchanged
+	conn, _ := repo.FindByID("a")
+	if err := repo.Save(conn); err != nil {
+		t.Fatal(err)
+	}
+	if _, secret := storedPassword(t, repo, v, "a"); secret != "vault:hunter2" {
+		t.Errorf("password after saving again = %q", secret)
+	}
+}
+
+func TestConnectionRepoCopiesDuplicatedSecrets(t *testing.T) {
+	repo, v := newTestConnectionRepo(t)
+	saveTestConnection(t, repo, "a", "s3cret")
+
+	source, _ := repo.FindByID("a")
+	if err := repo.Save(source.Duplicate("b")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	ref, secret := storedPassword(t, repo, v, "b")
+	if ref != domain.VaultRef("connections/b/password") || secret != "s3cret" {
+		t.Errorf("password of the duplicate = %q resolving to %q", ref, secret)
+	}
+
+	// Each connection keeps its own copy
+	if err := repo.DeleteByID("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, secret := storedPassword(t, repo, v, "b"); secret != "s3cret" {
+		t.Errorf("password of the duplicate after deleting the source = %q", secret)
+	}
+}
+
+func TestConnectionRepoKeepsPlaintextWithoutVault(t *testing.T) {
+	v := NewVault(filepath.Join(t.TempDir(), "vault.json"), 0)
+	repo := NewConnection(filepath.Join(t.TempDir(), "connections.json"), v)
+
+	saveTestConnection(t, repo, "a", "s3cret")
+	if password := readPassword(t, repo, "a"); password != "s3cret" {
+		t.Errorf("password without a vault = %q, want it in plaintext", password)
+	}
+
+	// Kept as is, it would read as a reference
+	conn, err := domain.NewConnection("b", "postgresql", "localhost", 5432, "app", "admin", "vault:s3cret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(conn); !errors.Is(err, domain.ErrVaultNotInitialized) {
+		t.Errorf("Save() of a password starting with vault: error = %v, want ErrVaultNotInitialized", err)
+	}
+}
+
+func TestConnectionRepoKeepsPlaintextWhileLocked(t *testing.T) {
+	repo, v := newTestConnectionRepo(t)
+	saveTestConnection(t, repo, "a", "s3cret")
+
+	// The unchanged password stays sealed, a new one waits for the unlock
+	v.Lock()
+	conn, err := repo.FindByID("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(conn); err != nil {
+		t.Fatalf("Save() of the unchanged connection while locked error = %v", err)
+	}
+	if password := readPassword(t, repo, "a"); password != domain.VaultRef("connections/a/password") {
+		t.Errorf("unchanged password while locked = %q, want the vault reference", password)
+	}
+
+	saveTestConnection(t, repo, "a", "changed")
+	if password := readPassword(t, repo, "a"); password != "changed" {
+		t.Errorf("changed password while locked = %q, want it in plaintext", password)
+	}
+	if _, exists := readVaultFile(t, v).Secrets["connections/a/password"]; exists {
+		t.Error("the vault still holds the replaced password")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"seagle/core/domain"
 )
@@ -155,17 +154,12 @@ func (r *MetadataRepository) loadFile() (*metadataFile, error) {
 
 // saveFile saves the metadata file
 func (r *MetadataRepository) saveFile(file *metadataFile) error {
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
 	data, err := json.MarshalIndent(file, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	if err := os.WriteFile(r.filePath, data, 0644); err != nil {
+	if err := writePrivateFile(r.filePath, data); err != nil {
 		return fmt.Errorf("failed to write metadata file: %w", err)
 	}
 
@@ -0,0 +1,253 @@
+package persistence
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"seagle/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// QueryHistoryRepo stores the query history in a JSON Lines file, one entry
+// per line, so recording a query appends to the file instead of rewriting it
+type QueryHistoryRepo struct {
+	mu       sync.Mutex
+	filename string
+	// maxBytes caps the size of the file, when positive. Once an entry takes
+	// the file past it, the oldest entries are dropped down to three
+	// quarters of it, so the file is not rewritten on every append.
+	maxBytes int64
+}
+
+func NewQueryHistoryRepo(filename string, maxBytes int64) *QueryHistoryRepo {
+	return &QueryHistoryRepo{
+		filename: filename,
+		maxBytes: maxBytes,
+	}
+}
+
+// historyRecord represents a line of the history file
+type historyRecord struct {
+	ID           string    `json:"id"`
+	ConnectionID string    `json:"connectionId"`
+	Database     string    `json:"database"`
+	Query        string    `json:"query"`
+	StartedAt    time.Time `json:"startedAt"`
+	Duration     int64     `json:"duration"` // in milliseconds
+	RowCount     int64     `json:"rowCount"`
+	Error        string    `json:"error,omitempty"`
+	DryRun       bool      `json:"dryRun,omitempty"`
+}
+
+func (r *QueryHistoryRepo) NextID() string {
+	return uuid.NewString()
+}
+
+func (r *QueryHistoryRepo) Append(entry *domain.HistoryEntry) error {
+	line, err := json.Marshal(r.domainToRecord(entry))
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.filename), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.OpenFile(r.filename, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	// A line cut short by a crash while appending is ended first, so the
+	// entry does not share it
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	if r.maxBytes <= 0 {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+	if info.Size() > r.maxBytes {
+		if _, err := r.prune(time.Time{}, r.maxBytes*3/4); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search reads the file through, keeping no more than the entries it
+// returns
+func (r *QueryHistoryRepo) Search(filter domain.HistoryFilter) ([]*domain.HistoryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The matches, oldest first, are cut to the newest ones as they come
+	var matches []*domain.HistoryEntry
+	err := r.scan(func(record historyRecord) bool {
+		entry := r.recordToDomain(record)
+		if filter.Matches(entry) {
+			matches = append(matches, entry)
+			if filter.Limit > 0 && len(matches) > filter.Limit {
+				matches = matches[1:]
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.HistoryEntry, 0, len(matches))
+	for i := len(matches) - 1; i >= 0; i-- {
+		entries = append(entries, matches[i])
+	}
+	return entries, nil
+}
+
+func (r *QueryHistoryRepo) FindByID(id string) (*domain.HistoryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var found *domain.HistoryEntry
+	err := r.scan(func(record historyRecord) bool {
+		if record.ID == id {
+			found = r.recordToDomain(record)
+		}
+		return found == nil
+	})
+	return found, err
+}
+
+func (r *QueryHistoryRepo) Prune(olderThan time.Time, maxBytes int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.prune(olderThan, maxBytes)
+}
+
+// prune rewrites the file without the entries Prune removes. r.mu must be
+// held.
+func (r *QueryHistoryRepo) prune(olderThan time.Time, maxBytes int64) (int, error) {
+	var records []historyRecord
+	err := r.scan(func(record historyRecord) bool {
+		records = append(records, record)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// The newest entries are kept, so the lines are measured from the end
+	var kept [][]byte
+	var size int64
+	for i := len(records) - 1; i >= 0; i-- {
+		if !olderThan.IsZero() && records[i].StartedAt.Before(olderThan) {
+			continue
+		}
+		line, err := json.Marshal(records[i])
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal history entry: %w", err)
+		}
+		size += int64(len(line)) + 1
+		if maxBytes > 0 && size > maxBytes {
+			break
+		}
+		kept = append(kept, line)
+	}
+
+	removed := len(records) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	var data bytes.Buffer
+	for i := len(kept) - 1; i >= 0; i-- {
+		data.Write(kept[i])
+		data.WriteByte('\n')
+	}
+	if err := writePrivateFile(r.filename, data.Bytes()); err != nil {
+		return 0, fmt.Errorf("failed to write history file: %w", err)
+	}
+	return removed, nil
+}
+
+// scan calls fn with the entries of the history file, oldest first, until
+// it returns false. Lines that do not parse, as one cut short by a crash
+// while appending, are skipped.
+func (r *QueryHistoryRepo) scan(fn func(record historyRecord) bool) error {
+	file, err := os.Open(r.filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var record historyRecord
+			if json.Unmarshal(line, &record) == nil && !fn(record) {
+				return nil
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read history file: %w", err)
+		}
+	}
+}
+
+func (r *QueryHistoryRepo) domainToRecord(entry *domain.HistoryEntry) historyRecord {
+	return historyRecord{
+		ID:           entry.ID(),
+		ConnectionID: entry.ConnectionID(),
+		Database:     entry.Database(),
+		Query:        entry.Query(),
+		StartedAt:    entry.StartedAt(),
+		Duration:     entry.Duration().Milliseconds(),
+		RowCount:     entry.RowCount(),
+		Error:        entry.Error(),
+		DryRun:       entry.DryRun(),
+	}
+}
+
+func (r *QueryHistoryRepo) recordToDomain(record historyRecord) *domain.HistoryEntry {
+	return domain.NewHistoryEntry(
+		record.ID,
+		record.ConnectionID,
+		record.Database,
+		record.Query,
+		record.StartedAt,
+		time.Duration(record.Duration)*time.Millisecond,
+		record.RowCount,
+		record.Error,
+		record.DryRun,
+	)
+}
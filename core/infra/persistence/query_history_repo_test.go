@@ -0,0 +1,217 @@
+package persistence
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"seagle/core/domain"
+)
+
+var historyStart = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestHistoryRepo(t *testing.T, maxBytes int64) *QueryHistoryRepo {
+	t.Helper()
+	return NewQueryHistoryRepo(filepath.Join(t.TempDir(), "history.jsonl"), maxBytes)
+}
+
+// appendQueries records the queries a minute apart, the first one at
+// historyStart, on the connection
+func appendQueries(t *testing.T, repo *QueryHistoryRepo, connectionID string, queries ...string) {
+	t.Helper()
+	for _, query := range queries {
+		entries, err := repo.Search(domain.HistoryFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		startedAt := historyStart.Add(time.Duration(len(entries)) * time.Minute)
+		entry := domain.NewHistoryEntry(repo.NextID(), connectionID, "shop", query, startedAt, 15*time.Millisecond, 1, "", false)
+		if err := repo.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func queriesOf(entries []*domain.HistoryEntry) []string {
+	var queries []string
+	for _, entry := range entries {
+		queries = append(queries, entry.Query())
+	}
+	return queries
+}
+
+func TestQueryHistoryRepoSearch(t *testing.T) {
+	repo := newTestHistoryRepo(t, 0)
+	appendQueries(t, repo, "a", "SELECT * FROM orders", "SELECT * FROM customers")
+	appendQueries(t, repo, "b", "select count(*) from orders", "DELETE FROM carts")
+	appendQueries(t, repo, "a", "UPDATE orders SET paid = true")
+
+	tests := []struct {
+		name   string
+		filter domain.HistoryFilter
+		want   []string
+	}{
+		{
+			name:   "everything, newest first",
+			filter: domain.HistoryFilter{},
+			want:   []string{"UPDATE orders SET paid = true", "DELETE FROM carts", "select count(*) from orders", "SELECT * FROM customers", "SELECT * FROM orders"},
+		},
+		{
+			name:   "text ignoring case",
+			filter: domain.HistoryFilter{Text: "FROM ORDERS"},
+			want:   []string{"select count(*) from orders", "SELECT * FROM orders"},
+		},
+		{
+			name:   "connection",
+			filter: domain.HistoryFilter{ConnectionID: "b"},
+			want:   []string{"DELETE FROM carts", "select count(*) from orders"},
+		},
+		{
+			name:   "time range, end excluded",
+			filter: domain.HistoryFilter{From: historyStart.Add(time.Minute), To: historyStart.Add(3 * time.Minute)},
+			want:   []string{"select count(*) from orders", "SELECT * FROM customers"},
+		},
+		{
+			name:   "limit keeps the newest",
+			filter: domain.HistoryFilter{Text: "orders", Limit: 2},
+			want:   []string{"UPDATE orders SET paid = true", "select count(*) from orders"},
+		},
+		{
+			name:   "no match",
+			filter: domain.HistoryFilter{Text: "DROP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := repo.Search(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := queriesOf(entries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryHistoryRepoFindByID(t *testing.T) {
+	repo := newTestHistoryRepo(t, 0)
+
+	if entry, err := repo.FindByID("missing"); err != nil || entry != nil {
+		t.Fatalf("FindByID() without a history file = %v, %v", entry, err)
+	}
+
+	appendQueries(t, repo, "a", "SELECT 1", "SELECT 2", "SELECT 3")
+	entries, err := repo.Search(domain.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := repo.FindByID(entries[1].ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(entry, entries[1]) {
+		t.Errorf("FindByID() = %+v, want %+v", entry, entries[1])
+	}
+
+	if entry, err := repo.FindByID("missing"); err != nil || entry != nil {
+		t.Errorf("FindByID() of an unknown ID = %v, %v", entry, err)
+	}
+}
+
+func TestQueryHistoryRepoSkipsBrokenLines(t *testing.T) {
+	repo := newTestHistoryRepo(t, 0)
+	appendQueries(t, repo, "a", "SELECT 1")
+
+	// A crash while appending leaves a line cut short
+	file, err := os.OpenFile(repo.filename, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"id":"cut","query":"SELE`)
+	file.Close()
+
+	appendQueries(t, repo, "a", "SELECT 2")
+
+	entries, err := repo.Search(domain.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := queriesOf(entries), []string{"SELECT 2", "SELECT 1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() = %q, want %q", got, want)
+	}
+}
+
+func TestQueryHistoryRepoAppendCapsSize(t *testing.T) {
+	const maxBytes = 4096
+	repo := newTestHistoryRepo(t, maxBytes)
+
+	for i := 0; i < 100; i++ {
+		appendQueries(t, repo, "a", fmt.Sprintf("SELECT %d FROM orders", i))
+
+		info, err := os.Stat(repo.filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > maxBytes {
+			t.Fatalf("the history takes %d bytes after %d entries, more than %d", info.Size(), i+1, maxBytes)
+		}
+	}
+
+	entries, err := repo.Search(domain.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 || len(entries) == 100 {
+		t.Fatalf("%d entries kept", len(entries))
+	}
+	if got := entries[0].Query(); got != "SELECT 99 FROM orders" {
+		t.Errorf("newest entry = %q, want the last one appended", got)
+	}
+	if got := entries[len(entries)-1].Query(); got == "SELECT 0 FROM orders" {
+		t.Errorf("the oldest entry is still kept")
+	}
+}
+
+func TestQueryHistoryRepoPrune(t *testing.T) {
+	repo := newTestHistoryRepo(t, 0)
+	appendQueries(t, repo, "a", "SELECT 1", "SELECT 2", "SELECT 3", "SELECT 4")
+
+	removed, err := repo.Prune(historyStart.Add(time.Minute), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("Prune() by age removed %d entries, want 1", removed)
+	}
+
+	data, err := os.ReadFile(repo.filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Room for two of the three entries left, whose lines are as long
+	line := len(data) / 3
+	if removed, err = repo.Prune(time.Time{}, int64(2*line)); err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("Prune() by size removed %d entries, want 1", removed)
+	}
+
+	entries, err := repo.Search(domain.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := queriesOf(entries), []string{"SELECT 4", "SELECT 3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("entries left = %q, want %q", got, want)
+	}
+	if strings.Contains(string(data), "SELECT 1") {
+		t.Errorf("the entry pruned by age is still in the file")
+	}
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"seagle/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SnippetRepo stores the saved query snippets in a JSON file
+type SnippetRepo struct {
+	filename string
+}
+
+func NewSnippetRepo(filename string) *SnippetRepo {
+	return &SnippetRepo{
+		filename: filename,
+	}
+}
+
+func (r *SnippetRepo) NextID() string {
+	return uuid.NewString()
+}
+
+func (r *SnippetRepo) Save(snippet *domain.Snippet) error {
+	snippets, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	exists := false
+	for i, s := range snippets {
+		if s.ID() == snippet.ID() {
+			snippets[i] = snippet
+			exists = true
+			break
+		}
+	}
+
+	if !exists {
+		snippets = append(snippets, snippet)
+	}
+
+	return saveDataToFile(r.filename, r.toDataMap(snippets))
+}
+
+func (r *SnippetRepo) List() ([]*domain.Snippet, error) {
+	return r.load()
+}
+
+func (r *SnippetRepo) FindByID(id string) (*domain.Snippet, error) {
+	snippets, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range snippets {
+		if s.ID() == id {
+			return s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *SnippetRepo) DeleteByID(id string) error {
+	snippets, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	updatedSnippets := []*domain.Snippet{}
+	for _, s := range snippets {
+		if s.ID() != id {
+			updatedSnippets = append(updatedSnippets, s)
+		}
+	}
+
+	return saveDataToFile(r.filename, r.toDataMap(updatedSnippets))
+}
+
+func (r *SnippetRepo) DeleteByConnectionID(connectionID string) error {
+	snippets, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	updatedSnippets := []*domain.Snippet{}
+	for _, s := range snippets {
+		if s.Global() || s.ConnectionID() != connectionID {
+			updatedSnippets = append(updatedSnippets, s)
+		}
+	}
+	if len(updatedSnippets) == len(snippets) {
+		return nil
+	}
+
+	return saveDataToFile(r.filename, r.toDataMap(updatedSnippets))
+}
+
+func (r *SnippetRepo) load() ([]*domain.Snippet, error) {
+	data, err := loadDataFromFile(r.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	snippets := []*domain.Snippet{}
+	if existingSnippets, ok := data["snippets"].([]interface{}); ok {
+		for _, s := range existingSnippets {
+			if snippetMap, ok := s.(map[string]interface{}); ok {
+				snippets = append(snippets, domain.NewSnippetFromMap(snippetMap))
+			}
+		}
+	}
+
+	return snippets, nil
+}
+
+func (r *SnippetRepo) toDataMap(snippets []*domain.Snippet) map[string]interface{} {
+	snippetMaps := make([]map[string]interface{}, len(snippets))
+	for i, s := range snippets {
+		snippetMaps[i] = s.Map()
+	}
+	return map[string]interface{}{
+		"snippets": snippetMaps,
+	}
+}
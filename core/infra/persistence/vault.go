@@ -0,0 +1,355 @@
+package persistence
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"seagle/core/domain"
+)
+
+// MinPassphraseLength is the shortest master passphrase accepted
+const MinPassphraseLength = 8
+
+const (
+	vaultVersion = 1
+	kdfArgon2id  = "argon2id"
+	// vaultCheck is sealed with the key so a wrong passphrase is detected
+	// before any secret is touched
+	vaultCheck = "seagle-vault"
+)
+
+// vaultFile is the JSON layout of the vault. Every sealed value is the base64
+// encoding of a random nonce followed by the XChaCha20-Poly1305 ciphertext.
+type vaultFile struct {
+	Version int               `json:"version"`
+	KDF     vaultKDF          `json:"kdf"`
+	Check   string            `json:"check"`
+	Secrets map[string]string `json:"secrets"`
+}
+
+// vaultKDF holds the Argon2id parameters the key is derived with
+type vaultKDF struct {
+	Algorithm string `json:"algorithm"`
+	Salt      string `json:"salt"`
+	Time      uint32 `json:"time"`
+	// Memory is given in KiB
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// Vault implements domain.Vault with a JSON file only readable by the user.
+// The key lives in memory while unlocked and is dropped after relockAfter
+// without use.
+type Vault struct {
+	mu          sync.Mutex
+	filename    string
+	relockAfter time.Duration
+	key         []byte
+	relock      *time.Timer
+	// uses tells a relock timer that fired late whether the vault was used
+	// in the meantime
+	uses uint64
+}
+
+// NewVault creates a locked vault stored in filename
+func NewVault(filename string, relockAfter time.Duration) *Vault {
+	return &Vault{
+		filename:    filename,
+		relockAfter: relockAfter,
+	}
+}
+
+func (v *Vault) Initialized() bool {
+	_, err := os.Stat(v.filename)
+	return err == nil
+}
+
+func (v *Vault) Locked() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.key == nil
+}
+
+func (v *Vault) RelockAfter() time.Duration {
+	return v.relockAfter
+}
+
+func (v *Vault) Initialize(passphrase string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.Initialized() {
+		return fmt.Errorf("vault already exists")
+	}
+	if len(passphrase) < MinPassphraseLength {
+		return fmt.Errorf("master passphrase must be at least %d characters long", MinPassphraseLength)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	file := &vaultFile{
+		Version: vaultVersion,
+		KDF: vaultKDF{
+			Algorithm: kdfArgon2id,
+			Salt:      base64.StdEncoding.EncodeToString(salt),
+			Time:      3,
+			Memory:    64 * 1024,
+			Threads:   4,
+		},
+		Secrets: map[string]string{},
+	}
+
+	key, err := deriveKey(passphrase, file.KDF)
+	if err != nil {
+		return err
+	}
+
+	file.Check, err = seal(key, vaultCheck, vaultCheck)
+	if err != nil {
+		return err
+	}
+
+	if err := v.save(file); err != nil {
+		return err
+	}
+
+	v.unlock(key)
+	return nil
+}
+
+func (v *Vault) Unlock(passphrase string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	file, err := v.load()
+	if err != nil {
+		return err
+	}
+
+	key, err := deriveKey(passphrase, file.KDF)
+	if err != nil {
+		return err
+	}
+	if _, err := open(key, vaultCheck, file.Check); err != nil {
+		return fmt.Errorf("wrong master passphrase")
+	}
+
+	v.unlock(key)
+	return nil
+}
+
+func (v *Vault) Lock() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lock()
+}
+
+func (v *Vault) Put(name, secret string) (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	file, err := v.load()
+	if err != nil {
+		return "", err
+	}
+	if v.key == nil {
+		return "", domain.ErrVaultLocked
+	}
+
+	file.Secrets[name], err = seal(v.key, name, secret)
+	if err != nil {
+		return "", err
+	}
+	if err := v.save(file); err != nil {
+		return "", err
+	}
+
+	v.touch()
+	return domain.VaultRef(name), nil
+}
+
+// Delete works while locked, removing a secret does not need the key
+func (v *Vault) Delete(name string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	file, err := v.load()
+	if errors.Is(err, domain.ErrVaultNotInitialized) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, exists := file.Secrets[name]; !exists {
+		return nil
+	}
+	delete(file.Secrets, name)
+
+	return v.save(file)
+}
+
+func (v *Vault) Resolve(value string) (string, error) {
+	if !domain.IsVaultRef(value) {
+		return value, nil
+	}
+	name := strings.TrimPrefix(value, domain.VaultRefPrefix)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	file, err := v.load()
+	if err != nil {
+		return "", err
+	}
+	if v.key == nil {
+		return "", domain.ErrVaultLocked
+	}
+
+	sealed, exists := file.Secrets[name]
+	if !exists {
+		return "", fmt.Errorf("secret %s not found in vault", name)
+	}
+
+	secret, err := open(v.key, name, sealed)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret %s: %w", name, err)
+	}
+
+	v.touch()
+	return secret, nil
+}
+
+// unlock keeps the key and starts the relock countdown, the lock must be held
+func (v *Vault) unlock(key []byte) {
+	v.lock()
+	v.key = key
+	v.touch()
+}
+
+// lock wipes the key, the lock must be held
+func (v *Vault) lock() {
+	if v.relock != nil {
+		v.relock.Stop()
+		v.relock = nil
+	}
+	for i := range v.key {
+		v.key[i] = 0
+	}
+	v.key = nil
+}
+
+// touch restarts the relock countdown, the lock must be held
+func (v *Vault) touch() {
+	v.uses++
+	if v.relockAfter <= 0 {
+		return
+	}
+	if v.relock != nil {
+		v.relock.Stop()
+	}
+
+	uses := v.uses
+	v.relock = time.AfterFunc(v.relockAfter, func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		if v.uses == uses {
+			v.lock()
+		}
+	})
+}
+
+func (v *Vault) load() (*vaultFile, error) {
+	data, err := os.ReadFile(v.filename)
+	if os.IsNotExist(err) {
+		return nil, domain.ErrVaultNotInitialized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault: %w", err)
+	}
+
+	var file vaultFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse vault: %w", err)
+	}
+	if file.Version != vaultVersion || file.KDF.Algorithm != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported vault version %d with %s", file.Version, file.KDF.Algorithm)
+	}
+	if file.Secrets == nil {
+		file.Secrets = map[string]string{}
+	}
+
+	return &file, nil
+}
+
+func (v *Vault) save(file *vaultFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal vault: %w", err)
+	}
+
+	if err := writePrivateFile(v.filename, data); err != nil {
+		return fmt.Errorf("failed to write vault: %w", err)
+	}
+
+	return nil
+}
+
+func deriveKey(passphrase string, kdf vaultKDF) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(kdf.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault salt: %w", err)
+	}
+	return argon2.IDKey([]byte(passphrase), salt, kdf.Time, kdf.Memory, kdf.Threads, chacha20poly1305.KeySize), nil
+}
+
+// seal encrypts the plaintext, binding it to the name it is stored under so
+// sealed values cannot be swapped between names
+func seal(key []byte, name, plaintext string) (string, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(name))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func open(key []byte, name, sealed string) (string, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < aead.NonceSize() {
+		return "", fmt.Errorf("sealed value is too short")
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(name))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
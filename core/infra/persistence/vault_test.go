@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"seagle/core/domain"
+)
+
+func newTestVault(t *testing.T) *Vault {
+	t.Helper()
+	v := NewVault(filepath.Join(t.TempDir(), "vault.json"), 0)
+	if err := v.Initialize("correct horse"); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	return v
+}
+
+func readVaultFile(t *testing.T, v *Vault) vaultFile {
+	t.Helper()
+	data, err := os.ReadFile(v.filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file vaultFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestVaultSealsSecrets(t *testing.T) {
+	v := newTestVault(t)
+
+	ref, err := v.Put("connections/a/password", "s3cret")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if ref != domain.VaultRef("connections/a/password") {
+		t.Errorf("Put() = %q", ref)
+	}
+
+	file := readVaultFile(t, v)
+	if file.KDF.Algorithm != kdfArgon2id || file.KDF.Salt == "" {
+		t.Errorf("KDF = %+v", file.KDF)
+	}
+	sealed := file.Secrets["connections/a/password"]
+	if sealed == "" || strings.Contains(sealed, "s3cret") {
+		t.Errorf("sealed secret = %q", sealed)
+	}
+	info, err := os.Stat(v.filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("vault file mode = %v, want 0600", perm)
+	}
+
+	got, err := v.Resolve(ref)
+	if err != nil || got != "s3cret" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+	if got, err := v.Resolve("plain"); err != nil || got != "plain" {
+		t.Errorf("Resolve(plain) = %q, %v", got, err)
+	}
+
+	// Sealing twice never gives the same value, the nonce is random
+	v.Put("connections/b/password", "s3cret")
+	if again := readVaultFile(t, v).Secrets["connections/b/password"]; again == sealed {
+		t.Error("sealed values repeat")
+	}
+}
+
+func TestVaultBindsSecretsToNames(t *testing.T) {
+	v := newTestVault(t)
+	v.Put("a", "first")
+	v.Put("b", "second")
+
+	// A sealed value moved under another name does not open
+	file := readVaultFile(t, v)
+	file.Secrets["b"] = file.Secrets["a"]
+	if err := v.save(&file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Resolve(domain.VaultRef("b")); err == nil {
+		t.Error("Resolve() of a swapped secret succeeded")
+	}
+}
+
+func TestVaultUnlock(t *testing.T) {
+	v := newTestVault(t)
+	ref, _ := v.Put("a", "s3cret")
+
+	v.Lock()
+	if !v.Locked() {
+		t.Fatal("Locked() = false after Lock()")
+	}
+	if _, err := v.Resolve(ref); !errors.Is(err, domain.ErrVaultLocked) {
+		t.Errorf("Resolve() while locked error = %v, want ErrVaultLocked", err)
+	}
+	if _, err := v.Put("b", "other"); !errors.Is(err, domain.ErrVaultLocked) {
+		t.Errorf("Put() while locked error = %v, want ErrVaultLocked", err)
+	}
+
+	// A vault opened again from its file unlocks with the same passphrase
+	reopened := NewVault(v.filename, 0)
+	if err := reopened.Unlock("wrong passphrase"); err == nil || !reopened.Locked() {
+		t.Errorf("Unlock() with a wrong passphrase error = %v, locked = %v", err, reopened.Locked())
+	}
+	if err := reopened.Unlock("correct horse"); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	if got, err := reopened.Resolve(ref); err != nil || got != "s3cret" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+}
+
+func TestVaultInitialize(t *testing.T) {
+	v := NewVault(filepath.Join(t.TempDir(), "vault.json"), 0)
+	if _, err := v.Put("a", "s3cret"); !errors.Is(err, domain.ErrVaultNotInitialized) {
+		t.Errorf("Put() before Initialize() error = %v, want ErrVaultNotInitialized", err)
+	}
+	if err := v.Initialize("short"); err == nil {
+		t.Error("Initialize() accepted a short passphrase")
+	}
+	if err := v.Initialize("correct horse"); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if !v.Initialized() || v.Locked() {
+		t.Errorf("Initialized() = %v, Locked() = %v", v.Initialized(), v.Locked())
+	}
+	if err := v.Initialize("correct horse"); err == nil {
+		t.Error("Initialize() replaced an existing vault")
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
-import "seagle/core/domain"
+import (
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
 
 type ConfigService struct {
 	repo domain.ConfigRepo
@@ -12,14 +15,23 @@ func NewConfigService(repo domain.ConfigRepo) *ConfigService {
 	}
 }
 
-func (s *ConfigService) SetConfig(
-	openAIAPIKey string,
-) error {
+// SetConfig saves the configuration. A masked API key, as returned by
+// GetConfig, keeps the stored one. Zero result limits restore the defaults.
+func (s *ConfigService) SetConfig(config types.Config) error {
 	cfg, err := s.repo.Find()
 	if err != nil {
 		return err
 	}
 
+	openAIAPIKey := config.OpenAIAPIKey
+	if openAIAPIKey == types.MaskedSecret {
+		if cfg == nil {
+			openAIAPIKey = ""
+		} else {
+			openAIAPIKey = cfg.OpenAIAPIKey()
+		}
+	}
+
 	if cfg == nil {
 		cfg, err = domain.NewConfig(openAIAPIKey)
 		if err != nil {
@@ -28,29 +40,24 @@ func (s *ConfigService) SetConfig(
 	} else {
 		cfg.SetOpenAIAPIKey(openAIAPIKey)
 	}
+	cfg.SetResultLimits(config.MaxResultRows, config.MaxResultBytes)
 
 	return s.repo.Save(cfg)
 }
 
-func (s *ConfigService) GetConfig() (struct {
-	OpenAIAPIKey string
-}, error) {
+// GetConfig returns the configuration with the API key masked
+func (s *ConfigService) GetConfig() (types.Config, error) {
 	cfg, err := s.repo.Find()
 	if err != nil {
-		return struct {
-			OpenAIAPIKey string
-		}{}, err
+		return types.Config{}, err
 	}
 
-	if cfg == nil {
-		return struct {
-			OpenAIAPIKey string
-		}{}, nil
+	config := types.Config{
+		MaxResultRows:  cfg.MaxResultRows(),
+		MaxResultBytes: cfg.MaxResultBytes(),
 	}
-
-	return struct {
-		OpenAIAPIKey string
-	}{
-		OpenAIAPIKey: cfg.OpenAIAPIKey(),
-	}, nil
+	if cfg != nil {
+		config.OpenAIAPIKey = maskSecret(cfg.OpenAIAPIKey())
+	}
+	return config, nil
 }
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"seagle/core/services/types"
+)
+
+// confirmationTTL is how long a confirmation token stays valid
+const confirmationTTL = 5 * time.Minute
+
+// ConfirmationRequiredError is returned instead of running destructive
+// statements on production connections
+type ConfirmationRequiredError struct {
+	Confirmation types.QueryConfirmation
+}
+
+func (e *ConfirmationRequiredError) Error() string {
+	return fmt.Sprintf("%d destructive statement(s) need confirmation before running on a production connection", len(e.Confirmation.Statements))
+}
+
+// pendingConfirmation is the query a confirmation token was issued for
+type pendingConfirmation struct {
+	connectionID string
+	database     string
+	query        string
+	expires      time.Time
+}
+
+// confirmationStore issues one-time tokens that let a destructive query run
+// once, on the connection and database it was confirmed for
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{
+		pending: make(map[string]pendingConfirmation),
+	}
+}
+
+// issue returns a new token for the query
+func (s *confirmationStore) issue(connectionID, database, query string) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for t, p := range s.pending {
+		if !now.Before(p.expires) {
+			delete(s.pending, t)
+		}
+	}
+	s.pending[token] = pendingConfirmation{
+		connectionID: connectionID,
+		database:     database,
+		query:        query,
+		expires:      now.Add(confirmationTTL),
+	}
+	return token, nil
+}
+
+// consume reports whether the token was issued for the query and is still
+// valid. The token cannot be used again either way.
+func (s *confirmationStore) consume(token, connectionID, database, query string) bool {
+	if token == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, exists := s.pending[token]
+	if !exists {
+		return false
+	}
+	delete(s.pending, token)
+
+	return time.Now().Before(p.expires) &&
+		p.connectionID == connectionID &&
+		p.database == database &&
+		p.query == query
+}
@@ -1,7 +1,12 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
 
 	"seagle/core/domain"
 	"seagle/core/services/types"
@@ -14,6 +19,15 @@ type ConnectionService struct {
 	serviceFactory  *domain.ServiceFactory
 	metadataFactory *domain.MetadataFactory
 	openaiClient    *OpenAIClient
+	secrets         domain.SecretResolver
+	credentials     *credentialCache
+	confirmations   *confirmationStore
+	executions      *executionRegistry
+	results         *resultStore
+	transactions    *transactionStore
+	configRepo      domain.ConfigRepo
+	historyRepo     domain.QueryHistoryRepo
+	snippetRepo     domain.SnippetRepo
 }
 
 // NewConnectionService creates a new ConnectionService instance
@@ -23,13 +37,27 @@ func NewConnectionService(
 	serviceFactory *domain.ServiceFactory,
 	metadataFactory *domain.MetadataFactory,
 	openaiClient *OpenAIClient,
+	secrets domain.SecretResolver,
+	configRepo domain.ConfigRepo,
+	historyRepo domain.QueryHistoryRepo,
+	snippetRepo domain.SnippetRepo,
 ) *ConnectionService {
+	results := newResultStore()
 	return &ConnectionService{
 		repo:            repo,
 		metadataRepo:    metadataRepo,
 		serviceFactory:  serviceFactory,
 		metadataFactory: metadataFactory,
 		openaiClient:    openaiClient,
+		secrets:         secrets,
+		credentials:     newCredentialCache(),
+		confirmations:   newConfirmationStore(),
+		executions:      newExecutionRegistry(),
+		results:         results,
+		transactions:    newTransactionStore(results),
+		configRepo:      configRepo,
+		historyRepo:     historyRepo,
+		snippetRepo:     snippetRepo,
 	}
 }
 
@@ -53,6 +81,10 @@ func (cs *ConnectionService) Connect(config types.DatabaseConfig) (*types.Databa
 		return nil, err
 	}
 
+	if domainConn.PromptPassword() {
+		cs.credentials.store(domainConn.ID(), domainConn.Password(), 0)
+	}
+
 	return &types.DatabaseConnection{
 		ID:          domainConn.ID(),
 		Config:      config,
@@ -60,28 +92,82 @@ func (cs *ConnectionService) Connect(config types.DatabaseConfig) (*types.Databa
 	}, nil
 }
 
-func (cs *ConnectionService) ConnectByID(id string) (*types.DatabaseConnection, error) {
-	conn, dbService, err := cs.lookup(id)
+// ConnectByID connects a saved connection. Connections that do not save their
+// password take it from password, or from memory when it was supplied before,
+// and fail with ErrCredentialsRequired otherwise. The supplied password is
+// kept while connected and for rememberFor after disconnecting.
+func (cs *ConnectionService) ConnectByID(id, password string, rememberFor time.Duration) (*types.DatabaseConnection, error) {
+	conn, err := cs.repo.FindByID(id)
 	if err != nil {
+		return nil, fmt.Errorf("failed to find connection by ID: %w", err)
+	}
+	if conn == nil {
+		return nil, fmt.Errorf("connection with ID %s not found", id)
+	}
+
+	supplied := conn.PromptPassword() && password != ""
+	if supplied {
+		conn.SetCredentials(conn.Username(), password)
+	} else if err := cs.applyCachedPassword(conn); err != nil {
 		return nil, err
 	}
 
+	dbService, err := cs.serviceFactory.NewDatabaseService(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database service: %w", err)
+	}
+
 	if err := dbService.Connect(conn); err != nil {
 		return nil, err
 	}
 
+	if supplied {
+		cs.credentials.store(id, password, rememberFor)
+	}
+
 	return &types.DatabaseConnection{
+		ID:          id,
 		IsConnected: true,
 	}, nil
 }
 
-func (cs *ConnectionService) lookup(id string) (*domain.Connection, domain.DatabaseService, error) {
+// find returns a saved connection with the password it was connected with
+// when it does not save it
+func (cs *ConnectionService) find(id string) (*domain.Connection, error) {
 	conn, err := cs.repo.FindByID(id)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find connection by ID: %w", err)
+		return nil, fmt.Errorf("failed to find connection by ID: %w", err)
 	}
 	if conn == nil {
-		return nil, nil, fmt.Errorf("connection with ID %s not found", id)
+		return nil, fmt.Errorf("connection with ID %s not found", id)
+	}
+
+	if err := cs.applyCachedPassword(conn); err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// applyCachedPassword sets the password kept in memory on connections that
+// do not save it
+func (cs *ConnectionService) applyCachedPassword(conn *domain.Connection) error {
+	if !conn.PromptPassword() {
+		return nil
+	}
+
+	password, ok := cs.credentials.get(conn.ID())
+	if !ok {
+		return ErrCredentialsRequired
+	}
+	conn.SetCredentials(conn.Username(), password)
+	return nil
+}
+
+func (cs *ConnectionService) lookup(id string) (*domain.Connection, domain.DatabaseService, error) {
+	conn, err := cs.find(id)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	dbService, err := cs.serviceFactory.NewDatabaseService(conn)
@@ -92,26 +178,144 @@ func (cs *ConnectionService) lookup(id string) (*domain.Connection, domain.Datab
 	return conn, dbService, nil
 }
 
-// TestConnection tests the database connection with given parameters
-func (cs *ConnectionService) TestConnection(config types.DatabaseConfig) error {
+// TestConnection tests the database connection with given parameters and
+// reports the TLS session negotiated with the server
+func (cs *ConnectionService) TestConnection(config types.DatabaseConfig) (*types.TLSStatus, error) {
 	domainConn, err := cs.configToDomainConnection(cs.repo.NextID(), config)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return cs.testConnection(domainConn)
+}
+
+// testConnection connects with a connection that is not saved, so it must
+// carry an ID of its own, and closes its sessions afterwards
+func (cs *ConnectionService) testConnection(domainConn *domain.Connection) (*types.TLSStatus, error) {
 	dbService, err := cs.serviceFactory.NewDatabaseService(domainConn)
 	if err != nil {
-		return fmt.Errorf("failed to create database service: %w", err)
+		return nil, fmt.Errorf("failed to create database service: %w", err)
 	}
 
 	if err := dbService.Connect(domainConn); err != nil {
-		return err
+		return nil, err
 	}
+	defer dbService.Disconnect(domainConn)
 
-	return dbService.Disconnect(domainConn)
+	status := &types.TLSStatus{}
+	if inspector, ok := dbService.(domain.TLSInspector); ok {
+		state, err := inspector.TLSState(domainConn)
+		if err != nil {
+			return nil, err
+		}
+		status = &types.TLSStatus{
+			Enabled: state.Enabled,
+			Version: state.Version,
+			Cipher:  state.Cipher,
+			Subject: state.Subject,
+			Issuer:  state.Issuer,
+		}
+	}
+
+	return status, nil
 }
 
-// Disconnect closes the current database connection
+// UpdateConnection validates and saves a new config for a saved connection,
+// testing it first when asked. Sessions and cached metadata are dropped only
+// when the host, port or credentials changed.
+func (cs *ConnectionService) UpdateConnection(id string, config types.DatabaseConfig, test bool) (*types.DatabaseConnection, error) {
+	existing, err := cs.repo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection by ID: %w", err)
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("connection with ID %s not found", id)
+	}
+
+	unmaskConfig(&config, existing)
+	updated, err := cs.configToDomainConnection(id, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if test {
+		if _, err := cs.testConnection(updated.Duplicate(cs.repo.NextID())); err != nil {
+			return nil, fmt.Errorf("connection test failed: %w", err)
+		}
+	}
+
+	if err := cs.repo.Save(updated); err != nil {
+		return nil, fmt.Errorf("failed to save connection: %w", err)
+	}
+
+	if !existing.SameServer(updated) {
+		cs.credentials.forget(id)
+		cs.results.closeConnection(id)
+		cs.transactions.closeConnection(id)
+		if dbService, err := cs.serviceFactory.NewDatabaseService(existing); err == nil {
+			if err := dbService.Disconnect(existing); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := cs.metadataRepo.Delete(id); err != nil {
+			return nil, fmt.Errorf("failed to delete connection metadata: %w", err)
+		}
+	}
+
+	return &types.DatabaseConnection{
+		ID:     id,
+		Config: maskConfig(config),
+	}, nil
+}
+
+// DuplicateConnection saves a copy of a connection under a new ID. The copy
+// takes the given config when there is one, otherwise it clones the source
+// and names it after it.
+func (cs *ConnectionService) DuplicateConnection(id string, config *types.DatabaseConfig, test bool) (*types.DatabaseConnection, error) {
+	source, err := cs.repo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection by ID: %w", err)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("connection with ID %s not found", id)
+	}
+
+	newID := cs.repo.NextID()
+
+	var duplicate *domain.Connection
+	if config != nil {
+		unmaskConfig(config, source)
+		duplicate, err = cs.configToDomainConnection(newID, *config)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		duplicate = source.Duplicate(newID)
+		profile := duplicate.Profile()
+		profile.Name = source.DisplayName() + " (copy)"
+		if err := duplicate.SetProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+
+	if test {
+		if _, err := cs.testConnection(duplicate); err != nil {
+			return nil, fmt.Errorf("connection test failed: %w", err)
+		}
+	}
+
+	if err := cs.repo.Save(duplicate); err != nil {
+		return nil, fmt.Errorf("failed to save connection: %w", err)
+	}
+
+	return &types.DatabaseConnection{
+		ID:     newID,
+		Config: maskConfig(domainToConfig(duplicate)),
+	}, nil
+}
+
+// Disconnect closes every session opened for the connection
 func (cs *ConnectionService) Disconnect(id string) error {
 	conn, err := cs.repo.FindByID(id)
 	if err != nil {
@@ -126,10 +330,14 @@ func (cs *ConnectionService) Disconnect(id string) error {
 		return fmt.Errorf("failed to create database service: %w", err)
 	}
 
+	cs.results.closeConnection(id)
+	cs.transactions.closeConnection(id)
 	if err := dbService.Disconnect(conn); err != nil {
 		return err
 	}
 
+	cs.credentials.disconnect(id)
+
 	return nil
 }
 
@@ -159,7 +367,6 @@ func (cs *ConnectionService) GetTables(originalID string, databaseName string) (
 	if err := dbService.Connect(cpy); err != nil {
 		return nil, fmt.Errorf("failed to connect to database %s: %w", databaseName, err)
 	}
-	defer dbService.Disconnect(cpy)
 
 	return dbService.GetTableNames(cpy, databaseName)
 }
@@ -176,7 +383,6 @@ func (cs *ConnectionService) GetTableColumns(originalID, databaseName, tableName
 	if err := dbService.Connect(cpy); err != nil {
 		return nil, fmt.Errorf("failed to connect to database %s: %w", databaseName, err)
 	}
-	defer dbService.Disconnect(cpy)
 
 	columns, err := dbService.GetTableColumns(cpy, databaseName, tableName)
 	if err != nil {
@@ -196,57 +402,705 @@ func (cs *ConnectionService) GetTableColumns(originalID, databaseName, tableName
 	return result, nil
 }
 
-// ExecuteQuery executes a SQL query against a specific database and returns the results
-func (cs *ConnectionService) ExecuteQuery(originalID, databaseName, query string) (*types.QueryResult, error) {
-	conn, dbService, err := cs.lookup(originalID)
+// ExecuteQuery runs the statements of a SQL script one after the other on the
+// same database session and returns the result of each. On production
+// connections, scripts with destructive statements fail with a
+// ConfirmationRequiredError until they are sent again with its token. Every
+// statement is canceled after the statement timeout of the connection, and
+// the script by CancelQuery with its execution ID. Scripts run in the open
+// transaction of the database, one is begun for connections in manual-commit
+// mode. A dry run reads the rows its statements change and rolls them back.
+// The returned error is for failures before the first statement runs, the
+// statements that fail are reported in the result. Every execution is
+// recorded in the query history.
+func (cs *ConnectionService) ExecuteQuery(req types.QueryRequest) (*types.ScriptResult, error) {
+	start := time.Now()
+	script, err := cs.executeQuery(req)
+	cs.recordHistory(req, start, script, err)
+	return script, err
+}
+
+func (cs *ConnectionService) executeQuery(req types.QueryRequest) (*types.ScriptResult, error) {
+	conn, dbService, err := cs.lookup(req.ConnectionID)
 	if err != nil {
 		return nil, err
 	}
 
-	cpy := domain.CopyConnection(conn, databaseName)
+	statements := domain.SplitScript(req.Query, conn.Dialect())
+	if len(statements) == 0 {
+		return nil, errors.New("query has no statement to run")
+	}
+
+	values, err := bindParameters(statements, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if conn.ReadOnly() {
+		if err := domain.CheckReadOnly(req.Query, conn.Dialect()); err != nil {
+			return nil, err
+		}
+	}
+
+	var plans []*domain.DryRun
+	if req.DryRun {
+		for _, statement := range statements {
+			plan, err := domain.PlanDryRun(statement, conn.Dialect())
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, plan)
+		}
+	}
+
+	open := cs.transactions.get(req.ConnectionID, req.Database) != nil
+	if conn.ManualCommit() || open {
+		if statement, ok := domain.FindTransactionControl(req.Query, conn.Dialect()); ok {
+			return nil, fmt.Errorf("%q cannot run in the open transaction, commit or roll it back instead", statement)
+		}
+	}
+
+	// Statements committing the open transaction on their own would leave
+	// it tracked with nothing left to roll back. In manual-commit mode they
+	// run in autocommit when no transaction is open.
+	implicitCommit := false
+	if statement, ok := domain.FindImplicitCommit(req.Query, conn.Dialect()); ok {
+		if open {
+			return nil, fmt.Errorf("%q commits the open transaction implicitly, commit or roll it back first", statement)
+		}
+		implicitCommit = true
+	}
+
+	cpy := domain.CopyConnection(conn, req.Database)
 
 	if err := dbService.Connect(cpy); err != nil {
-		return nil, fmt.Errorf("failed to connect to database %s: %w", databaseName, err)
+		return nil, fmt.Errorf("failed to connect to database %s: %w", req.Database, err)
+	}
+
+	// Dry runs are confirmed as well, the rollback they rely on may not undo
+	// every change
+	if conn.Profile().Environment == domain.EnvironmentProduction {
+		destructive := domain.FindDestructiveStatements(req.Query, conn.Dialect())
+		if len(destructive) > 0 && !cs.confirmations.consume(req.ConfirmationToken, req.ConnectionID, req.Database, req.Query) {
+			return nil, cs.confirmationRequired(cpy, dbService, req.ConnectionID, req.Database, req.Query, destructive)
+		}
 	}
-	defer dbService.Disconnect(cpy)
 
-	res, err := dbService.ExecQuery(cpy, query)
+	maxRows, maxBytes, err := cs.resultLimits()
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, err
 	}
 
-	return &types.QueryResult{
-		Columns:      res.Columns,
-		Rows:         res.Rows,
-		RowsAffected: res.RowsAffected,
-		Duration:     res.Duration,
-	}, nil
+	ctx, hook := domain.WithCancelHook(context.Background())
+	if req.ExecutionID != "" {
+		finish, err := cs.executions.start(req.ExecutionID, hook)
+		if err != nil {
+			hook.Cancel()
+			return nil, err
+		}
+		defer finish()
+	}
+
+	if req.DryRun {
+		defer hook.Cancel()
+		return cs.dryRun(ctx, hook, conn, cpy, dbService, req.Database, statements, plans, values, req.PageSize, maxBytes)
+	}
+
+	tx, err := cs.currentTransaction(conn, cpy, dbService, req.Database, !implicitCommit)
+	if err != nil {
+		hook.Cancel()
+		return nil, err
+	}
+	// A transaction may have begun since the check
+	if tx != nil && implicitCommit {
+		tx.mu.Unlock()
+		hook.Cancel()
+		return nil, errors.New("the query commits the open transaction implicitly, commit or roll it back first")
+	}
+
+	var session domain.QuerySession
+	if tx != nil {
+		defer tx.mu.Unlock()
+		// The rows left of the previous result hold the session
+		cs.results.remove(tx.resultID)
+		tx.resultID = ""
+		session = tx.session
+	} else {
+		session, err = openSession(ctx, dbService, cpy)
+		if err != nil {
+			hook.Cancel()
+			return nil, err
+		}
+		// The server rejects the writes the read-only check lets through
+		if begin := conn.Dialect().ReadOnlyBegin; conn.ReadOnly() && begin != "" {
+			session = domain.NewReadOnlySession(session, begin)
+		}
+	}
+	// The session goes with the result of the last statement when it has
+	// rows left to fetch, the session of a transaction stays with it
+	kept := false
+	defer func() {
+		if !kept {
+			hook.Cancel()
+			if tx == nil {
+				session.Close()
+			}
+		}
+	}()
+
+	script := &types.ScriptResult{
+		Statements: make([]types.StatementResult, 0, len(statements)),
+		Total:      len(statements),
+	}
+	start := time.Now()
+	for i, statement := range statements {
+		last := i == len(statements)-1
+		item := types.StatementResult{
+			Statement: statement.Text,
+			Start:     statement.Start,
+			End:       statement.End,
+		}
+
+		result, open, err := cs.runStatement(ctx, hook, conn, session, statement, values, req.PageSize, maxRows, maxBytes, last)
+		if err != nil {
+			item.Error = err.Error()
+			// A query canceled on the server may leave the session unusable
+			// and the transaction aborted, so the transaction is rolled back
+			if tx != nil && ctx.Err() != nil {
+				item.Error += " (the transaction was rolled back)"
+				_ = cs.transactions.finish(tx, "ROLLBACK")
+			}
+			script.Statements = append(script.Statements, item)
+			// A canceled or timed out script stops whatever was asked
+			if ctx.Err() != nil || !req.ContinueOnError {
+				break
+			}
+			continue
+		}
+
+		if tx != nil && domain.CheckReadOnly(statement.Text, conn.Dialect()) != nil {
+			tx.dirty = true
+		}
+
+		if open != nil {
+			kept = true
+			open.connectionID = req.ConnectionID
+			open.database = req.Database
+			open.hook = hook
+			if tx != nil {
+				open.drain = true
+			} else {
+				open.session = session
+			}
+			result.ResultID, err = cs.results.add(open)
+			if err != nil {
+				open.close()
+				return nil, err
+			}
+			if tx != nil {
+				tx.resultID = result.ResultID
+			}
+		}
+		item.Result = result
+		script.Statements = append(script.Statements, item)
+	}
+	script.Duration = time.Since(start).Milliseconds()
+
+	if tx != nil {
+		cs.transactions.touch(tx)
+		script.Transaction = tx.state()
+	}
+
+	return script, nil
+}
+
+// currentTransaction returns the open transaction of the database, locked,
+// beginning one for connections in manual-commit mode when begin is set. It
+// is nil when the script runs in autocommit.
+func (cs *ConnectionService) currentTransaction(
+	conn *domain.Connection,
+	cpy *domain.Connection,
+	dbService domain.DatabaseService,
+	database string,
+	begin bool,
+) (*transaction, error) {
+	for {
+		tx := cs.transactions.get(conn.ID(), database)
+		if tx == nil {
+			if !conn.ManualCommit() || !begin {
+				return nil, nil
+			}
+			var err error
+			if tx, err = cs.beginTransaction(conn.ID(), database, cpy, dbService); err != nil {
+				return nil, err
+			}
+		}
+
+		tx.mu.Lock()
+		// The transaction may have ended while waiting for it
+		if !tx.closed {
+			return tx, nil
+		}
+		tx.mu.Unlock()
+	}
+}
+
+// beginTransaction starts a transaction on a session taken out of the pool
+func (cs *ConnectionService) beginTransaction(
+	connectionID, database string,
+	cpy *domain.Connection,
+	dbService domain.DatabaseService,
+) (*transaction, error) {
+	session, err := openSession(context.Background(), dbService, cpy)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := cs.transactions.begin(connectionID, database, session, cpy.BeginStatement())
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	return tx, nil
+}
+
+// sessionTimeout bounds the wait for a session when every connection of the
+// pool is taken, by open results or transactions
+const sessionTimeout = 10 * time.Second
+
+// openSession takes a session out of the pool of the connection, failing
+// with a clear error when none is freed within sessionTimeout
+func openSession(ctx context.Context, dbService domain.DatabaseService, c *domain.Connection) (domain.QuerySession, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, sessionTimeout)
+	defer cancel()
+
+	session, err := dbService.OpenSession(waitCtx, c)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("no database session was freed within %s, close open results or transactions and try again", sessionTimeout)
+		}
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+	return session, nil
+}
+
+// BeginTransaction opens a transaction on a database of the connection. The
+// queries run in it until it is committed or rolled back, or rolled back
+// after staying idle or when the connection is disconnected.
+func (cs *ConnectionService) BeginTransaction(connectionID, database string) (*types.TransactionState, error) {
+	conn, dbService, err := cs.lookup(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	cpy := domain.CopyConnection(conn, database)
+	if err := dbService.Connect(cpy); err != nil {
+		return nil, fmt.Errorf("failed to connect to database %s: %w", database, err)
+	}
+
+	tx, err := cs.beginTransaction(connectionID, database, cpy, dbService)
+	if err != nil {
+		return nil, err
+	}
+	return tx.state(), nil
+}
+
+// Commit commits the open transaction of a database of the connection
+func (cs *ConnectionService) Commit(connectionID, database string) (*types.TransactionState, error) {
+	tx, err := cs.transactions.end(connectionID, database, "COMMIT")
+	if err != nil {
+		if errors.Is(err, ErrNoTransaction) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return tx.state(), nil
+}
+
+// Rollback rolls back the open transaction of a database of the connection
+func (cs *ConnectionService) Rollback(connectionID, database string) (*types.TransactionState, error) {
+	tx, err := cs.transactions.end(connectionID, database, "ROLLBACK")
+	if err != nil {
+		if errors.Is(err, ErrNoTransaction) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to roll back transaction: %w", err)
+	}
+	return tx.state(), nil
+}
+
+// runStatement runs a statement of a script with the values bound to its
+// placeholders and reads its first page, within the statement timeout of the
+// connection
+func (cs *ConnectionService) runStatement(
+	ctx context.Context,
+	hook *domain.CancelHook,
+	conn *domain.Connection,
+	session domain.QuerySession,
+	statement domain.ScriptStatement,
+	values map[string]interface{},
+	size, maxRows int,
+	maxBytes int64,
+	keep bool,
+) (*types.QueryResult, *openResult, error) {
+	text, args, err := domain.BindStatement(statement, conn.Dialect(), values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The timeout covers running the statement and reading its first page,
+	// the rows are then read at the pace pages are fetched
+	done := statementTimeout(ctx, conn, hook)
+
+	start := time.Now()
+	result, open, err := readFirstPage(ctx, session, text, args, pageSize(size), maxRows, maxBytes, keep)
+	if err := done(err); err != nil {
+		return nil, nil, err
+	}
+	result.Duration = time.Since(start).Milliseconds()
+
+	return result, open, nil
 }
 
-// ListConnections returns a list of saved connections
-func (cs *ConnectionService) ListConnections() ([]types.ConnectionSummary, error) {
+// statementTimeout cancels the statement running under the hook after the
+// statement timeout of the connection. The returned function stops the
+// timer and tells why a statement that failed was canceled.
+func statementTimeout(ctx context.Context, conn *domain.Connection, hook *domain.CancelHook) func(error) error {
+	var timedOut atomic.Bool
+	var timer *time.Timer
+	if timeout := conn.StatementTimeout(); timeout > 0 {
+		timer = time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			hook.Cancel()
+		})
+	}
+
+	return func(err error) error {
+		if timer != nil {
+			timer.Stop()
+		}
+		switch {
+		case err == nil:
+			return nil
+		case timedOut.Load():
+			return fmt.Errorf("query canceled after the statement timeout of %s: %w", conn.StatementTimeout(), err)
+		case ctx.Err() != nil:
+			return fmt.Errorf("query canceled: %w", err)
+		}
+		return err
+	}
+}
+
+// readFirstPage runs the statement and reads its first page. When keep is set,
+// a result with rows left is returned open for FetchResultPage, otherwise
+// the rows past the first page are skipped and the result is truncated.
+func readFirstPage(
+	ctx context.Context,
+	session domain.QuerySession,
+	statement string,
+	args []interface{},
+	size, maxRows int,
+	maxBytes int64,
+	keep bool,
+) (*types.QueryResult, *openResult, error) {
+	cursor, err := session.Query(ctx, statement, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	open := &openResult{
+		cursor:   cursor,
+		lastUsed: time.Now(),
+	}
+	rows, more, truncated, err := open.fetch(size, maxRows, maxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if more && !keep {
+		open.close()
+		more, truncated = false, true
+	}
+
+	result := &types.QueryResult{
+		Columns:      domain.ColumnNames(cursor.Columns()),
+		ColumnTypes:  resultColumns(cursor.Columns()),
+		Rows:         resultRows(rows),
+		RowsAffected: cursor.RowsAffected(),
+		HasMore:      more,
+		Truncated:    truncated,
+	}
+	if len(result.Columns) > 0 {
+		result.RowsAffected = int64(len(rows))
+	}
+	if result.Rows == nil {
+		result.Rows = [][]interface{}{}
+	}
+
+	if !more {
+		return result, nil, nil
+	}
+	return result, open, nil
+}
+
+// bindParameters converts the values given to the parameters of the script,
+// failing before anything runs when one has no value
+func bindParameters(statements []domain.ScriptStatement, given []types.QueryParameterValue) (map[string]interface{}, error) {
+	parameters, err := domain.ScriptParameters(statements)
+	if err != nil || len(parameters) == 0 {
+		return nil, err
+	}
+
+	values := make([]domain.ParameterValue, len(given))
+	for i, v := range given {
+		values[i] = domain.ParameterValue{Name: v.Name, Type: v.Type, Value: v.Value}
+	}
+	bound, err := domain.BindValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range parameters {
+		if _, ok := bound[p.Name]; !ok {
+			return nil, fmt.Errorf("no value given for parameter %s", p.Placeholder())
+		}
+	}
+	return bound, nil
+}
+
+// QueryParameters returns the placeholders of a query, for the UI to ask
+// their values
+func (cs *ConnectionService) QueryParameters(connectionID, query string) ([]types.QueryParameter, error) {
+	conn, err := cs.find(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := domain.ScriptParameters(domain.SplitScript(query, conn.Dialect()))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.QueryParameter, len(parameters))
+	for i, p := range parameters {
+		result[i] = types.QueryParameter{
+			Name:        p.Name,
+			Placeholder: p.Placeholder(),
+		}
+	}
+	return result, nil
+}
+
+// FetchResultPage reads the next page of rows of an open result
+func (cs *ConnectionService) FetchResultPage(resultID string, size int) (*types.QueryResult, error) {
+	open, err := cs.results.get(resultID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRows, maxBytes, err := cs.resultLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, more, truncated, err := open.fetch(pageSize(size), maxRows, maxBytes)
+	if err != nil {
+		cs.results.remove(resultID)
+		if errors.Is(err, ErrResultNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch rows: %w", err)
+	}
+	if !more {
+		cs.results.remove(resultID)
+	}
+
+	result := &types.QueryResult{
+		Columns:      domain.ColumnNames(open.cursor.Columns()),
+		ColumnTypes:  resultColumns(open.cursor.Columns()),
+		Rows:         resultRows(rows),
+		RowsAffected: int64(len(rows)),
+		Duration:     time.Since(start).Milliseconds(),
+		HasMore:      more,
+		Truncated:    truncated,
+	}
+	if more {
+		result.ResultID = resultID
+	}
+	if result.Rows == nil {
+		result.Rows = [][]interface{}{}
+	}
+
+	return result, nil
+}
+
+// CloseResult stops reading an open result and releases its session
+func (cs *ConnectionService) CloseResult(resultID string) {
+	cs.results.remove(resultID)
+}
+
+// resultLimits returns the caps of query results
+func (cs *ConnectionService) resultLimits() (int, int64, error) {
+	cfg, err := cs.configRepo.Find()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to find configuration: %w", err)
+	}
+	return cfg.MaxResultRows(), cfg.MaxResultBytes(), nil
+}
+
+// pageSize bounds the rows of a page asked for, zero meaning the default
+func pageSize(size int) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+	return min(size, maxPageSize)
+}
+
+// CancelQuery cancels the query running under the execution ID, on the
+// server as well
+func (cs *ConnectionService) CancelQuery(executionID string) error {
+	if err := cs.executions.cancel(executionID); err != nil {
+		if errors.Is(err, ErrExecutionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to cancel query on the server: %w", err)
+	}
+	return nil
+}
+
+// confirmationRequired builds the error asking to confirm the destructive
+// statements of a query, estimating the rows of updates and deletes when the
+// database can tell
+func (cs *ConnectionService) confirmationRequired(
+	conn *domain.Connection,
+	dbService domain.DatabaseService,
+	originalID, databaseName, query string,
+	statements []domain.DestructiveStatement,
+) error {
+	token, err := cs.confirmations.issue(originalID, databaseName, query)
+	if err != nil {
+		return err
+	}
+
+	estimator, canEstimate := dbService.(domain.RowEstimator)
+	confirmation := types.QueryConfirmation{
+		Token:      token,
+		ExpiresIn:  int(confirmationTTL.Seconds()),
+		Statements: make([]types.DestructiveStatement, 0, len(statements)),
+	}
+	for _, statement := range statements {
+		item := types.DestructiveStatement{
+			Statement: statement.Statement,
+			Kind:      statement.Keyword,
+			Risk:      statement.Risk,
+		}
+		if canEstimate && (statement.Keyword == "UPDATE" || statement.Keyword == "DELETE") {
+			if rows, err := estimator.EstimateRows(conn, statement.Statement); err == nil {
+				item.EstimatedRows = &rows
+			}
+		}
+		confirmation.Statements = append(confirmation.Statements, item)
+	}
+
+	return &ConfirmationRequiredError{Confirmation: confirmation}
+}
+
+// ListConnections returns the saved connections passing the filter, grouped
+// by folder or tag when the filter asks for it
+func (cs *ConnectionService) ListConnections(filter types.ConnectionFilter) ([]types.ConnectionSummary, []types.ConnectionGroup, error) {
+	if filter.GroupBy != "" && filter.GroupBy != "folder" && filter.GroupBy != "tag" {
+		return nil, nil, fmt.Errorf("unsupported grouping: %s", filter.GroupBy)
+	}
+
 	connections, err := cs.repo.List()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list connections: %w", err)
+		return nil, nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	domainFilter := domain.ConnectionFilter{
+		Folder:      filter.Folder,
+		Tag:         filter.Tag,
+		Environment: filter.Environment,
+		Search:      filter.Search,
+	}
+
+	summaries := []types.ConnectionSummary{}
+	for _, conn := range connections {
+		if domainFilter.Matches(conn) {
+			summaries = append(summaries, toConnectionSummary(conn))
+		}
+	}
+
+	if filter.GroupBy == "" {
+		return summaries, nil, nil
 	}
 
-	summaries := make([]types.ConnectionSummary, len(connections))
-	for i, conn := range connections {
-		summaries[i] = types.ConnectionSummary{
-			ID:   conn.ID(),
-			Host: conn.Host(),
-			Port: conn.Port(),
+	return summaries, groupConnections(summaries, filter.GroupBy), nil
+}
+
+// groupConnections groups the summaries by folder or by tag, a connection
+// with several tags showing in each of their groups
+func groupConnections(summaries []types.ConnectionSummary, groupBy string) []types.ConnectionGroup {
+	byKey := make(map[string][]types.ConnectionSummary)
+	for _, summary := range summaries {
+		keys := []string{summary.Folder}
+		if groupBy == "tag" {
+			keys = summary.Tags
+			if len(keys) == 0 {
+				keys = []string{""}
+			}
+		}
+		for _, key := range keys {
+			byKey[key] = append(byKey[key], summary)
 		}
 	}
 
-	return summaries, nil
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	groups := make([]types.ConnectionGroup, len(keys))
+	for i, key := range keys {
+		groups[i] = types.ConnectionGroup{
+			Key:         key,
+			Connections: byKey[key],
+		}
+	}
+	return groups
+}
+
+func toConnectionSummary(conn *domain.Connection) types.ConnectionSummary {
+	profile := conn.Profile()
+	tags := profile.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return types.ConnectionSummary{
+		ID:          conn.ID(),
+		Name:        conn.DisplayName(),
+		Vendor:      conn.Vendor(),
+		Host:        conn.Host(),
+		Port:        conn.Port(),
+		Database:    conn.Database(),
+		Folder:      profile.Folder,
+		Tags:        tags,
+		Environment: profile.Environment,
+		Color:       profile.Color,
+
+		PromptPassword: conn.PromptPassword(),
+		ReadOnly:       conn.ReadOnly(),
+		ManualCommit:   conn.ManualCommit(),
+	}
 }
 
 // AnalyzeConnectionMetadata analyzes the current connection and persists the metadata
 func (cs *ConnectionService) AnalyzeConnectionMetadata(id string) error {
-	conn, err := cs.repo.FindByID(id)
+	conn, err := cs.find(id)
 	if err != nil {
-		return fmt.Errorf("failed to find connection by ID: %w", err)
+		return err
 	}
 
 	// Use the domain method to analyze metadata
@@ -298,6 +1152,12 @@ func (cs *ConnectionService) GenerateQuery(id string, request types.GenerateQuer
 		return nil, fmt.Errorf("failed to generate query with AI: %w", err)
 	}
 
+	if conn.ReadOnly() {
+		if err := domain.CheckReadOnly(generatedQuery, conn.Dialect()); err != nil {
+			return nil, fmt.Errorf("generated query cannot run on this connection: %w", err)
+		}
+	}
+
 	return &types.GenerateQueryResult{
 		GeneratedQuery: generatedQuery,
 		OriginalPrompt: request.Prompt,
@@ -313,27 +1173,298 @@ func (cs *ConnectionService) DeleteConnection(id string) error {
 		return fmt.Errorf("connection with ID %s not found", id)
 	}
 
+	cs.results.closeConnection(id)
+	cs.transactions.closeConnection(id)
+	if dbService, err := cs.serviceFactory.NewDatabaseService(conn); err == nil {
+		if err := dbService.Disconnect(conn); err != nil {
+			return err
+		}
+	}
+
 	if err := cs.repo.DeleteByID(id); err != nil {
 		return fmt.Errorf("failed to delete connection: %w", err)
 	}
+	cs.credentials.forget(id)
 
 	if err := cs.metadataRepo.Delete(id); err != nil {
 		return fmt.Errorf("failed to delete connection metadata: %w", err)
 	}
 
+	// Snippets scoped to the connection could not run anywhere else
+	if err := cs.snippetRepo.DeleteByConnectionID(id); err != nil {
+		return fmt.Errorf("failed to delete connection snippets: %w", err)
+	}
+
 	return nil
 }
 
+// ParseConnectionString normalizes a connection string in any supported format
+// into the form fields it stands for
+func (cs *ConnectionService) ParseConnectionString(connStr string) (*types.DatabaseConfig, error) {
+	conn, err := domain.NewConnectionFromString("", connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := domainToConfig(conn)
+	return &config, nil
+}
+
+// FormatConnectionString renders a saved connection in one of the formats of
+// its vendor, for use in other tools
+func (cs *ConnectionService) FormatConnectionString(id, format string, includePassword bool) (string, error) {
+	conn, err := cs.repo.FindByID(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to find connection by ID: %w", err)
+	}
+	if conn == nil {
+		return "", fmt.Errorf("connection with ID %s not found", id)
+	}
+
+	if includePassword {
+		conn, err = conn.WithSecrets(cs.secrets)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return domain.FormatConnectionString(conn, format, includePassword)
+}
+
+// configProfile returns the presentation settings of a connection config
+func configProfile(config types.DatabaseConfig) domain.ConnectionProfile {
+	return domain.ConnectionProfile{
+		Name:        config.Name,
+		Folder:      config.Folder,
+		Tags:        config.Tags,
+		Environment: config.Environment,
+		Color:       config.Color,
+	}
+}
+
+// domainToConfig converts a domain.Connection into the form fields it stands for
+func domainToConfig(conn *domain.Connection) types.DatabaseConfig {
+	config := types.DatabaseConfig{
+		Vendor:      conn.Vendor(),
+		Host:        conn.Host(),
+		Port:        conn.Port(),
+		Database:    conn.Database(),
+		Username:    conn.Username(),
+		Password:    conn.Password(),
+		PassCommand: conn.PassCommand(),
+		PasswordRef: conn.PasswordRef(),
+		Arguments:   conn.Arguments(),
+
+		PromptPassword: conn.PromptPassword(),
+		ReadOnly:       conn.ReadOnly(),
+		ManualCommit:   conn.ManualCommit(),
+
+		StatementTimeout: int(conn.StatementTimeout() / time.Second),
+	}
+
+	profile := conn.Profile()
+	config.Name = profile.Name
+	config.Folder = profile.Folder
+	config.Tags = profile.Tags
+	config.Environment = profile.Environment
+	config.Color = profile.Color
+
+	for _, endpoint := range conn.FailoverHosts() {
+		config.FailoverHosts = append(config.FailoverHosts, types.Endpoint{Host: endpoint.Host, Port: endpoint.Port})
+	}
+
+	if t := conn.TLS(); t != nil {
+		config.SSLMode = t.Mode
+		config.TLS = &types.TLSConfig{
+			Mode:       t.Mode,
+			CAFile:     t.CAFile,
+			CertFile:   t.CertFile,
+			KeyFile:    t.KeyFile,
+			ServerName: t.ServerName,
+		}
+	}
+
+	if t := conn.SSHTunnel(); t != nil {
+		config.SSH = &types.SSHTunnelConfig{
+			Host:              t.Host,
+			Port:              t.Port,
+			User:              t.User,
+			Password:          t.Password,
+			PrivateKeyFile:    t.PrivateKeyFile,
+			Passphrase:        t.Passphrase,
+			PasswordRef:       t.PasswordRef,
+			PassphraseRef:     t.PassphraseRef,
+			AgentSocket:       t.AgentSocket,
+			KnownHostsFile:    t.KnownHostsFile,
+			KeepAliveInterval: int(t.KeepAliveInterval / time.Second),
+		}
+	}
+
+	return config
+}
+
+// maskConfig hides the secrets of a saved connection config from the UI
+func maskConfig(config types.DatabaseConfig) types.DatabaseConfig {
+	config.Password = maskSecret(config.Password)
+	if config.SSH != nil {
+		ssh := *config.SSH
+		ssh.Password = maskSecret(ssh.Password)
+		ssh.Passphrase = maskSecret(ssh.Passphrase)
+		config.SSH = &ssh
+	}
+	return config
+}
+
+// unmaskConfig puts back the stored secrets the UI sent masked
+func unmaskConfig(config *types.DatabaseConfig, stored *domain.Connection) {
+	config.Password = keepMaskedSecret(config.Password, stored.Password())
+	if config.SSH != nil {
+		var storedSSH domain.SSHTunnelConfig
+		if t := stored.SSHTunnel(); t != nil {
+			storedSSH = *t
+		}
+		config.SSH.Password = keepMaskedSecret(config.SSH.Password, storedSSH.Password)
+		config.SSH.Passphrase = keepMaskedSecret(config.SSH.Passphrase, storedSSH.Passphrase)
+	}
+}
+
+// withConnectionString fills the server fields of the config from a parsed
+// connection string. The arguments given with the form are added to those of
+// the string, and its TLS settings replace the string's. The SSH tunnel and
+// the other settings of the form are kept.
+func withConnectionString(config types.DatabaseConfig, parsed *domain.Connection) types.DatabaseConfig {
+	fromString := domainToConfig(parsed)
+	config.Vendor = fromString.Vendor
+	config.Host = fromString.Host
+	config.Port = fromString.Port
+	config.Database = fromString.Database
+	config.Username = fromString.Username
+	config.Password = fromString.Password
+	config.FailoverHosts = fromString.FailoverHosts
+
+	arguments := fromString.Arguments
+	for k, v := range config.Arguments {
+		arguments[k] = v
+	}
+	config.Arguments = arguments
+
+	if config.TLS == nil && config.SSLMode == "" {
+		config.TLS = fromString.TLS
+	}
+	return config
+}
+
 // Helper function to convert types.DatabaseConfig to domain.Connection
 func (cs *ConnectionService) configToDomainConnection(id string, config types.DatabaseConfig) (*domain.Connection, error) {
+	if config.StatementTimeout < 0 {
+		return nil, fmt.Errorf("statement timeout cannot be negative")
+	}
+
 	if config.UseConnectionString && config.ConnectionString != "" {
-		return domain.NewConnectionFromString(id, config.ConnectionString)
+		parsed, err := domain.NewConnectionFromString(id, config.ConnectionString)
+		if err != nil {
+			return nil, err
+		}
+		config = withConnectionString(config, parsed)
+	}
+
+	vendor, err := domain.LookupVendor(config.Vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsConfig *domain.TLSConfig
+	if config.TLS != nil || config.SSLMode != "" {
+		tlsConfig = &domain.TLSConfig{Mode: config.SSLMode}
+		if config.TLS != nil {
+			tlsConfig.CAFile = config.TLS.CAFile
+			tlsConfig.CertFile = config.TLS.CertFile
+			tlsConfig.KeyFile = config.TLS.KeyFile
+			tlsConfig.ServerName = config.TLS.ServerName
+			if config.TLS.Mode != "" {
+				tlsConfig.Mode = config.TLS.Mode
+			}
+		}
+		if tlsConfig.Mode == "" {
+			return nil, fmt.Errorf("TLS mode is required")
+		}
+		if len(vendor.SSLModes) == 0 {
+			return nil, fmt.Errorf("%s connections cannot use TLS", vendor.DisplayName)
+		}
+	}
+
+	sslMode := ""
+	if tlsConfig != nil {
+		sslMode = tlsConfig.Mode
+	}
+	if err := vendor.ValidateArguments(sslMode, config.Arguments); err != nil {
+		return nil, err
 	}
 
 	arguments := make(map[string]string)
-	if config.SSLMode != "" {
-		arguments["sslmode"] = config.SSLMode
+	for k, v := range config.Arguments {
+		arguments[k] = v
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = vendor.DefaultPort
+	}
+
+	conn, err := domain.NewConnection(id, vendor.Name, config.Host, port, config.Database, config.Username, config.Password, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.SetTLS(tlsConfig); err != nil {
+		return nil, err
+	}
+
+	conn.SetPassCommand(config.PassCommand)
+	if err := conn.SetPasswordRef(config.PasswordRef); err != nil {
+		return nil, err
+	}
+	conn.SetPromptPassword(config.PromptPassword)
+	conn.SetReadOnly(config.ReadOnly)
+	conn.SetManualCommit(config.ManualCommit)
+	conn.SetStatementTimeout(time.Duration(config.StatementTimeout) * time.Second)
+
+	if err := conn.SetProfile(configProfile(config)); err != nil {
+		return nil, err
+	}
+
+	if len(config.FailoverHosts) > 0 {
+		hosts := make([]domain.Endpoint, len(config.FailoverHosts))
+		for i, endpoint := range config.FailoverHosts {
+			hosts[i] = domain.Endpoint{Host: endpoint.Host, Port: endpoint.Port}
+			if hosts[i].Port == 0 {
+				hosts[i].Port = vendor.DefaultPort
+			}
+		}
+		conn.SetFailoverHosts(hosts)
+	}
+
+	if config.SSH != nil {
+		if !vendor.SupportsSSH {
+			return nil, fmt.Errorf("%s connections cannot use an SSH tunnel", vendor.DisplayName)
+		}
+
+		if err := conn.SetSSHTunnel(&domain.SSHTunnelConfig{
+			Host:              config.SSH.Host,
+			Port:              config.SSH.Port,
+			User:              config.SSH.User,
+			Password:          config.SSH.Password,
+			PrivateKeyFile:    config.SSH.PrivateKeyFile,
+			Passphrase:        config.SSH.Passphrase,
+			PasswordRef:       config.SSH.PasswordRef,
+			PassphraseRef:     config.SSH.PassphraseRef,
+			AgentSocket:       config.SSH.AgentSocket,
+			KnownHostsFile:    config.SSH.KnownHostsFile,
+			KeepAliveInterval: time.Duration(config.SSH.KeepAliveInterval) * time.Second,
+		}); err != nil {
+			return nil, err
+		}
 	}
 
-	return domain.NewConnection(id, config.Vendor, config.Host, config.Port, config.Database, config.Username, config.Password, arguments)
+	return conn, nil
 }
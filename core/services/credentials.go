@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCredentialsRequired is returned for connections that do not save their
+// password when it was not supplied yet
+var ErrCredentialsRequired = errors.New("password required to connect")
+
+// cachedPassword is a password supplied at connect time
+type cachedPassword struct {
+	password  string
+	connected bool
+	// expires is when the password is dropped after disconnecting, it is
+	// dropped right away when zero
+	expires time.Time
+}
+
+// credentialCache keeps in memory the passwords of connections that do not
+// save them. A password lives while its connection stays connected and, when
+// a TTL was given, until the TTL runs out.
+type credentialCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedPassword
+}
+
+func newCredentialCache() *credentialCache {
+	return &credentialCache{
+		entries: make(map[string]*cachedPassword),
+	}
+}
+
+// store keeps the password of a connection that just connected
+func (c *credentialCache) store(id, password string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cachedPassword{password: password, connected: true}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	c.entries[id] = entry
+}
+
+// get returns the password of the connection while it is still valid
+func (c *credentialCache) get(id string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[id]
+	if !exists {
+		return "", false
+	}
+	if !entry.connected && !time.Now().Before(entry.expires) {
+		delete(c.entries, id)
+		return "", false
+	}
+	return entry.password, true
+}
+
+// disconnect ends the session of the connection, keeping the password
+// only until its TTL runs out
+func (c *credentialCache) disconnect(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[id]
+	if !exists {
+		return
+	}
+	entry.connected = false
+	if !time.Now().Before(entry.expires) {
+		delete(c.entries, id)
+	}
+}
+
+// forget drops the password of the connection
+func (c *credentialCache) forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+}
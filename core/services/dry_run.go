@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// dryRun runs the statements in a transaction, or under a savepoint of the
+// open transaction of the database, reads the rows they change and rolls
+// them back. It stops at the first statement that fails.
+func (cs *ConnectionService) dryRun(
+	ctx context.Context,
+	hook *domain.CancelHook,
+	conn *domain.Connection,
+	cpy *domain.Connection,
+	dbService domain.DatabaseService,
+	database string,
+	statements []domain.ScriptStatement,
+	plans []*domain.DryRun,
+	values map[string]interface{},
+	size int,
+	maxBytes int64,
+) (*types.ScriptResult, error) {
+	if err := checkRollback(dbService, cpy, conn.Dialect(), statements...); err != nil {
+		return nil, fmt.Errorf("dry run refused: %w", err)
+	}
+
+	script := &types.ScriptResult{
+		Statements: make([]types.StatementResult, 0, len(statements)),
+		Total:      len(statements),
+	}
+
+	var err error
+	script.Transaction, err = cs.rolledBack(ctx, conn, cpy, dbService, database, func(session domain.QuerySession) {
+		start := time.Now()
+		for i, statement := range statements {
+			item := types.StatementResult{
+				Statement: statement.Text,
+				Start:     statement.Start,
+				End:       statement.End,
+			}
+
+			preview, err := previewStatement(ctx, hook, conn, session, plans[i], values, pageSize(size), maxBytes)
+			if err != nil {
+				item.Error = err.Error()
+				script.Statements = append(script.Statements, item)
+				break
+			}
+			item.Preview = preview
+			script.Statements = append(script.Statements, item)
+		}
+		script.Duration = time.Since(start).Milliseconds()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return script, nil
+}
+
+// checkRollback fails when a statement changes a table whose changes the
+// database keeps after a rollback, which dry runs and analyzed plans rely on
+func checkRollback(
+	dbService domain.DatabaseService,
+	cpy *domain.Connection,
+	dialect domain.SQLDialect,
+	statements ...domain.ScriptStatement,
+) error {
+	checker, ok := dbService.(domain.RollbackChecker)
+	if !ok {
+		return nil
+	}
+	for _, statement := range statements {
+		if tables := domain.ChangedTables(statement, dialect); len(tables) > 0 {
+			if err := checker.CheckRollback(cpy, tables); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// previewStatement runs a statement of a dry run and reads the rows it
+// changes, within the statement timeout of the connection
+func previewStatement(
+	ctx context.Context,
+	hook *domain.CancelHook,
+	conn *domain.Connection,
+	session domain.QuerySession,
+	plan *domain.DryRun,
+	values map[string]interface{},
+	size int,
+	maxBytes int64,
+) (*types.DryRunPreview, error) {
+	done := statementTimeout(ctx, conn, hook)
+
+	start := time.Now()
+	preview, err := readDryRun(ctx, session, conn.Dialect(), plan, values, size, maxBytes)
+	if err := done(err); err != nil {
+		return nil, err
+	}
+	preview.Duration = time.Since(start).Milliseconds()
+
+	return preview, nil
+}
+
+// readDryRun reads the rows the statement is about to change, runs it and
+// reads the rows it changed
+func readDryRun(
+	ctx context.Context,
+	session domain.QuerySession,
+	dialect domain.SQLDialect,
+	plan *domain.DryRun,
+	values map[string]interface{},
+	size int,
+	maxBytes int64,
+) (*types.DryRunPreview, error) {
+	preview := &types.DryRunPreview{}
+
+	var err error
+	if plan.Before != nil {
+		if preview.Before, err = readPreview(ctx, session, dialect, *plan.Before, false, values, size, maxBytes); err != nil {
+			return nil, fmt.Errorf("failed to read the rows before the change: %w", err)
+		}
+	}
+
+	result, err := readPreview(ctx, session, dialect, plan.Statement, plan.Counted, values, size, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	preview.RowsAffected = result.RowsAffected
+	switch {
+	case !plan.Returning:
+	case plan.Keyword == "DELETE":
+		preview.Before = result
+	default:
+		preview.After = result
+	}
+
+	if plan.After != nil {
+		if preview.After, err = readPreview(ctx, session, dialect, *plan.After, false, values, size, maxBytes); err != nil {
+			return nil, fmt.Errorf("failed to read the rows after the change: %w", err)
+		}
+	}
+
+	switch {
+	case preview.After != nil:
+	case plan.Keyword == "UPDATE":
+		preview.Note = "The SET clause changes columns the WHERE clause reads, so the updated rows cannot be selected again"
+	case plan.Keyword == "INSERT":
+		preview.Note = "The database cannot return the inserted rows, only their count"
+	}
+
+	return preview, nil
+}
+
+// readPreview runs a statement of a dry run and reads the first page of its
+// rows. The rows past it come from the statement when counted is set and
+// from a COUNT(*) of a SELECT. A statement returning the rows it changed
+// cannot run again, so the rest of its rows are read and counted.
+func readPreview(
+	ctx context.Context,
+	session domain.QuerySession,
+	dialect domain.SQLDialect,
+	statement domain.ScriptStatement,
+	counted bool,
+	values map[string]interface{},
+	size int,
+	maxBytes int64,
+) (*types.QueryResult, error) {
+	text, args, err := domain.BindStatement(statement, dialect, values)
+	if err != nil {
+		return nil, err
+	}
+	if counted {
+		// The rows past the page are not sent
+		text += fmt.Sprintf(" LIMIT %d", size+1)
+	}
+
+	cursor, err := session.Query(ctx, text, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	rows, more, err := cursor.Fetch(size)
+	if err != nil {
+		return nil, err
+	}
+	columns := cursor.Columns()
+	count := int64(len(rows))
+	truncated := more
+
+	switch {
+	case counted:
+		// The last column holds the count
+		columns = columns[:len(columns)-1]
+		if len(rows) > 0 {
+			if count, err = rowCount(rows[0][len(columns)]); err != nil {
+				return nil, err
+			}
+		}
+		for i, row := range rows {
+			rows[i] = row[:len(columns)]
+		}
+	case more && !pageable(text, dialect):
+		rest, err := countRest(cursor, size)
+		if err != nil {
+			return nil, err
+		}
+		count += rest
+	case more:
+		cursor.Close()
+		if count, err = countRows(ctx, session, dialect, text, args, size); err != nil {
+			return nil, err
+		}
+	}
+
+	var bytes int64
+	for i, row := range rows {
+		for _, value := range row {
+			bytes += valueSize(value)
+		}
+		if bytes > maxBytes {
+			rows = rows[:i+1]
+			truncated = true
+			break
+		}
+	}
+
+	result := &types.QueryResult{
+		Columns:      domain.ColumnNames(columns),
+		ColumnTypes:  resultColumns(columns),
+		Rows:         resultRows(rows),
+		RowsAffected: cursor.RowsAffected(),
+		Truncated:    truncated,
+	}
+	if len(result.Columns) > 0 {
+		result.RowsAffected = count
+	}
+	if result.Rows == nil {
+		result.Rows = [][]interface{}{}
+	}
+	return result, nil
+}
+
+// countRows counts the rows of a SELECT of a dry run. A database that cannot
+// count them with COUNT(*), as MySQL for a derived table whose columns share
+// a name, has them read and counted.
+func countRows(ctx context.Context, session domain.QuerySession, dialect domain.SQLDialect, text string, args []interface{}, size int) (int64, error) {
+	if query, ok := domain.PageableSelect(text, dialect); ok {
+		cursor, err := session.Query(ctx, "SELECT COUNT(*) FROM ("+query+") AS preview", args...)
+		if err == nil {
+			rows, _, err := cursor.Fetch(1)
+			cursor.Close()
+			if err == nil && len(rows) == 1 {
+				return rowCount(rows[0][0])
+			}
+		}
+	}
+
+	cursor, err := session.Query(ctx, text, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	return countRest(cursor, size)
+}
+
+// countRest reads the rows left in the cursor page by page and counts them
+func countRest(cursor domain.QueryCursor, size int) (int64, error) {
+	var count int64
+	for more := true; more; {
+		page, next, err := cursor.Fetch(size)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(page))
+		more = next
+	}
+	return count, nil
+}
+
+// pageable reports whether the statement is a SELECT, which can run again to
+// be counted
+func pageable(text string, dialect domain.SQLDialect) bool {
+	_, ok := domain.PageableSelect(text, dialect)
+	return ok
+}
+
+// rowCount reads a count of rows returned by the database
+func rowCount(value interface{}) (int64, error) {
+	count, ok := value.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected row count %v", value)
+	}
+	return count, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+	"sync"
+
+	"seagle/core/domain"
+)
+
+// ErrExecutionNotFound is returned when canceling a query that is not running
+var ErrExecutionNotFound = errors.New("query is not running")
+
+// executionRegistry keeps the cancel hooks of the running queries by
+// execution ID
+type executionRegistry struct {
+	mu      sync.Mutex
+	running map[string]*domain.CancelHook
+}
+
+func newExecutionRegistry() *executionRegistry {
+	return &executionRegistry{
+		running: make(map[string]*domain.CancelHook),
+	}
+}
+
+// start registers a running query, the returned function unregisters it
+func (r *executionRegistry) start(id string, hook *domain.CancelHook) (func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.running[id]; exists {
+		return nil, errors.New("a query with the same execution ID is already running")
+	}
+	r.running[id] = hook
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.running, id)
+	}, nil
+}
+
+// cancel cancels the query running under the execution ID
+func (r *executionRegistry) cancel(id string) error {
+	r.mu.Lock()
+	hook, exists := r.running[id]
+	r.mu.Unlock()
+
+	if !exists {
+		return ErrExecutionNotFound
+	}
+	return hook.Cancel()
+}
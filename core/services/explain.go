@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// ExplainQuery tells how the database runs a single statement. The plan is
+// read in a transaction, or under a savepoint of the open transaction, that
+// is rolled back, so analyzing an UPDATE or DELETE changes nothing. The
+// statements changing tables a rollback does not undo are not analyzed, and
+// destructive statements are analyzed on production connections only once
+// confirmed, as ExecuteQuery runs them.
+func (cs *ConnectionService) ExplainQuery(req types.ExplainRequest) (*types.QueryPlan, error) {
+	conn, dbService, err := cs.lookup(req.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	explainer, ok := dbService.(domain.QueryExplainer)
+	if !ok {
+		return nil, fmt.Errorf("%s cannot explain statements", conn.Vendor())
+	}
+
+	statements := domain.SplitScript(req.Query, conn.Dialect())
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("explain expects a single statement, the query has %d", len(statements))
+	}
+
+	values, err := bindParameters(statements, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	text, args, err := domain.BindStatement(statements[0], conn.Dialect(), values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Analyzing runs the statement
+	if conn.ReadOnly() && req.Analyze {
+		if err := domain.CheckReadOnly(req.Query, conn.Dialect()); err != nil {
+			return nil, err
+		}
+	}
+
+	cpy := domain.CopyConnection(conn, req.Database)
+
+	if err := dbService.Connect(cpy); err != nil {
+		return nil, fmt.Errorf("failed to connect to database %s: %w", req.Database, err)
+	}
+
+	// Analyzing is confirmed like a dry run, the rollback may not undo
+	// triggers, sequences or the locks the statement takes
+	if req.Analyze && conn.Profile().Environment == domain.EnvironmentProduction {
+		destructive := domain.FindDestructiveStatements(req.Query, conn.Dialect())
+		if len(destructive) > 0 && !cs.confirmations.consume(req.ConfirmationToken, req.ConnectionID, req.Database, req.Query) {
+			return nil, cs.confirmationRequired(cpy, dbService, req.ConnectionID, req.Database, req.Query, destructive)
+		}
+	}
+
+	// The analyzed statement is rolled back, which has to undo its changes
+	if req.Analyze {
+		if err := checkRollback(dbService, cpy, conn.Dialect(), statements[0]); err != nil {
+			return nil, fmt.Errorf("analyze refused: %w", err)
+		}
+	}
+
+	ctx, hook := domain.WithCancelHook(context.Background())
+	defer hook.Cancel()
+	if req.ExecutionID != "" {
+		finish, err := cs.executions.start(req.ExecutionID, hook)
+		if err != nil {
+			return nil, err
+		}
+		defer finish()
+	}
+
+	var plan *domain.QueryPlan
+	var explainErr error
+	var duration time.Duration
+	options := domain.ExplainOptions{Analyze: req.Analyze, Buffers: req.Buffers}
+	transaction, err := cs.rolledBack(ctx, conn, cpy, dbService, req.Database, func(session domain.QuerySession) {
+		done := statementTimeout(ctx, conn, hook)
+		start := time.Now()
+		plan, explainErr = explainer.ExplainQuery(ctx, session, text, args, options)
+		duration = time.Since(start)
+		explainErr = done(explainErr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if explainErr != nil {
+		return nil, explainErr
+	}
+
+	result := &types.QueryPlan{
+		Root:          planNode(plan.Root),
+		Analyzed:      plan.Analyzed,
+		PlanningTime:  plan.PlanningTime,
+		ExecutionTime: plan.ExecutionTime,
+		Hotspots:      make([]types.PlanHotspot, 0, len(plan.Hotspots)),
+		Raw:           plan.Raw,
+		Duration:      duration.Milliseconds(),
+		Transaction:   transaction,
+	}
+	for _, hotspot := range plan.Hotspots {
+		result.Hotspots = append(result.Hotspots, types.PlanHotspot(hotspot))
+	}
+
+	return result, nil
+}
+
+// planNode converts a node of a plan and its children to their DTO
+func planNode(node *domain.PlanNode) *types.PlanNode {
+	if node == nil {
+		return nil
+	}
+
+	result := &types.PlanNode{
+		ID:            node.ID,
+		NodeType:      node.NodeType,
+		Relation:      node.Relation,
+		Index:         node.Index,
+		Detail:        node.Detail,
+		FullScan:      node.FullScan,
+		EstimatedRows: node.EstimatedRows,
+		ActualRows:    node.ActualRows,
+		Loops:         node.Loops,
+		RowsScanned:   node.RowsScanned,
+		StartupCost:   node.StartupCost,
+		TotalCost:     node.TotalCost,
+		ActualTime:    node.ActualTime,
+		SelfTime:      node.SelfTime,
+		Children:      make([]*types.PlanNode, 0, len(node.Children)),
+	}
+	if node.Buffers != nil {
+		buffers := types.PlanBuffers(*node.Buffers)
+		result.Buffers = &buffers
+	}
+	for _, child := range node.Children {
+		result.Children = append(result.Children, planNode(child))
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// defaultHistoryLimit caps the entries a history search returns when the
+// request does not set one
+const defaultHistoryLimit = 200
+
+type HistoryService struct {
+	repo           domain.QueryHistoryRepo
+	connectionRepo domain.ConnectionRepo
+}
+
+func NewHistoryService(repo domain.QueryHistoryRepo, connectionRepo domain.ConnectionRepo) *HistoryService {
+	return &HistoryService{
+		repo:           repo,
+		connectionRepo: connectionRepo,
+	}
+}
+
+// SearchHistory returns the entries of the query history passing the
+// search, newest first
+func (s *HistoryService) SearchHistory(search types.HistorySearch) ([]types.HistoryEntry, error) {
+	filter := domain.HistoryFilter{
+		Text:         search.Text,
+		ConnectionID: search.ConnectionID,
+		Limit:        search.Limit,
+	}
+	if search.From > 0 {
+		filter.From = time.UnixMilli(search.From)
+	}
+	if search.To > 0 {
+		filter.To = time.UnixMilli(search.To)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultHistoryLimit
+	}
+
+	entries, err := s.repo.Search(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search history: %w", err)
+	}
+
+	names, err := s.connectionNames()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, historyEntry(entry, names))
+	}
+	return result, nil
+}
+
+// GetHistoryEntry returns an entry of the query history to open it again
+func (s *HistoryService) GetHistoryEntry(id string) (*types.HistoryEntry, error) {
+	entry, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("history entry not found: %s", id)
+	}
+
+	names, err := s.connectionNames()
+	if err != nil {
+		return nil, err
+	}
+
+	result := historyEntry(entry, names)
+	return &result, nil
+}
+
+// PruneHistory removes the entries older than the given days and then the
+// oldest entries until the history fits the given size. It returns how many
+// entries were removed.
+func (s *HistoryService) PruneHistory(prune types.HistoryPrune) (int, error) {
+	if prune.OlderThanDays <= 0 && prune.MaxBytes <= 0 {
+		return 0, errors.New("prune needs an age or a size to keep the history under")
+	}
+
+	var olderThan time.Time
+	if prune.OlderThanDays > 0 {
+		olderThan = time.Now().AddDate(0, 0, -prune.OlderThanDays)
+	}
+
+	removed, err := s.repo.Prune(olderThan, prune.MaxBytes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune history: %w", err)
+	}
+	return removed, nil
+}
+
+// connectionNames maps the IDs of the saved connections to their names
+func (s *HistoryService) connectionNames() (map[string]string, error) {
+	connections, err := s.connectionRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	names := make(map[string]string, len(connections))
+	for _, conn := range connections {
+		names[conn.ID()] = conn.DisplayName()
+	}
+	return names, nil
+}
+
+func historyEntry(entry *domain.HistoryEntry, names map[string]string) types.HistoryEntry {
+	return types.HistoryEntry{
+		ID:             entry.ID(),
+		ConnectionID:   entry.ConnectionID(),
+		ConnectionName: names[entry.ConnectionID()],
+		Database:       entry.Database(),
+		Query:          entry.Query(),
+		StartedAt:      entry.StartedAt().UnixMilli(),
+		Duration:       entry.Duration().Milliseconds(),
+		RowCount:       entry.RowCount(),
+		Error:          entry.Error(),
+		DryRun:         entry.DryRun(),
+	}
+}
+
+// recordHistory records an execution of ExecuteQuery in the query history,
+// the passwords it sets redacted. Scripts waiting for a confirmation did not
+// run. The history is best effort, failing to record a query does not fail
+// it.
+func (cs *ConnectionService) recordHistory(req types.QueryRequest, start time.Time, script *types.ScriptResult, err error) {
+	var confirmation *ConfirmationRequiredError
+	if cs.historyRepo == nil || errors.As(err, &confirmation) {
+		return
+	}
+
+	// Without its connection the query is lexed as plain SQL
+	var dialect domain.SQLDialect
+	if conn, _ := cs.repo.FindByID(req.ConnectionID); conn != nil {
+		dialect = conn.Dialect()
+	}
+
+	var rowCount int64
+	var message string
+	if err != nil {
+		message = err.Error()
+	}
+	if script != nil {
+		for _, statement := range script.Statements {
+			switch {
+			case statement.Error != "":
+				if message == "" {
+					message = statement.Error
+				}
+			case statement.Result != nil:
+				rowCount += statement.Result.RowsAffected
+			case statement.Preview != nil:
+				rowCount += statement.Preview.RowsAffected
+			}
+		}
+	}
+
+	entry := domain.NewHistoryEntry(
+		cs.historyRepo.NextID(),
+		req.ConnectionID,
+		req.Database,
+		domain.RedactCredentials(req.Query, dialect),
+		start,
+		time.Since(start),
+		rowCount,
+		message,
+		req.DryRun,
+	)
+	_ = cs.historyRepo.Append(entry)
+}
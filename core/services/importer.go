@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// ImportService imports connections saved by other tools
+type ImportService struct {
+	repo    domain.ConnectionRepo
+	sources []domain.ConnectionSource
+}
+
+// NewImportService creates a new ImportService instance
+func NewImportService(repo domain.ConnectionRepo, sources ...domain.ConnectionSource) *ImportService {
+	return &ImportService{
+		repo:    repo,
+		sources: sources,
+	}
+}
+
+// importCandidate is a connection found in a source, with what the dedupe
+// step learned about it
+type importCandidate struct {
+	kind        string
+	imported    domain.ImportedConnection
+	duplicateOf string
+}
+
+// ListSources returns the tools connections can be imported from
+func (s *ImportService) ListSources() []types.ImportSource {
+	result := make([]types.ImportSource, len(s.sources))
+	for i, source := range s.sources {
+		result[i] = types.ImportSource{
+			Kind:        source.Kind(),
+			Label:       source.Label(),
+			DefaultPath: source.DefaultPath(),
+		}
+	}
+	return result
+}
+
+// PreviewImport reads the requested sources and returns the connections they
+// hold, flagging those already saved or found twice. Sources that cannot be
+// read, and entries they skipped, are reported without failing the others.
+func (s *ImportService) PreviewImport(requests []types.ImportRequest) ([]types.ImportCandidate, []types.ImportError, error) {
+	candidates, importErrors, err := s.collect(requests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]types.ImportCandidate, len(candidates))
+	for i, candidate := range candidates {
+		conn := candidate.imported.Connection
+		config := domainToConfig(conn)
+		config.Password = ""
+
+		result[i] = types.ImportCandidate{
+			Key:         candidate.imported.Origin,
+			Kind:        candidate.kind,
+			Origin:      candidate.imported.Origin,
+			Config:      config,
+			HasPassword: conn.Password() != "",
+			Duplicate:   candidate.duplicateOf != "",
+			DuplicateOf: candidate.duplicateOf,
+		}
+	}
+
+	return result, importErrors, nil
+}
+
+// ImportConnections saves the candidates with the given keys under new IDs.
+// Duplicates are skipped even when selected.
+func (s *ImportService) ImportConnections(requests []types.ImportRequest, keys []string) ([]types.ConnectionSummary, []types.ImportError, error) {
+	candidates, importErrors, err := s.collect(requests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imported := []types.ConnectionSummary{}
+	for _, candidate := range candidates {
+		if candidate.duplicateOf != "" || !slices.Contains(keys, candidate.imported.Origin) {
+			continue
+		}
+
+		conn := candidate.imported.Connection.Duplicate(s.repo.NextID())
+		if err := s.repo.Save(conn); err != nil {
+			return nil, nil, fmt.Errorf("failed to save connection: %w", err)
+		}
+		imported = append(imported, toConnectionSummary(conn))
+	}
+
+	return imported, importErrors, nil
+}
+
+// collect reads the requested sources and dedupes what they hold against
+// the saved connections and against each other
+func (s *ImportService) collect(requests []types.ImportRequest) ([]importCandidate, []types.ImportError, error) {
+	saved, err := s.repo.List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	seen := make(map[string]string)
+	for _, conn := range saved {
+		seen[conn.Identity()] = conn.ID()
+	}
+
+	var candidates []importCandidate
+	importErrors := []types.ImportError{}
+	for _, request := range requests {
+		idx := slices.IndexFunc(s.sources, func(source domain.ConnectionSource) bool {
+			return source.Kind() == request.Kind
+		})
+		if idx < 0 {
+			return nil, nil, fmt.Errorf("unsupported import source: %s", request.Kind)
+		}
+		source := s.sources[idx]
+
+		path := request.Path
+		if path == "" {
+			path = source.DefaultPath()
+		}
+		path = expandHome(path)
+
+		imported, err := source.Read(path)
+		var skipped *domain.SkippedEntries
+		if errors.As(err, &skipped) {
+			for _, reason := range skipped.Reasons {
+				importErrors = append(importErrors, types.ImportError{
+					Kind:    request.Kind,
+					Path:    path,
+					Message: reason,
+				})
+			}
+			err = nil
+		}
+		if err != nil {
+			importErrors = append(importErrors, types.ImportError{
+				Kind:    request.Kind,
+				Path:    path,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		for _, entry := range imported {
+			identity := entry.Connection.Identity()
+			candidate := importCandidate{
+				kind:        request.Kind,
+				imported:    entry,
+				duplicateOf: seen[identity],
+			}
+			if candidate.duplicateOf == "" {
+				seen[identity] = entry.Origin
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, importErrors, nil
+}
+
+// expandHome resolves a leading ~ to the home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, rest)
+		}
+	}
+	return path
+}
@@ -16,14 +16,16 @@ import (
 // OpenAIClient handles communication with OpenAI API
 type OpenAIClient struct {
 	configRepo domain.ConfigRepo
+	secrets    domain.SecretResolver
 	baseURL    string
 	httpClient *http.Client
 }
 
 // NewOpenAIClient creates a new OpenAI client
-func NewOpenAIClient(configRepo domain.ConfigRepo) *OpenAIClient {
+func NewOpenAIClient(configRepo domain.ConfigRepo, secrets domain.SecretResolver) *OpenAIClient {
 	return &OpenAIClient{
 		configRepo: configRepo,
+		secrets:    secrets,
 		baseURL:    "https://api.openai.com/v1",
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
@@ -87,7 +89,10 @@ func (c *OpenAIClient) GenerateQuery(userPrompt string, metadata *domain.Connect
 	if cfg == nil || cfg.OpenAIAPIKey() == "" {
 		return "", fmt.Errorf("OpenAI API key not configured")
 	}
-	apiKey := cfg.OpenAIAPIKey()
+	apiKey, err := c.secrets.Resolve(cfg.OpenAIAPIKey())
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve OpenAI API key: %w", err)
+	}
 
 	// Build the system prompt with database metadata
 	systemPrompt := c.buildSystemPrompt(metadata, connection)
@@ -157,16 +162,11 @@ func (c *OpenAIClient) cleanSQLResponse(response string) string {
 
 // buildSystemPrompt creates a system prompt with database schema information
 func (c *OpenAIClient) buildSystemPrompt(metadata *domain.ConnectionMetadata, connection *domain.Connection) string {
-	databaseType := connection.Vendor()
-	var expertType string
-
-	switch databaseType {
-	case "postgresql":
-		expertType = "PostgreSQL"
-	case "mysql":
-		expertType = "MySQL"
-	default:
-		expertType = "SQL"
+	expertType := "SQL"
+	var hints []string
+	if vendor, err := domain.LookupVendor(connection.Vendor()); err == nil {
+		expertType = vendor.DisplayName
+		hints = vendor.PromptHints
 	}
 
 	prompt := fmt.Sprintf(`You are a %s expert. Generate SQL queries based on the user's natural language request.
@@ -200,52 +200,31 @@ Database Schema Information:
 	}
 
 	// Add database-specific rules
-	var rules string
-	switch databaseType {
-	case "postgresql":
-		rules = `
-Rules:
-1. Generate only PostgreSQL-compatible SQL queries
-2. Use proper table and column names from the schema above
-3. Include appropriate WHERE clauses, JOINs, and other SQL constructs as needed
-4. Return ONLY the raw SQL query without any markdown formatting, code blocks, or explanations
-5. DO NOT use code block markers or any markdown formatting
-6. Ensure the query is syntactically correct and executable
-7. Use meaningful aliases when joining tables
-8. Consider PostgreSQL-specific features like SERIAL, LIMIT, and case-sensitive identifiers
-9. Use double quotes for identifiers if needed
-10. Consider performance best practices
-
-Respond with only the raw SQL query, no formatting.`
-	case "mysql":
-		rules = `
-Rules:
-1. Generate only MySQL-compatible SQL queries
-2. Use proper table and column names from the schema above
-3. Include appropriate WHERE clauses, JOINs, and other SQL constructs as needed
-4. Return ONLY the raw SQL query without any markdown formatting, code blocks, or explanations
-5. DO NOT use code block markers or any markdown formatting
-6. Ensure the query is syntactically correct and executable
-7. Use meaningful aliases when joining tables
-8. Consider MySQL-specific features like AUTO_INCREMENT, LIMIT, and backticks for identifiers
-9. Use backticks for identifiers if needed
-10. Consider performance best practices
-
-Respond with only the raw SQL query, no formatting.`
-	default:
-		rules = `
-Rules:
-1. Generate standard SQL queries compatible with the database type
-2. Use proper table and column names from the schema above
-3. Include appropriate WHERE clauses, JOINs, and other SQL constructs as needed
-4. Return ONLY the raw SQL query without any markdown formatting, code blocks, or explanations
-5. DO NOT use code block markers or any markdown formatting
-6. Ensure the query is syntactically correct and executable
-7. Use meaningful aliases when joining tables
-8. Consider performance best practices
-
-Respond with only the raw SQL query, no formatting.`
+	compatibility := "Generate standard SQL queries compatible with the database type"
+	if expertType != "SQL" {
+		compatibility = fmt.Sprintf("Generate only %s-compatible SQL queries", expertType)
+	}
+
+	ruleLines := []string{
+		compatibility,
+		"Use proper table and column names from the schema above",
+		"Include appropriate WHERE clauses, JOINs, and other SQL constructs as needed",
+		"Return ONLY the raw SQL query without any markdown formatting, code blocks, or explanations",
+		"DO NOT use code block markers or any markdown formatting",
+		"Ensure the query is syntactically correct and executable",
+		"Use meaningful aliases when joining tables",
+	}
+	if connection.ReadOnly() {
+		ruleLines = append(ruleLines, "The connection is read-only: generate only SELECT queries, never statements that insert, update, delete or change the schema")
+	}
+	ruleLines = append(ruleLines, hints...)
+	ruleLines = append(ruleLines, "Consider performance best practices")
+
+	rules := "\nRules:\n"
+	for i, line := range ruleLines {
+		rules += fmt.Sprintf("%d. %s\n", i+1, line)
 	}
+	rules += "\nRespond with only the raw SQL query, no formatting."
 
 	prompt += rules
 
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+const (
+	// defaultPageSize and maxPageSize bound the rows of a result page
+	defaultPageSize = 500
+	maxPageSize     = 10000
+	// resultIdleTimeout closes results whose pages were not fetched for longer
+	resultIdleTimeout = 10 * time.Minute
+	// maxOpenResults closes the least recently used results of a database
+	// past it. Every open result holds a session of the pool of the database,
+	// the cap stays below its MaxOpenConns so transactions and metadata
+	// queries still find a connection.
+	maxOpenResults = 6
+)
+
+// ErrResultNotFound is returned when fetching a result that was read to the
+// end, closed or expired
+var ErrResultNotFound = errors.New("result not found, run the query again")
+
+// openResult is a query result whose rows are still being read
+type openResult struct {
+	mu           sync.Mutex
+	connectionID string
+	database     string
+	cursor       domain.QueryCursor
+	// hook and session are set for a result kept for FetchResultPage, which
+	// holds the session of its script
+	hook    *domain.CancelHook
+	session domain.QuerySession
+	// drain is set for a result read in a transaction, whose rows are read to
+	// the end on close, canceling the query on the server would abort the
+	// transaction
+	drain bool
+	// rows and bytes count what was read so far, against the caps
+	rows     int
+	bytes    int64
+	lastUsed time.Time
+	closed   bool
+}
+
+// fetch reads the next page of at most n rows, within the caps. The result
+// is closed once it is read to the end or truncated at a cap.
+func (r *openResult) fetch(n, maxRows int, maxBytes int64) (page [][]interface{}, more, truncated bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil, false, false, ErrResultNotFound
+	}
+	r.lastUsed = time.Now()
+
+	n = min(n, maxRows-r.rows)
+	page, more, err = r.cursor.Fetch(n)
+	if err != nil {
+		r.closeLocked()
+		return nil, false, false, err
+	}
+
+	for i, row := range page {
+		for _, value := range row {
+			r.bytes += valueSize(value)
+		}
+		if r.bytes > maxBytes {
+			page = page[:i+1]
+			more, truncated = false, true
+			break
+		}
+	}
+	r.rows += len(page)
+	if more && r.rows >= maxRows {
+		more, truncated = false, true
+	}
+
+	if !more {
+		r.closeLocked()
+	}
+	return page, more, truncated, nil
+}
+
+func (r *openResult) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeLocked()
+}
+
+// closeLocked cancels the query, in case its rows are still coming, and
+// releases the cursor and the session
+func (r *openResult) closeLocked() {
+	if r.closed {
+		return
+	}
+	r.closed = true
+	if r.drain {
+		r.cursor.Close()
+	}
+	if r.hook != nil {
+		r.hook.Cancel()
+	}
+	if !r.drain {
+		r.cursor.Close()
+	}
+	if r.session != nil {
+		r.session.Close()
+	}
+}
+
+// valueSize estimates the memory taken by a value of a row
+func valueSize(value interface{}) int64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case json.Number:
+		return int64(len(v))
+	case domain.TaggedValue:
+		return valueSize(v.Value)
+	case []interface{}:
+		var size int64
+		for _, element := range v {
+			size += valueSize(element)
+		}
+		return size
+	case map[string]interface{}:
+		var size int64
+		for key, element := range v {
+			size += int64(len(key)) + valueSize(element)
+		}
+		return size
+	default:
+		return 8
+	}
+}
+
+// resultColumns maps the columns of a result to their DTO
+func resultColumns(columns []domain.ColumnType) []types.ResultColumn {
+	result := make([]types.ResultColumn, len(columns))
+	for i, column := range columns {
+		result[i] = types.ResultColumn{
+			Name:         column.Name,
+			DatabaseType: column.DatabaseType,
+			Nullable:     column.Nullable,
+			Length:       column.Length,
+			Precision:    column.Precision,
+			Scale:        column.Scale,
+		}
+	}
+	return result
+}
+
+// resultRows maps the tagged values of the rows to their DTO, in place
+func resultRows(rows [][]interface{}) [][]interface{} {
+	for _, row := range rows {
+		for i, value := range row {
+			row[i] = resultValue(value)
+		}
+	}
+	return rows
+}
+
+func resultValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case domain.TaggedValue:
+		if v.Type == domain.ArrayValue {
+			return types.TaggedValue{Type: v.Type, Value: resultValue(v.Value)}
+		}
+		return types.TaggedValue{Type: v.Type, Value: v.Value}
+	case []interface{}:
+		// Elements of arrays, nested arrays included
+		mapped := make([]interface{}, len(v))
+		for i, element := range v {
+			mapped[i] = resultValue(element)
+		}
+		return mapped
+	}
+	return value
+}
+
+// resultStore keeps the results with rows left to fetch by result ID
+type resultStore struct {
+	mu      sync.Mutex
+	results map[string]*openResult
+}
+
+func newResultStore() *resultStore {
+	return &resultStore{
+		results: make(map[string]*openResult),
+	}
+}
+
+// add keeps an open result and returns its ID. Expired results are closed,
+// and the least recently used ones when too many hold sessions of the same
+// database.
+func (s *resultStore) add(r *openResult) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate result ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	s.mu.Lock()
+	s.results[id] = r
+	closing := s.evictLocked()
+	s.mu.Unlock()
+
+	for _, r := range closing {
+		r.close()
+	}
+	return id, nil
+}
+
+// evictLocked removes the results to close
+func (s *resultStore) evictLocked() []*openResult {
+	var closing []*openResult
+
+	type entry struct {
+		id       string
+		lastUsed time.Time
+	}
+	pools := make(map[string][]entry)
+	for id, r := range s.results {
+		// A result fetching a page is in use
+		if !r.mu.TryLock() {
+			continue
+		}
+		lastUsed, closed := r.lastUsed, r.closed
+		r.mu.Unlock()
+
+		if closed || time.Since(lastUsed) > resultIdleTimeout {
+			closing = append(closing, r)
+			delete(s.results, id)
+			continue
+		}
+		// Results read in a transaction share its session
+		if r.session != nil {
+			pool := r.connectionID + "/" + r.database
+			pools[pool] = append(pools[pool], entry{id: id, lastUsed: lastUsed})
+		}
+	}
+
+	for _, entries := range pools {
+		if len(entries) <= maxOpenResults {
+			continue
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].lastUsed.Before(entries[j].lastUsed)
+		})
+		for _, e := range entries[:len(entries)-maxOpenResults] {
+			closing = append(closing, s.results[e.id])
+			delete(s.results, e.id)
+		}
+	}
+
+	return closing
+}
+
+// get returns an open result
+func (s *resultStore) get(id string) (*openResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, exists := s.results[id]
+	if !exists {
+		return nil, ErrResultNotFound
+	}
+	return r, nil
+}
+
+// remove closes a result
+func (s *resultStore) remove(id string) {
+	s.mu.Lock()
+	r, exists := s.results[id]
+	delete(s.results, id)
+	s.mu.Unlock()
+
+	if exists {
+		r.close()
+	}
+}
+
+// closeConnection closes the results of a connection
+func (s *resultStore) closeConnection(connectionID string) {
+	s.mu.Lock()
+	var closing []*openResult
+	for id, r := range s.results {
+		if r.connectionID == connectionID {
+			closing = append(closing, r)
+			delete(s.results, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, r := range closing {
+		r.close()
+	}
+}
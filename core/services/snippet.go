@@ -0,0 +1,244 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+type SnippetService struct {
+	repo              domain.SnippetRepo
+	connectionRepo    domain.ConnectionRepo
+	connectionService *ConnectionService
+}
+
+func NewSnippetService(repo domain.SnippetRepo, connectionRepo domain.ConnectionRepo, connectionService *ConnectionService) *SnippetService {
+	return &SnippetService{
+		repo:              repo,
+		connectionRepo:    connectionRepo,
+		connectionService: connectionService,
+	}
+}
+
+// CreateSnippet saves a new snippet
+func (s *SnippetService) CreateSnippet(input types.SnippetInput) (*types.Snippet, error) {
+	if err := s.checkConnection(input.ConnectionID); err != nil {
+		return nil, err
+	}
+
+	snippet, err := domain.NewSnippet(s.repo.NextID(), input.Name, input.Description, input.Folder, input.ConnectionID, input.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(snippet); err != nil {
+		return nil, fmt.Errorf("failed to save snippet: %w", err)
+	}
+
+	result := snippetToDTO(snippet, s.dialect(snippet.ConnectionID()))
+	return &result, nil
+}
+
+// UpdateSnippet replaces the fields of a saved snippet
+func (s *SnippetService) UpdateSnippet(id string, input types.SnippetInput) (*types.Snippet, error) {
+	snippet, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkConnection(input.ConnectionID); err != nil {
+		return nil, err
+	}
+
+	if err := snippet.Update(input.Name, input.Description, input.Folder, input.ConnectionID, input.Query); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(snippet); err != nil {
+		return nil, fmt.Errorf("failed to save snippet: %w", err)
+	}
+
+	result := snippetToDTO(snippet, s.dialect(snippet.ConnectionID()))
+	return &result, nil
+}
+
+// DeleteSnippet removes a saved snippet
+func (s *SnippetService) DeleteSnippet(id string) error {
+	if _, err := s.find(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteByID(id); err != nil {
+		return fmt.Errorf("failed to delete snippet: %w", err)
+	}
+	return nil
+}
+
+// SearchSnippets returns the snippets passing the search, sorted by folder
+// and name
+func (s *SnippetService) SearchSnippets(search types.SnippetSearch) ([]types.Snippet, error) {
+	snippets, err := s.repo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snippets: %w", err)
+	}
+
+	dialects, err := s.dialects()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.SnippetFilter{
+		Text:         search.Text,
+		ConnectionID: search.ConnectionID,
+		Folder:       search.Folder,
+	}
+	result := []types.Snippet{}
+	for _, snippet := range snippets {
+		if !filter.Matches(snippet) {
+			continue
+		}
+		// Global snippets are read as SQL of the connection searched for
+		connectionID := snippet.ConnectionID()
+		if snippet.Global() {
+			connectionID = search.ConnectionID
+		}
+		result = append(result, snippetToDTO(snippet, dialects[connectionID]))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Folder != result[j].Folder {
+			return result[i].Folder < result[j].Folder
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// RunSnippet runs a snippet as ExecuteQuery does, its variables rewritten
+// into :name placeholders and their values bound as query parameters. It
+// returns the query that ran, so a confirmation of its destructive
+// statements can be sent with the same query and values.
+func (s *SnippetService) RunSnippet(req types.SnippetRun) (string, *types.ScriptResult, error) {
+	snippet, err := s.find(req.SnippetID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !snippet.Global() && snippet.ConnectionID() != req.ConnectionID {
+		return "", nil, fmt.Errorf("snippet %q belongs to another connection", snippet.Name())
+	}
+
+	conn, err := s.connectionRepo.FindByID(req.ConnectionID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find connection by ID: %w", err)
+	}
+	if conn == nil {
+		return "", nil, fmt.Errorf("connection with ID %s not found", req.ConnectionID)
+	}
+
+	query, names := snippet.Bind(conn.Dialect())
+	parameters := slices.Clone(req.Parameters)
+	var missing []string
+	for _, name := range names {
+		value, ok := req.Variables[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		parameters = append(parameters, types.QueryParameterValue{Name: name, Value: value})
+	}
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("missing value for snippet variables: %s", strings.Join(missing, ", "))
+	}
+
+	result, err := s.connectionService.ExecuteQuery(types.QueryRequest{
+		ConnectionID:      req.ConnectionID,
+		Database:          req.Database,
+		Query:             query,
+		ExecutionID:       req.ExecutionID,
+		PageSize:          req.PageSize,
+		ConfirmationToken: req.ConfirmationToken,
+		ContinueOnError:   req.ContinueOnError,
+		Parameters:        parameters,
+	})
+	return query, result, err
+}
+
+func (s *SnippetService) find(id string) (*domain.Snippet, error) {
+	snippet, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find snippet by ID: %w", err)
+	}
+	if snippet == nil {
+		return nil, fmt.Errorf("snippet with ID %s not found", id)
+	}
+	return snippet, nil
+}
+
+// checkConnection makes sure a snippet is scoped to a saved connection, an
+// empty ID making it global
+func (s *SnippetService) checkConnection(id string) error {
+	if id == "" {
+		return nil
+	}
+
+	conn, err := s.connectionRepo.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to find connection by ID: %w", err)
+	}
+	if conn == nil {
+		return fmt.Errorf("connection with ID %s not found", id)
+	}
+	return nil
+}
+
+// dialect returns the SQL dialect of a saved connection, plain SQL for global
+// snippets and unknown connections
+func (s *SnippetService) dialect(connectionID string) domain.SQLDialect {
+	if connectionID == "" {
+		return domain.SQLDialect{}
+	}
+	conn, err := s.connectionRepo.FindByID(connectionID)
+	if err != nil || conn == nil {
+		return domain.SQLDialect{}
+	}
+	return conn.Dialect()
+}
+
+// dialects maps the IDs of the saved connections to their SQL dialects, so
+// the connections are read once for many snippets. Global snippets and
+// unknown connections get the plain SQL zero value.
+func (s *SnippetService) dialects() (map[string]domain.SQLDialect, error) {
+	connections, err := s.connectionRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	dialects := make(map[string]domain.SQLDialect, len(connections))
+	for _, conn := range connections {
+		dialects[conn.ID()] = conn.Dialect()
+	}
+	return dialects, nil
+}
+
+func snippetToDTO(snippet *domain.Snippet, dialect domain.SQLDialect) types.Snippet {
+	variables := snippet.Variables(dialect)
+	if variables == nil {
+		variables = []string{}
+	}
+	return types.Snippet{
+		ID:           snippet.ID(),
+		Name:         snippet.Name(),
+		Description:  snippet.Description(),
+		Folder:       snippet.Folder(),
+		ConnectionID: snippet.ConnectionID(),
+		Query:        snippet.Query(),
+		Variables:    variables,
+		CreatedAt:    snippet.CreatedAt().UnixMilli(),
+		UpdatedAt:    snippet.UpdatedAt().UnixMilli(),
+	}
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// transactionIdleTimeout rolls back transactions no statement ran in for
+// longer, an open transaction holds its locks and a database session
+const transactionIdleTimeout = 10 * time.Minute
+
+// ErrNoTransaction is returned when committing or rolling back without an
+// open transaction
+var ErrNoTransaction = errors.New("no transaction is open")
+
+// transaction is a transaction left open across queries on a session taken
+// out of the pool
+type transaction struct {
+	mu           sync.Mutex
+	connectionID string
+	database     string
+	session      domain.QuerySession
+	// dirty is set once a statement that may write ran, until the
+	// transaction ends
+	dirty     bool
+	startedAt time.Time
+	// resultID is the result with rows left to fetch read in the
+	// transaction, closed before the next statement runs
+	resultID string
+	timer    *time.Timer
+	closed   bool
+}
+
+// state returns the DTO of the transaction
+func (t *transaction) state() *types.TransactionState {
+	return &types.TransactionState{
+		Active:    !t.closed,
+		Dirty:     t.dirty && !t.closed,
+		Database:  t.database,
+		StartedAt: t.startedAt.UnixMilli(),
+	}
+}
+
+// end commits or rolls back the transaction and gives its session back to
+// the pool. The session is released even when the statement fails, the
+// database rolls back what is left when it goes away.
+func (t *transaction) end(results *resultStore, statement string) error {
+	if t.closed {
+		return ErrNoTransaction
+	}
+	t.closed = true
+	t.timer.Stop()
+	if t.resultID != "" {
+		results.remove(t.resultID)
+	}
+
+	err := runCommand(t.session, statement)
+	if closeErr := t.session.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	return err
+}
+
+// rollbackSavepoint is the savepoint work that must leave no change rolls
+// back to in an open transaction
+const rollbackSavepoint = "seagle_rollback"
+
+// rolledBack runs fn in a transaction, or under a savepoint of the open
+// transaction of the database, and rolls back whatever it changed. It
+// returns the state of the open transaction, nil when there is none.
+func (cs *ConnectionService) rolledBack(
+	ctx context.Context,
+	conn *domain.Connection,
+	cpy *domain.Connection,
+	dbService domain.DatabaseService,
+	database string,
+	fn func(session domain.QuerySession),
+) (*types.TransactionState, error) {
+	var tx *transaction
+	if open := cs.transactions.get(conn.ID(), database); open != nil {
+		open.mu.Lock()
+		defer open.mu.Unlock()
+		if !open.closed {
+			tx = open
+		}
+	}
+
+	var session domain.QuerySession
+	begin, rollback := conn.BeginStatement(), "ROLLBACK"
+	if tx != nil {
+		// The rows left of the previous result hold the session
+		cs.results.remove(tx.resultID)
+		tx.resultID = ""
+		session = tx.session
+		begin, rollback = "SAVEPOINT "+rollbackSavepoint, "ROLLBACK TO SAVEPOINT "+rollbackSavepoint
+	} else {
+		var err error
+		session, err = openSession(ctx, dbService, cpy)
+		if err != nil {
+			return nil, err
+		}
+		defer session.Close()
+	}
+
+	if err := runCommand(session, begin); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	fn(session)
+
+	if err := runCommand(session, rollback); err != nil {
+		if tx != nil {
+			_ = cs.transactions.finish(tx, "ROLLBACK")
+			return nil, fmt.Errorf("failed to roll back changes, the transaction was rolled back: %w", err)
+		}
+		return nil, fmt.Errorf("failed to roll back changes: %w", err)
+	}
+
+	if tx == nil {
+		return nil, nil
+	}
+	_ = runCommand(session, "RELEASE SAVEPOINT "+rollbackSavepoint)
+	cs.transactions.touch(tx)
+	return tx.state(), nil
+}
+
+// runCommand runs a statement without rows on the session
+func runCommand(session domain.QuerySession, statement string) error {
+	cursor, err := session.Query(context.Background(), statement)
+	if err != nil {
+		return err
+	}
+	return cursor.Close()
+}
+
+// transactionStore keeps the open transactions by connection and database
+type transactionStore struct {
+	mu           sync.Mutex
+	transactions map[string]*transaction
+	results      *resultStore
+}
+
+func newTransactionStore(results *resultStore) *transactionStore {
+	return &transactionStore{
+		transactions: make(map[string]*transaction),
+		results:      results,
+	}
+}
+
+func transactionKey(connectionID, database string) string {
+	return connectionID + "\x00" + database
+}
+
+// get returns the open transaction of the database, nil when there is none
+func (s *transactionStore) get(connectionID, database string) *transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transactions[transactionKey(connectionID, database)]
+}
+
+// begin starts a transaction on the session with the begin statement. It
+// fails when the database has one open already. The transaction is reserved
+// locked while the statement runs outside the lock of the store, so callers
+// wanting it wait for it to begin and other databases are not held up.
+func (s *transactionStore) begin(connectionID, database string, session domain.QuerySession, begin string) (*transaction, error) {
+	key := transactionKey(connectionID, database)
+	t := &transaction{
+		connectionID: connectionID,
+		database:     database,
+		session:      session,
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s.mu.Lock()
+	if _, exists := s.transactions[key]; exists {
+		s.mu.Unlock()
+		return nil, errors.New("a transaction is already open on this database")
+	}
+	s.transactions[key] = t
+	s.mu.Unlock()
+
+	// A database that does not answer gives the reservation back in time
+	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
+	defer cancel()
+
+	cursor, err := session.Query(ctx, begin)
+	if err == nil {
+		err = cursor.Close()
+	}
+	if err != nil {
+		t.closed = true
+		s.mu.Lock()
+		if s.transactions[key] == t {
+			delete(s.transactions, key)
+		}
+		s.mu.Unlock()
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	t.startedAt = time.Now()
+	t.timer = time.AfterFunc(transactionIdleTimeout, func() {
+		s.expire(t)
+	})
+	return t, nil
+}
+
+// touch postpones the idle rollback of the transaction after it was used
+func (s *transactionStore) touch(t *transaction) {
+	if !t.closed {
+		t.timer.Reset(transactionIdleTimeout)
+	}
+}
+
+// expire rolls back a transaction that stayed idle. One running a query is
+// given another idle timeout instead.
+func (s *transactionStore) expire(t *transaction) {
+	if !t.mu.TryLock() {
+		t.timer.Reset(transactionIdleTimeout)
+		return
+	}
+	defer t.mu.Unlock()
+
+	_ = s.finish(t, "ROLLBACK")
+}
+
+// end commits or rolls back the open transaction of the database
+func (s *transactionStore) end(connectionID, database, statement string) (*transaction, error) {
+	t := s.get(connectionID, database)
+	if t == nil {
+		return nil, ErrNoTransaction
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t, s.finish(t, statement)
+}
+
+// finish removes the transaction from the store and commits or rolls it
+// back, the caller holds its lock
+func (s *transactionStore) finish(t *transaction, statement string) error {
+	key := transactionKey(t.connectionID, t.database)
+
+	s.mu.Lock()
+	// Another transaction may have taken its place once it ended
+	if s.transactions[key] == t {
+		delete(s.transactions, key)
+	}
+	s.mu.Unlock()
+
+	return t.end(s.results, statement)
+}
+
+// closeConnection rolls back the open transactions of a connection
+func (s *transactionStore) closeConnection(connectionID string) {
+	s.mu.Lock()
+	var closing []*transaction
+	for key, t := range s.transactions {
+		if t.connectionID == connectionID {
+			closing = append(closing, t)
+			delete(s.transactions, key)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, t := range closing {
+		t.mu.Lock()
+		_ = t.end(s.results, "ROLLBACK")
+		t.mu.Unlock()
+	}
+}
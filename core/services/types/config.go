@@ -0,0 +1,9 @@
+package types
+
+// Config is the application configuration
+type Config struct {
+	OpenAIAPIKey string `json:"openAIAPIKey"`
+	// MaxResultRows and MaxResultBytes cap query results
+	MaxResultRows  int   `json:"maxResultRows"`
+	MaxResultBytes int64 `json:"maxResultBytes"`
+}
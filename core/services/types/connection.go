@@ -2,15 +2,70 @@ package types
 
 // DatabaseConfig holds database connection parameters
 type DatabaseConfig struct {
-	Vendor              string `json:"vendor"`
-	Host                string `json:"host"`
-	Port                int    `json:"port"`
-	Database            string `json:"database"`
-	Username            string `json:"username"`
-	Password            string `json:"password"`
-	SSLMode             string `json:"sslmode"`
-	ConnectionString    string `json:"connectionString"`
-	UseConnectionString bool   `json:"useConnectionString"`
+	Vendor              string            `json:"vendor"`
+	Host                string            `json:"host"`
+	Port                int               `json:"port"`
+	Database            string            `json:"database"`
+	Username            string            `json:"username"`
+	Password            string            `json:"password"`
+	PassCommand         string            `json:"passCommand,omitempty"`
+	PasswordRef         string            `json:"passwordRef,omitempty"` // ${env:NAME} or file:path
+	PromptPassword      bool              `json:"promptPassword,omitempty"`
+	ReadOnly            bool              `json:"readOnly,omitempty"`
+	ManualCommit        bool              `json:"manualCommit,omitempty"`
+	StatementTimeout    int               `json:"statementTimeout,omitempty"` // in seconds, 0 for none
+	SSLMode             string            `json:"sslmode"`
+	Arguments           map[string]string `json:"arguments,omitempty"`
+	SSH                 *SSHTunnelConfig  `json:"ssh,omitempty"`
+	TLS                 *TLSConfig        `json:"tls,omitempty"`
+	FailoverHosts       []Endpoint        `json:"failoverHosts,omitempty"`
+	Name                string            `json:"name,omitempty"`
+	Folder              string            `json:"folder,omitempty"`
+	Tags                []string          `json:"tags,omitempty"`
+	Environment         string            `json:"environment,omitempty"`
+	Color               string            `json:"color,omitempty"`
+	ConnectionString    string            `json:"connectionString"`
+	UseConnectionString bool              `json:"useConnectionString"`
+}
+
+// SSHTunnelConfig holds the bastion host used to reach a database
+type SSHTunnelConfig struct {
+	Host              string `json:"host"`
+	Port              int    `json:"port"`
+	User              string `json:"user"`
+	Password          string `json:"password,omitempty"`
+	PrivateKeyFile    string `json:"privateKeyFile,omitempty"`
+	Passphrase        string `json:"passphrase,omitempty"`
+	PasswordRef       string `json:"passwordRef,omitempty"`
+	PassphraseRef     string `json:"passphraseRef,omitempty"`
+	AgentSocket       string `json:"agentSocket,omitempty"`
+	KnownHostsFile    string `json:"knownHostsFile,omitempty"`
+	KeepAliveInterval int    `json:"keepAliveInterval,omitempty"` // in seconds
+}
+
+// Endpoint is a host and port tried when the primary host is unreachable
+type Endpoint struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// TLSConfig holds the TLS settings of a database connection. Mode defaults to
+// the sslmode of the connection config.
+type TLSConfig struct {
+	Mode       string `json:"mode,omitempty"`
+	CAFile     string `json:"caFile,omitempty"`
+	CertFile   string `json:"certFile,omitempty"`
+	KeyFile    string `json:"keyFile,omitempty"`
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// TLSStatus describes the TLS session negotiated with a database server
+type TLSStatus struct {
+	Enabled bool   `json:"enabled"`
+	Version string `json:"version,omitempty"`
+	Cipher  string `json:"cipher,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Issuer  string `json:"issuer,omitempty"`
 }
 
 // DatabaseConnection represents a database connection
@@ -28,16 +83,162 @@ type TableColumn struct {
 	DefaultValue string `json:"defaultValue,omitempty"`
 }
 
-// QueryResult represents the result of a SQL query
+// QueryResult represents the result of a SQL query, or a page of its rows
 type QueryResult struct {
+	// ResultID fetches the next page of rows while HasMore is set
+	ResultID     string          `json:"resultId,omitempty"`
 	Columns      []string        `json:"columns"`
+	ColumnTypes  []ResultColumn  `json:"columnTypes"`
 	Rows         [][]interface{} `json:"rows"`
 	RowsAffected int64           `json:"rowsAffected"`
 	Duration     int64           `json:"duration"` // in milliseconds
+	HasMore      bool            `json:"hasMore"`
+	// Truncated tells the result reached the row or byte cap, the rows past
+	// it are not read
+	Truncated bool `json:"truncated"`
+}
+
+// ResultColumn describes a column of a query result, the sizes are left out
+// when the driver does not know them
+type ResultColumn struct {
+	Name string `json:"name"`
+	// DatabaseType is the type name reported by the driver, e.g. VARCHAR,
+	// NUMERIC, or _INT4 for an array of int4
+	DatabaseType string `json:"databaseType"`
+	Nullable     *bool  `json:"nullable,omitempty"`
+	Length       *int64 `json:"length,omitempty"`
+	Precision    *int64 `json:"precision,omitempty"`
+	Scale        *int64 `json:"scale,omitempty"`
+}
+
+// TaggedValue is a value of a query result that JSON cannot carry as is.
+// Type is binary (base64 bytes), decimal, integer or float (text), timestamp
+// (RFC 3339), date (YYYY-MM-DD), json (parsed document) or array (elements).
+type TaggedValue struct {
+	Type  string      `json:"$type"`
+	Value interface{} `json:"value"`
+}
+
+// StatementResult is the outcome of one statement of a script
+type StatementResult struct {
+	Statement string `json:"statement"`
+	// Start and End are the byte range of the statement in the script
+	Start  int          `json:"start"`
+	End    int          `json:"end"`
+	Result *QueryResult `json:"result,omitempty"`
+	Error  string       `json:"error,omitempty"`
+	// Preview is what the statement changed in a dry run
+	Preview *DryRunPreview `json:"preview,omitempty"`
+}
+
+// DryRunPreview shows the rows a data-modifying statement changed in a dry
+// run, whose changes were rolled back
+type DryRunPreview struct {
+	// Before holds the rows an UPDATE or DELETE changed, as they were
+	Before *QueryResult `json:"before,omitempty"`
+	// After holds the rows an UPDATE or INSERT changed, as they would be
+	After *QueryResult `json:"after,omitempty"`
+	// RowsAffected is the count of rows the statement changed, as reported
+	// by the database
+	RowsAffected int64 `json:"rowsAffected"`
+	// Note tells what could not be previewed
+	Note     string `json:"note,omitempty"`
+	Duration int64  `json:"duration"` // in milliseconds
+}
+
+// ScriptResult holds the outcome of the statements of a script that ran, in
+// order. Statements after a failed one are not run unless asked to continue.
+type ScriptResult struct {
+	Statements []StatementResult `json:"statements"`
+	// Total is the number of statements of the script
+	Total    int   `json:"total"`
+	Duration int64 `json:"duration"` // in milliseconds
+	// Transaction is the transaction the script ran in, nil in autocommit
+	Transaction *TransactionState `json:"transaction,omitempty"`
+}
+
+// TransactionState describes the transaction left open on a database of a
+// connection
+type TransactionState struct {
+	Active bool `json:"active"`
+	// Dirty is set once a statement that may write ran, those changes are
+	// uncommitted until the transaction is committed
+	Dirty     bool   `json:"dirty"`
+	Database  string `json:"database"`
+	StartedAt int64  `json:"startedAt"` // in milliseconds since the epoch
 }
 
 type ConnectionSummary struct {
-	ID   string `json:"id"`
-	Host string `json:"host"`
-	Port int    `json:"port"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Vendor      string   `json:"vendor"`
+	Host        string   `json:"host"`
+	Port        int      `json:"port"`
+	Database    string   `json:"database"`
+	Folder      string   `json:"folder"`
+	Tags        []string `json:"tags"`
+	Environment string   `json:"environment"`
+	Color       string   `json:"color"`
+	// PromptPassword tells the password has to be supplied when connecting
+	PromptPassword bool `json:"promptPassword"`
+	ReadOnly       bool `json:"readOnly"`
+	ManualCommit   bool `json:"manualCommit"`
+}
+
+// ConnectionFilter selects and groups saved connections. Empty fields match everything.
+type ConnectionFilter struct {
+	// Folder matches the folder and its subfolders
+	Folder      string `json:"folder,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	// Search matches the name, host or database, ignoring case
+	Search string `json:"search,omitempty"`
+	// GroupBy is "folder", "tag" or empty for no grouping
+	GroupBy string `json:"groupBy,omitempty"`
+}
+
+// ConnectionGroup holds the connections sharing a folder or a tag. Key is
+// empty for the connections at the root or without tags.
+type ConnectionGroup struct {
+	Key         string              `json:"key"`
+	Connections []ConnectionSummary `json:"connections"`
+}
+
+// QueryRequest is a query to run on a database of a saved connection
+type QueryRequest struct {
+	ConnectionID string `json:"connectionId"`
+	Database     string `json:"database"`
+	Query        string `json:"query"`
+	// ExecutionID names the execution, so it can be canceled while it runs
+	ExecutionID string `json:"executionId,omitempty"`
+	// PageSize is the rows of the first page, 500 when zero
+	PageSize int `json:"pageSize,omitempty"`
+	// ConfirmationToken confirms the destructive statements of the query
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+	// ContinueOnError runs the remaining statements of a script after one
+	// fails, instead of stopping there
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// Parameters are the values bound to the placeholders of the query
+	Parameters []QueryParameterValue `json:"parameters,omitempty"`
+	// DryRun runs the UPDATE, DELETE and INSERT statements of the query in a
+	// transaction that is always rolled back, previewing what they change
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// QueryParameter is a placeholder of a query the UI asks a value for
+type QueryParameter struct {
+	// Name is the name of a :name placeholder, or the number of $1 and ?
+	// placeholders
+	Name string `json:"name"`
+	// Placeholder is the parameter as written in the query, e.g. :from
+	Placeholder string `json:"placeholder"`
+}
+
+// QueryParameterValue is the value given to a parameter, as text of its type
+type QueryParameterValue struct {
+	Name string `json:"name"`
+	// Type is text, integer, number, boolean, date, timestamp or null, text
+	// when empty
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
 }
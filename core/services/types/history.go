@@ -0,0 +1,40 @@
+package types
+
+// HistoryEntry is a query that ran, as recorded in the query history
+type HistoryEntry struct {
+	ID           string `json:"id"`
+	ConnectionID string `json:"connectionId"`
+	// ConnectionName is empty when the connection was deleted since
+	ConnectionName string `json:"connectionName,omitempty"`
+	Database       string `json:"database"`
+	Query          string `json:"query"`
+	StartedAt      int64  `json:"startedAt"` // in milliseconds since the epoch
+	Duration       int64  `json:"duration"`  // in milliseconds
+	// RowCount sums the rows the statements returned or changed, counting
+	// the first page of the results read page by page
+	RowCount int64  `json:"rowCount"`
+	Error    string `json:"error,omitempty"`
+	DryRun   bool   `json:"dryRun,omitempty"`
+}
+
+// HistorySearch selects entries of the query history, empty fields match
+// every entry
+type HistorySearch struct {
+	// Text is searched in the SQL text, ignoring case
+	Text         string `json:"text,omitempty"`
+	ConnectionID string `json:"connectionId,omitempty"`
+	// From and To bound the start time in milliseconds since the epoch, To
+	// excluded
+	From int64 `json:"from,omitempty"`
+	To   int64 `json:"to,omitempty"`
+	// Limit caps the entries returned, newest first, 200 when zero
+	Limit int `json:"limit,omitempty"`
+}
+
+// HistoryPrune tells which entries of the query history to remove
+type HistoryPrune struct {
+	// OlderThanDays removes the entries started longer ago
+	OlderThanDays int `json:"olderThanDays,omitempty"`
+	// MaxBytes removes the oldest entries until the history fits
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
@@ -0,0 +1,38 @@
+package types
+
+// ImportSource describes a tool connections can be imported from
+type ImportSource struct {
+	Kind        string `json:"kind"`
+	Label       string `json:"label"`
+	DefaultPath string `json:"defaultPath"`
+}
+
+// ImportRequest selects a file to import connections from. The source
+// default path is read when Path is empty.
+type ImportRequest struct {
+	Kind string `json:"kind"`
+	Path string `json:"path,omitempty"`
+}
+
+// ImportCandidate is a connection found while previewing an import. The
+// config never carries the password, HasPassword tells whether one was found.
+type ImportCandidate struct {
+	// Key selects the candidate when importing
+	Key         string         `json:"key"`
+	Kind        string         `json:"kind"`
+	Origin      string         `json:"origin"`
+	Config      DatabaseConfig `json:"config"`
+	HasPassword bool           `json:"hasPassword"`
+	// Duplicate is set when a saved connection, or an earlier candidate,
+	// already reaches the same database with the same user
+	Duplicate   bool   `json:"duplicate"`
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+// ImportError reports a source that could not be read, or an entry of it
+// that was skipped
+type ImportError struct {
+	Kind    string `json:"kind"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,21 @@
+package types
+
+// QueryConfirmation asks to confirm destructive statements before running
+// them on a production connection
+type QueryConfirmation struct {
+	// Token runs the same query once when sent back with it
+	Token string `json:"token"`
+	// ExpiresIn is the seconds the token stays valid
+	ExpiresIn  int                    `json:"expiresIn"`
+	Statements []DestructiveStatement `json:"statements"`
+}
+
+// DestructiveStatement is a statement of the query that needs confirmation
+type DestructiveStatement struct {
+	Statement string `json:"statement"`
+	Kind      string `json:"kind"`
+	Risk      string `json:"risk"`
+	// EstimatedRows is the rows the database expects the statement to touch,
+	// when it can tell
+	EstimatedRows *int64 `json:"estimatedRows,omitempty"`
+}
@@ -0,0 +1,79 @@
+package types
+
+// ExplainRequest asks how the database runs a statement
+type ExplainRequest struct {
+	ConnectionID string `json:"connectionId"`
+	Database     string `json:"database"`
+	Query        string `json:"query"`
+	// Analyze runs the statement to measure it, in a transaction that is
+	// always rolled back
+	Analyze bool `json:"analyze,omitempty"`
+	// Buffers reports the blocks each node read and wrote, PostgreSQL only
+	Buffers bool `json:"buffers,omitempty"`
+	// Parameters are the values bound to the placeholders of the query
+	Parameters []QueryParameterValue `json:"parameters,omitempty"`
+	// ExecutionID names the execution, so it can be canceled while it runs
+	ExecutionID string `json:"executionId,omitempty"`
+	// ConfirmationToken confirms analyzing a destructive statement
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+}
+
+// QueryPlan is how the database runs a statement, in a tree the same for
+// every vendor
+type QueryPlan struct {
+	Root     *PlanNode `json:"root"`
+	Analyzed bool      `json:"analyzed"`
+	// PlanningTime and ExecutionTime are in milliseconds
+	PlanningTime  *float64 `json:"planningTime,omitempty"`
+	ExecutionTime *float64 `json:"executionTime,omitempty"`
+	// Hotspots are the nodes worth looking at first, by kind then weight
+	Hotspots []PlanHotspot `json:"hotspots"`
+	// Raw is the plan as the database printed it
+	Raw      string `json:"raw"`
+	Duration int64  `json:"duration"` // in milliseconds
+	// Transaction is the transaction the statement was explained in, nil in
+	// autocommit
+	Transaction *TransactionState `json:"transaction,omitempty"`
+}
+
+// PlanNode is a step of a plan. Rows are per loop, times are in
+// milliseconds over all loops.
+type PlanNode struct {
+	ID            int          `json:"id"`
+	NodeType      string       `json:"nodeType"`
+	Relation      string       `json:"relation,omitempty"`
+	Index         string       `json:"index,omitempty"`
+	Detail        string       `json:"detail,omitempty"`
+	FullScan      bool         `json:"fullScan,omitempty"`
+	EstimatedRows *float64     `json:"estimatedRows,omitempty"`
+	ActualRows    *float64     `json:"actualRows,omitempty"`
+	Loops         *float64     `json:"loops,omitempty"`
+	RowsScanned   *float64     `json:"rowsScanned,omitempty"`
+	StartupCost   *float64     `json:"startupCost,omitempty"`
+	TotalCost     *float64     `json:"totalCost,omitempty"`
+	ActualTime    *float64     `json:"actualTime,omitempty"`
+	SelfTime      *float64     `json:"selfTime,omitempty"`
+	Buffers       *PlanBuffers `json:"buffers,omitempty"`
+	Children      []*PlanNode  `json:"children"`
+}
+
+// PlanBuffers counts the blocks a node hit in cache, read, dirtied and
+// wrote, including its children
+type PlanBuffers struct {
+	SharedHit     int64 `json:"sharedHit"`
+	SharedRead    int64 `json:"sharedRead"`
+	SharedDirtied int64 `json:"sharedDirtied"`
+	SharedWritten int64 `json:"sharedWritten"`
+	TempRead      int64 `json:"tempRead"`
+	TempWritten   int64 `json:"tempWritten"`
+}
+
+// PlanHotspot is a node of a plan worth looking at first
+type PlanHotspot struct {
+	// Kind is self-time, row-estimate or full-scan
+	Kind   string  `json:"kind"`
+	NodeID int     `json:"nodeId"`
+	Value  float64 `json:"value"`
+	// Message explains the hotspot to the user
+	Message string `json:"message"`
+}
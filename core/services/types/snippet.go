@@ -0,0 +1,56 @@
+package types
+
+// Snippet is a saved query, global or scoped to a connection
+type Snippet struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Folder is a path of folder names separated by /, empty at the root
+	Folder string `json:"folder"`
+	// ConnectionID scopes the snippet to a connection, empty for a global one
+	ConnectionID string `json:"connectionId,omitempty"`
+	Query        string `json:"query"`
+	// Variables are the names of the ${name} variables of the query, outside
+	// its literals and comments
+	Variables []string `json:"variables"`
+	CreatedAt int64    `json:"createdAt"` // in milliseconds since the epoch
+	UpdatedAt int64    `json:"updatedAt"` // in milliseconds since the epoch
+}
+
+// SnippetInput holds the fields of a snippet to create or update
+type SnippetInput struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Folder       string `json:"folder,omitempty"`
+	ConnectionID string `json:"connectionId,omitempty"`
+	Query        string `json:"query"`
+}
+
+// SnippetSearch selects snippets, empty fields match every snippet
+type SnippetSearch struct {
+	// Text is searched in the name, description, folder and query
+	Text string `json:"text,omitempty"`
+	// ConnectionID keeps the global snippets and those of the connection
+	ConnectionID string `json:"connectionId,omitempty"`
+	// Folder keeps the snippets of the folder and its subfolders
+	Folder string `json:"folder,omitempty"`
+}
+
+// SnippetRun asks to run a snippet with its variables filled in
+type SnippetRun struct {
+	SnippetID    string `json:"snippetId"`
+	ConnectionID string `json:"connectionId"`
+	Database     string `json:"database"`
+	// Variables are the values of the ${name} variables, bound as text
+	// parameters of the query
+	Variables map[string]string `json:"variables,omitempty"`
+	// ExecutionID names the execution, so it can be canceled while it runs
+	ExecutionID string `json:"executionId,omitempty"`
+	// PageSize is the rows of the first page, 500 when zero
+	PageSize int `json:"pageSize,omitempty"`
+	// ConfirmationToken confirms the destructive statements of the query
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+	ContinueOnError   bool   `json:"continueOnError,omitempty"`
+	// Parameters are the values bound to the placeholders of the query
+	Parameters []QueryParameterValue `json:"parameters,omitempty"`
+}
@@ -0,0 +1,12 @@
+package types
+
+// MaskedSecret is sent to the UI in place of a stored secret. Sending it
+// back keeps the stored secret unchanged.
+const MaskedSecret = "********"
+
+// VaultStatus tells the UI whether it has to ask for the master passphrase
+type VaultStatus struct {
+	Initialized bool `json:"initialized"`
+	Locked      bool `json:"locked"`
+	RelockAfter int  `json:"relockAfter"` // in seconds
+}
@@ -0,0 +1,39 @@
+package types
+
+// VendorField describes an input of the connection form
+type VendorField struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Placeholder string `json:"placeholder,omitempty"`
+}
+
+// VendorArgument describes an extra driver argument accepted by a vendor
+type VendorArgument struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options,omitempty"`
+	Default     string   `json:"default,omitempty"`
+}
+
+// VendorFormat describes a connection string format understood by a vendor
+type VendorFormat struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+}
+
+// VendorCapabilities describes what the connection form offers for a vendor
+type VendorCapabilities struct {
+	Name        string           `json:"name"`
+	DisplayName string           `json:"displayName"`
+	Schemes     []string         `json:"schemes"`
+	DefaultPort int              `json:"defaultPort"`
+	Fields      []VendorField    `json:"fields"`
+	SSLModes    []string         `json:"sslModes"`
+	Arguments   []VendorArgument `json:"arguments"`
+	SupportsSSH bool             `json:"supportsSSH"`
+	Formats     []VendorFormat   `json:"formats"`
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// VaultService creates, unlocks and locks the credential vault
+type VaultService struct {
+	vault          domain.Vault
+	connectionRepo domain.ConnectionRepo
+	configRepo     domain.ConfigRepo
+}
+
+// NewVaultService creates a new VaultService instance
+func NewVaultService(vault domain.Vault, connectionRepo domain.ConnectionRepo, configRepo domain.ConfigRepo) *VaultService {
+	return &VaultService{
+		vault:          vault,
+		connectionRepo: connectionRepo,
+		configRepo:     configRepo,
+	}
+}
+
+// Status reports whether the vault exists and is unlocked
+func (s *VaultService) Status() types.VaultStatus {
+	return types.VaultStatus{
+		Initialized: s.vault.Initialized(),
+		Locked:      s.vault.Locked(),
+		RelockAfter: int(s.vault.RelockAfter().Seconds()),
+	}
+}
+
+// Create sets the master passphrase of a new vault and moves the secrets
+// still stored in plaintext into it
+func (s *VaultService) Create(passphrase string) error {
+	if err := s.vault.Initialize(passphrase); err != nil {
+		return err
+	}
+	return s.sealPlaintextSecrets()
+}
+
+// Unlock opens the vault with the master passphrase
+func (s *VaultService) Unlock(passphrase string) error {
+	if err := s.vault.Unlock(passphrase); err != nil {
+		return err
+	}
+	return s.sealPlaintextSecrets()
+}
+
+// Lock drops the vault key from memory
+func (s *VaultService) Lock() {
+	s.vault.Lock()
+}
+
+// sealPlaintextSecrets saves again the connections and configuration written
+// before the vault existed, which moves their secrets into it
+func (s *VaultService) sealPlaintextSecrets() error {
+	connections, err := s.connectionRepo.List()
+	if err != nil {
+		return fmt.Errorf("failed to list connections: %w", err)
+	}
+	for _, conn := range connections {
+		if hasPlaintextSecrets(conn) {
+			if err := s.connectionRepo.Save(conn); err != nil {
+				return fmt.Errorf("failed to move connection secrets into the vault: %w", err)
+			}
+		}
+	}
+
+	cfg, err := s.configRepo.Find()
+	if err != nil {
+		return fmt.Errorf("failed to find configuration: %w", err)
+	}
+	if cfg != nil && isPlaintext(cfg.OpenAIAPIKey()) {
+		if err := s.configRepo.Save(cfg); err != nil {
+			return fmt.Errorf("failed to move the OpenAI API key into the vault: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func hasPlaintextSecrets(conn *domain.Connection) bool {
+	if isPlaintext(conn.Password()) {
+		return true
+	}
+	t := conn.SSHTunnel()
+	return t != nil && (isPlaintext(t.Password) || isPlaintext(t.Passphrase))
+}
+
+func isPlaintext(secret string) bool {
+	return secret != "" && !domain.IsVaultRef(secret)
+}
+
+// maskSecret hides a stored secret from the UI, telling only whether it is
+// set
+func maskSecret(secret string) string {
+	if secret == "" {
+		return secret
+	}
+	return types.MaskedSecret
+}
+
+// keepMaskedSecret returns the stored secret when the UI sent it back masked
+func keepMaskedSecret(secret, stored string) string {
+	if secret == types.MaskedSecret {
+		return stored
+	}
+	return secret
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"seagle/core/domain"
+	"seagle/core/services/types"
+)
+
+// VendorService exposes the registered database vendors
+type VendorService struct{}
+
+// NewVendorService creates a new VendorService instance
+func NewVendorService() *VendorService {
+	return &VendorService{}
+}
+
+// ListVendors returns the connection form capabilities of every registered vendor
+func (s *VendorService) ListVendors() []types.VendorCapabilities {
+	vendors := domain.Vendors()
+
+	result := make([]types.VendorCapabilities, len(vendors))
+	for i, v := range vendors {
+		fields := make([]types.VendorField, len(v.Fields))
+		for j, f := range v.Fields {
+			fields[j] = types.VendorField{
+				Name:        f.Name,
+				Label:       f.Label,
+				Type:        f.Type,
+				Required:    f.Required,
+				Placeholder: f.Placeholder,
+			}
+		}
+
+		arguments := make([]types.VendorArgument, len(v.Arguments))
+		for j, a := range v.Arguments {
+			arguments[j] = types.VendorArgument{
+				Name:        a.Name,
+				Label:       a.Label,
+				Description: a.Description,
+				Type:        a.Type,
+				Options:     a.Options,
+				Default:     a.Default,
+			}
+		}
+
+		formats := make([]types.VendorFormat, len(v.Formats))
+		for j, f := range v.Formats {
+			formats[j] = types.VendorFormat{
+				Name:  f.Name,
+				Label: f.Label,
+			}
+		}
+
+		sslModes := v.SSLModes
+		if sslModes == nil {
+			sslModes = []string{}
+		}
+
+		result[i] = types.VendorCapabilities{
+			Name:        v.Name,
+			DisplayName: v.DisplayName,
+			Schemes:     v.Schemes,
+			DefaultPort: v.DefaultPort,
+			Fields:      fields,
+			SSLModes:    sslModes,
+			Arguments:   arguments,
+			SupportsSSH: v.SupportsSSH,
+			Formats:     formats,
+		}
+	}
+
+	return result
+}
@@ -1,6 +1,8 @@
 import { useState } from "react";
 import { DatabaseConnectionForm } from "./components/DatabaseConnectionForm";
 import { MainLayout } from "./components/MainLayout";
+import { PasswordPrompt } from "./components/PasswordPrompt";
+import { VaultGate } from "./components/VaultGate";
 import { WelcomeScreen } from "./components/WelcomeScreen";
 import { Button } from "./components/ui/button";
 import { ThemeProvider } from "./contexts/ThemeContext";
@@ -17,16 +19,40 @@ function AppContent() {
 	const { setDatabases, resetState } = useDatabaseStore();
 	const { setConnectingId, refreshConnections } = useConnectionsStore();
 	const { state: activeConnection, setConnection, clearConnection } = useActiveConnectionStore();
+	const [passwordPrompt, setPasswordPrompt] = useState<{
+		id: string;
+		message?: string;
+	} | null>(null);
 
 	const handleNewConnection = () => {
 		setCurrentScreen("connection");
 	};
 
-	const handleConnectToSaved = async (connectionId: string) => {
+	const handleConnectToSaved = async (
+		connectionId: string,
+		password?: string,
+		rememberFor?: number,
+	) => {
 		try {
 			setConnectingId(connectionId);
-			const result = await ConnectByID({ id: connectionId });
-			
+			const result = await ConnectByID({
+				id: connectionId,
+				password,
+				rememberFor,
+			});
+
+			if (result.credentialsRequired) {
+				// Ask again, telling why when a password was already given
+				setPasswordPrompt({
+					id: connectionId,
+					message: password ? result.message : undefined,
+				});
+				return;
+			}
+			if (password) {
+				setPasswordPrompt(null);
+			}
+
 			if (result.success) {
 				setCurrentScreen("connected");
 				setConnection(connectionId); // Store the connection ID globally
@@ -74,10 +100,21 @@ function AppContent() {
 
 	if (currentScreen === "welcome") {
 		return (
-			<WelcomeScreen 
-				onNewConnection={handleNewConnection}
-				onConnectToSaved={handleConnectToSaved}
-			/>
+			<>
+				<WelcomeScreen 
+					onNewConnection={handleNewConnection}
+					onConnectToSaved={handleConnectToSaved}
+				/>
+				{passwordPrompt && (
+					<PasswordPrompt
+						message={passwordPrompt.message}
+						onSubmit={(password, rememberFor) =>
+							handleConnectToSaved(passwordPrompt.id, password, rememberFor)
+						}
+						onCancel={() => setPasswordPrompt(null)}
+					/>
+				)}
+			</>
 		);
 	}
 
@@ -113,7 +150,9 @@ function App() {
 			<ActiveConnectionProvider>
 				<ConnectionsProvider>
 					<DatabaseProvider>
-						<AppContent />
+						<VaultGate>
+							<AppContent />
+						</VaultGate>
 					</DatabaseProvider>
 				</ConnectionsProvider>
 			</ActiveConnectionProvider>
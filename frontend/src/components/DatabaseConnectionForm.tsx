@@ -1,8 +1,10 @@
 import type React from "react";
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import { Connect } from "../../wailsjs/go/handlers/ConnectHandler";
 import { Disconnect } from "../../wailsjs/go/handlers/DisconnectHandler";
+import { ListVendors } from "../../wailsjs/go/handlers/ListVendorsHandler";
 import { TestConnection } from "../../wailsjs/go/handlers/TestConnectionHandler";
+import type { types } from "../../wailsjs/go/models";
 import { Button } from "./ui/button";
 import { Input } from "./ui/input";
 import { Label } from "./ui/label";
@@ -12,17 +14,20 @@ interface DatabaseConnectionFormProps {
 }
 
 interface DatabaseConfig {
-	vendor: "postgresql" | "mysql";
+	vendor: string;
 	host: string;
 	port: number;
 	database: string;
 	username: string;
 	password: string;
-	sslmode: "disable" | "require" | "verify-ca" | "verify-full";
+	sslmode: string;
+	arguments: Record<string, string>;
 	connectionString: string;
 	useConnectionString: boolean;
 }
 
+type FormFieldName = "host" | "port" | "database" | "username" | "password";
+
 export const DatabaseConnectionForm: React.FC<DatabaseConnectionFormProps> = ({
 	onConnectionChange,
 }) => {
@@ -34,42 +39,64 @@ export const DatabaseConnectionForm: React.FC<DatabaseConnectionFormProps> = ({
 		username: "",
 		password: "",
 		sslmode: "require",
+		arguments: {},
 		connectionString: "",
 		useConnectionString: false,
 	});
+	const [vendors, setVendors] = useState<types.VendorCapabilities[]>([]);
 	const [loading, setLoading] = useState(false);
 	const [connected, setConnected] = useState(false);
 	const [error, setError] = useState<string | null>(null);
 	const [connectionId, setConnectionId] = useState<string | null>(null);
 
+	const selectedVendor = vendors.find((v) => v.name === config.vendor);
+
+	useEffect(() => {
+		ListVendors()
+			.then((result) => setVendors(result?.vendors || []))
+			.catch((err) => setError(err as string));
+	}, []);
+
 	const handleInputChange = (
 		field: keyof DatabaseConfig,
 		value: string | number | boolean,
 	) => {
 		setConfig((prev) => {
 			const newConfig = { ...prev, [field]: value };
-			
-			// Update default port when vendor changes
+
+			// Reset vendor specific values when vendor changes
 			if (field === "vendor") {
-				if (value === "postgresql") {
-					newConfig.port = 5432;
-				} else if (value === "mysql") {
-					newConfig.port = 3306;
-				}
+				const vendor = vendors.find((v) => v.name === value);
+				newConfig.port = vendor?.defaultPort || 0;
+				newConfig.sslmode = vendor?.sslModes?.[0] || "";
+				newConfig.arguments = {};
 			}
-			
+
 			return newConfig;
 		});
 	};
 
+	const handleArgumentChange = (name: string, value: string) => {
+		setConfig((prev) => {
+			const args = { ...prev.arguments };
+			if (value === "") {
+				delete args[name];
+			} else {
+				args[name] = value;
+			}
+			return { ...prev, arguments: args };
+		});
+	};
+
 	const handleTestConnection = async () => {
 		setLoading(true);
 		setError(null);
 
 		try {
-			await TestConnection(config);
+			const result = await TestConnection(config);
 			setError(null);
-			alert("Connection test successful!");
+			const tls = result.tls?.enabled ? `\nTLS: ${result.tls.version}${result.tls.subject ? ` (${result.tls.subject})` : ""}` : "";
+			alert(`Connection test successful!${tls}`);
 		} catch (err) {
 			setError(err as string);
 		} finally {
@@ -166,12 +193,15 @@ export const DatabaseConnectionForm: React.FC<DatabaseConnectionFormProps> = ({
 				<select
 					id="vendor"
 					value={config.vendor}
-					onChange={(e) => handleInputChange("vendor", e.target.value as "postgresql" | "mysql")}
+					onChange={(e) => handleInputChange("vendor", e.target.value)}
 					disabled={connected || loading}
 					className="mt-1 block w-full rounded-md border border-gray-300 bg-white px-3 py-2 text-gray-900 shadow-sm focus:border-blue-500 focus:outline-none focus:ring-blue-500 dark:border-gray-600 dark:bg-gray-700 dark:text-gray-100 dark:focus:border-blue-400"
 				>
-					<option value="postgresql">PostgreSQL</option>
-					<option value="mysql">MySQL</option>
+					{vendors.map((vendor) => (
+						<option key={vendor.name} value={vendor.name}>
+							{vendor.displayName}
+						</option>
+					))}
 				</select>
 			</div>
 
@@ -212,95 +242,92 @@ export const DatabaseConnectionForm: React.FC<DatabaseConnectionFormProps> = ({
 				</div>
 			) : (
 				<div className="grid grid-cols-2 gap-4">
-					<div>
-						<Label htmlFor="host" className="text-gray-700 dark:text-gray-300">
-							Host
-						</Label>
-						<Input
-							id="host"
-							type="text"
-							value={config.host}
-							onChange={(e) => handleInputChange("host", e.target.value)}
-							disabled={connected || loading}
-							className="text-foreground"
-						/>
-					</div>
-
-					<div>
-						<Label htmlFor="port" className="text-gray-700 dark:text-gray-300">
-							Port
-						</Label>
-						<Input
-							id="port"
-							type="number"
-							value={config.port}
-							onChange={(e) =>
-								handleInputChange("port", Number.parseInt(e.target.value))
-							}
-							disabled={connected || loading}
-							className="text-foreground"
-						/>
-					</div>
-
-					<div>
-						<Label htmlFor="database" className="text-gray-700 dark:text-gray-300">
-							Database
-						</Label>
-						<Input
-							id="database"
-							type="text"
-							value={config.database}
-							onChange={(e) => handleInputChange("database", e.target.value)}
-							disabled={connected || loading}
-							className="text-foreground"
-						/>
-					</div>
-
-					<div>
-						<Label htmlFor="username" className="text-gray-700 dark:text-gray-300">
-							Username
-						</Label>
-						<Input
-							id="username"
-							type="text"
-							value={config.username}
-							onChange={(e) => handleInputChange("username", e.target.value)}
-							disabled={connected || loading}
-							className="text-foreground"
-						/>
-					</div>
+					{selectedVendor?.fields.map((field) => (
+						<div
+							key={field.name}
+							className={field.type === "file" ? "col-span-2" : undefined}
+						>
+							<Label htmlFor={field.name} className="text-gray-700 dark:text-gray-300">
+								{field.label}
+							</Label>
+							<Input
+								id={field.name}
+								type={field.type === "file" ? "text" : field.type}
+								value={config[field.name as FormFieldName]}
+								placeholder={field.placeholder}
+								required={field.required}
+								onChange={(e) =>
+									handleInputChange(
+										field.name as FormFieldName,
+										field.type === "number"
+											? Number.parseInt(e.target.value)
+											: e.target.value,
+									)
+								}
+								disabled={connected || loading}
+								className="text-foreground"
+							/>
+						</div>
+					))}
 
-					<div>
-						<Label htmlFor="password" className="text-gray-700 dark:text-gray-300">
-							Password
-						</Label>
-						<Input
-							id="password"
-							type="password"
-							value={config.password}
-							onChange={(e) => handleInputChange("password", e.target.value)}
-							disabled={connected || loading}
-							className="text-foreground"
-						/>
-					</div>
+					{selectedVendor && selectedVendor.sslModes.length > 0 && (
+						<div className="col-span-2">
+							<Label htmlFor="sslmode" className="text-gray-700 dark:text-gray-300">
+								SSL Mode
+							</Label>
+							<select
+								id="sslmode"
+								value={config.sslmode}
+								onChange={(e) => handleInputChange("sslmode", e.target.value)}
+								disabled={connected || loading}
+								className="flex h-10 w-full rounded-md border border-input bg-background px-3 py-2 text-foreground text-sm ring-offset-background file:border-0 file:bg-transparent file:font-medium file:text-sm placeholder:text-muted-foreground focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2 disabled:cursor-not-allowed disabled:opacity-50"
+							>
+								{selectedVendor.sslModes.map((mode) => (
+									<option key={mode} value={mode}>
+										{mode}
+									</option>
+								))}
+							</select>
+						</div>
+					)}
 
-					<div className="col-span-2">
-						<Label htmlFor="sslmode" className="text-gray-700 dark:text-gray-300">
-							SSL Mode
-						</Label>
-						<select
-							id="sslmode"
-							value={config.sslmode}
-							onChange={(e) => handleInputChange("sslmode", e.target.value)}
-							disabled={connected || loading}
-							className="flex h-10 w-full rounded-md border border-input bg-background px-3 py-2 text-foreground text-sm ring-offset-background file:border-0 file:bg-transparent file:font-medium file:text-sm placeholder:text-muted-foreground focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2 disabled:cursor-not-allowed disabled:opacity-50"
-						>
-							<option value="disable">Disable</option>
-							<option value="require">Require</option>
-							<option value="verify-ca">Verify CA</option>
-							<option value="verify-full">Verify Full</option>
-						</select>
-					</div>
+					{selectedVendor?.arguments.map((arg) => (
+						<div key={arg.name}>
+							<Label htmlFor={`arg-${arg.name}`} className="text-gray-700 dark:text-gray-300">
+								{arg.label}
+							</Label>
+							{arg.type === "enum" || arg.type === "bool" ? (
+								<select
+									id={`arg-${arg.name}`}
+									value={config.arguments[arg.name] || ""}
+									onChange={(e) => handleArgumentChange(arg.name, e.target.value)}
+									disabled={connected || loading}
+									title={arg.description}
+									className="flex h-10 w-full rounded-md border border-input bg-background px-3 py-2 text-foreground text-sm ring-offset-background focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring focus-visible:ring-offset-2 disabled:cursor-not-allowed disabled:opacity-50"
+								>
+									<option value="">Default{arg.default ? ` (${arg.default})` : ""}</option>
+									{(arg.type === "bool" ? ["true", "false"] : arg.options || []).map(
+										(option) => (
+											<option key={option} value={option}>
+												{option}
+											</option>
+										),
+									)}
+								</select>
+							) : (
+								<Input
+									id={`arg-${arg.name}`}
+									type={arg.type === "int" ? "number" : "text"}
+									value={config.arguments[arg.name] || ""}
+									placeholder={arg.default}
+									title={arg.description}
+									onChange={(e) => handleArgumentChange(arg.name, e.target.value)}
+									disabled={connected || loading}
+									className="text-foreground"
+								/>
+							)}
+						</div>
+					))}
 				</div>
 			)}
 
@@ -0,0 +1,68 @@
+import { AlertTriangle } from "lucide-react";
+import type React from "react";
+import type { types } from "../../wailsjs/go/models";
+import { Button } from "./ui/button";
+
+interface DestructiveQueryConfirmProps {
+	confirmation: types.QueryConfirmation;
+	onConfirm: () => void;
+	onCancel: () => void;
+}
+
+// DestructiveQueryConfirm lists the destructive statements of a query sent to
+// a production connection and asks before running them
+export const DestructiveQueryConfirm: React.FC<
+	DestructiveQueryConfirmProps
+> = ({ confirmation, onConfirm, onCancel }) => {
+	return (
+		<div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+			<div className="w-full max-w-lg space-y-4 rounded-lg border border-gray-200 bg-white p-6 shadow-lg dark:border-gray-700 dark:bg-gray-800">
+				<div className="flex items-center space-x-2">
+					<AlertTriangle className="h-5 w-5 text-red-600 dark:text-red-400" />
+					<h2 className="font-semibold text-gray-900 text-lg dark:text-white">
+						Run destructive statements on production?
+					</h2>
+				</div>
+
+				<ul className="max-h-80 space-y-3 overflow-y-auto">
+					{confirmation.statements.map((statement, index) => (
+						<li
+							// biome-ignore lint/suspicious/noArrayIndexKey: statements can repeat
+							key={index}
+							className="space-y-1 rounded-md border border-red-200 bg-red-50 p-3 text-sm dark:border-red-700 dark:bg-red-900/20"
+						>
+							<pre className="whitespace-pre-wrap break-all font-mono text-gray-900 dark:text-white">
+								{statement.statement}
+							</pre>
+							<p className="text-red-700 dark:text-red-400">{statement.risk}</p>
+							{statement.estimatedRows !== undefined && (
+								<p className="text-gray-600 dark:text-gray-400">
+									About {statement.estimatedRows.toLocaleString()} row(s)
+									affected
+								</p>
+							)}
+						</li>
+					))}
+				</ul>
+
+				<div className="flex justify-end space-x-2 pt-2">
+					<Button
+						type="button"
+						variant="outline"
+						onClick={onCancel}
+						className="border-gray-300 text-gray-700 hover:bg-gray-50 dark:border-gray-600 dark:text-gray-300 dark:hover:bg-gray-700"
+					>
+						Cancel
+					</Button>
+					<Button
+						type="button"
+						onClick={onConfirm}
+						className="bg-red-600 text-white hover:bg-red-700 dark:bg-red-700 dark:hover:bg-red-800"
+					>
+						Run anyway
+					</Button>
+				</div>
+			</div>
+		</div>
+	);
+};
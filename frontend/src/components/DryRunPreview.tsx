@@ -0,0 +1,111 @@
+import { FlaskConical, XCircle } from "lucide-react";
+import type React from "react";
+import type { types } from "../../wailsjs/go/models";
+import { formatCell } from "./QueryResults";
+
+interface DryRunPreviewProps {
+	statements: types.StatementResult[];
+}
+
+const PreviewTable: React.FC<{ title: string; result: types.QueryResult }> = ({
+	title,
+	result,
+}) => (
+	<div className="space-y-1">
+		<div className="font-medium text-gray-700 text-xs dark:text-gray-300">
+			{title}
+			{result.truncated &&
+				` (first ${result.rows.length} of ${result.rowsAffected} rows)`}
+		</div>
+		<div className="overflow-auto rounded border border-gray-200 dark:border-gray-600">
+			<table className="min-w-full font-mono text-xs">
+				<thead className="bg-gray-50 dark:bg-gray-700">
+					<tr>
+						{result.columns.map((column, i) => (
+							<th
+								// biome-ignore lint/suspicious/noArrayIndexKey: columns may repeat
+								key={`${column}-${i}`}
+								className="whitespace-nowrap px-2 py-1 text-left text-gray-600 dark:text-gray-300"
+							>
+								{column}
+							</th>
+						))}
+					</tr>
+				</thead>
+				<tbody>
+					{result.rows.map((row, rowIndex) => (
+						<tr
+							// biome-ignore lint/suspicious/noArrayIndexKey: rows have no key
+							key={rowIndex}
+							className="border-gray-100 border-t dark:border-gray-700"
+						>
+							{row.map((cell, cellIndex) => (
+								<td
+									// biome-ignore lint/suspicious/noArrayIndexKey: cells have no key
+									key={cellIndex}
+									className="whitespace-nowrap px-2 py-1 text-gray-900 dark:text-gray-100"
+								>
+									{cell === null ? (
+										<span className="text-gray-400 italic">NULL</span>
+									) : (
+										formatCell(cell)
+									)}
+								</td>
+							))}
+						</tr>
+					))}
+				</tbody>
+			</table>
+		</div>
+	</div>
+);
+
+// DryRunPreview shows the rows each statement of a dry run changed, before
+// and after, the changes having been rolled back
+export const DryRunPreview: React.FC<DryRunPreviewProps> = ({ statements }) => (
+	<div className="h-full space-y-4 overflow-auto p-4">
+		<div className="flex items-center space-x-2 text-gray-700 text-sm dark:text-gray-300">
+			<FlaskConical className="h-4 w-4" />
+			<span>Dry run, every change was rolled back</span>
+		</div>
+
+		{statements.map((statement) => (
+			<div
+				key={statement.start}
+				className="space-y-2 rounded-lg border border-gray-200 p-3 dark:border-gray-700"
+			>
+				<pre className="truncate font-mono text-gray-600 text-xs dark:text-gray-400">
+					{statement.statement}
+				</pre>
+
+				{statement.error ? (
+					<div className="flex items-center text-red-600 text-sm dark:text-red-400">
+						<XCircle className="mr-1 h-4 w-4" />
+						{statement.error}
+					</div>
+				) : (
+					statement.preview && (
+						<>
+							<div className="font-medium text-gray-900 text-sm dark:text-white">
+								{statement.preview.rowsAffected} rows affected
+							</div>
+							{statement.preview.note && (
+								<div className="text-gray-500 text-xs dark:text-gray-400">
+									{statement.preview.note}
+								</div>
+							)}
+							<div className="grid grid-cols-1 gap-3 xl:grid-cols-2">
+								{statement.preview.before && (
+									<PreviewTable title="Before" result={statement.preview.before} />
+								)}
+								{statement.preview.after && (
+									<PreviewTable title="After" result={statement.preview.after} />
+								)}
+							</div>
+						</>
+					)
+				)}
+			</div>
+		))}
+	</div>
+);
@@ -0,0 +1,117 @@
+import { KeyRound } from "lucide-react";
+import type React from "react";
+import { useState } from "react";
+import { Button } from "./ui/button";
+import { Input } from "./ui/input";
+import { Label } from "./ui/label";
+
+// Seconds the password stays in memory after disconnecting
+const REMEMBER_OPTIONS = [
+	{ label: "While connected", value: 0 },
+	{ label: "15 minutes", value: 15 * 60 },
+	{ label: "1 hour", value: 60 * 60 },
+	{ label: "8 hours", value: 8 * 60 * 60 },
+];
+
+interface PasswordPromptProps {
+	message?: string;
+	onSubmit: (password: string, rememberFor: number) => void;
+	onCancel: () => void;
+}
+
+// PasswordPrompt asks for the password of a connection that does not save it
+export const PasswordPrompt: React.FC<PasswordPromptProps> = ({
+	message,
+	onSubmit,
+	onCancel,
+}) => {
+	const [password, setPassword] = useState("");
+	const [rememberFor, setRememberFor] = useState(0);
+
+	const handleSubmit = (e: React.FormEvent) => {
+		e.preventDefault();
+		onSubmit(password, rememberFor);
+	};
+
+	return (
+		<div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+			<form
+				onSubmit={handleSubmit}
+				className="w-full max-w-md space-y-4 rounded-lg border border-gray-200 bg-white p-6 shadow-lg dark:border-gray-700 dark:bg-gray-800"
+			>
+				<div className="flex items-center space-x-2">
+					<KeyRound className="h-5 w-5 text-gray-700 dark:text-gray-300" />
+					<h2 className="font-semibold text-gray-900 text-lg dark:text-white">
+						Password required
+					</h2>
+				</div>
+				<p className="text-gray-500 text-sm dark:text-gray-400">
+					This connection does not save its password. It is kept in memory
+					only.
+				</p>
+
+				<div className="space-y-2">
+					<Label
+						htmlFor="connection-password"
+						className="text-gray-700 dark:text-gray-300"
+					>
+						Password
+					</Label>
+					<Input
+						id="connection-password"
+						type="password"
+						autoFocus
+						value={password}
+						onChange={(e) => setPassword(e.target.value)}
+						className="border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+					/>
+				</div>
+
+				<div className="space-y-2">
+					<Label
+						htmlFor="connection-remember"
+						className="text-gray-700 dark:text-gray-300"
+					>
+						Remember
+					</Label>
+					<select
+						id="connection-remember"
+						value={rememberFor}
+						onChange={(e) => setRememberFor(Number(e.target.value))}
+						className="w-full rounded-md border border-gray-300 bg-white px-3 py-2 text-sm dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+					>
+						{REMEMBER_OPTIONS.map((option) => (
+							<option key={option.value} value={option.value}>
+								{option.label}
+							</option>
+						))}
+					</select>
+				</div>
+
+				{message && (
+					<div className="rounded-md border border-red-200 bg-red-50 p-3 text-red-700 text-sm dark:border-red-700 dark:bg-red-900/20 dark:text-red-400">
+						{message}
+					</div>
+				)}
+
+				<div className="flex justify-end space-x-2 pt-2">
+					<Button
+						type="button"
+						variant="outline"
+						onClick={onCancel}
+						className="border-gray-300 text-gray-700 hover:bg-gray-50 dark:border-gray-600 dark:text-gray-300 dark:hover:bg-gray-700"
+					>
+						Cancel
+					</Button>
+					<Button
+						type="submit"
+						disabled={!password}
+						className="bg-blue-600 text-white hover:bg-blue-700 dark:bg-blue-700 dark:hover:bg-blue-800"
+					>
+						Connect
+					</Button>
+				</div>
+			</form>
+		</div>
+	);
+};
@@ -0,0 +1,197 @@
+import { History, Search, XCircle } from "lucide-react";
+import type React from "react";
+import { useCallback, useEffect, useState } from "react";
+import { OpenHistoryEntry } from "../../wailsjs/go/handlers/OpenHistoryEntryHandler";
+import { PruneHistory } from "../../wailsjs/go/handlers/PruneHistoryHandler";
+import { SearchHistory } from "../../wailsjs/go/handlers/SearchHistoryHandler";
+import type { types } from "../../wailsjs/go/models";
+import { Button } from "./ui/button";
+import { Input } from "./ui/input";
+
+interface QueryHistoryPanelProps {
+	// connectionId narrows the history to the active connection when asked
+	connectionId?: string | null;
+	onOpen: (entry: types.HistoryEntry) => void;
+	onClose: () => void;
+}
+
+// dayStart returns the time of a date input in milliseconds, 0 when empty
+const dayStart = (date: string, days = 0) => {
+	if (!date) return 0;
+	const day = new Date(`${date}T00:00:00`);
+	day.setDate(day.getDate() + days);
+	return day.getTime();
+};
+
+// QueryHistoryPanel searches the queries that ran by text, connection and
+// date, opens one again in the editor and prunes old entries
+export const QueryHistoryPanel: React.FC<QueryHistoryPanelProps> = ({
+	connectionId,
+	onOpen,
+	onClose,
+}) => {
+	const [text, setText] = useState("");
+	const [thisConnection, setThisConnection] = useState(Boolean(connectionId));
+	const [from, setFrom] = useState("");
+	const [to, setTo] = useState("");
+	const [entries, setEntries] = useState<types.HistoryEntry[]>([]);
+	const [pruneDays, setPruneDays] = useState(30);
+	const [message, setMessage] = useState<string>();
+
+	const search = useCallback(async () => {
+		const response = await SearchHistory({
+			text,
+			connectionId: thisConnection && connectionId ? connectionId : "",
+			from: dayStart(from),
+			// The day of the To date is included
+			to: dayStart(to, 1),
+		});
+		if (response?.success) {
+			setEntries(response.entries);
+		} else {
+			setMessage(response?.message || "Failed to search the history");
+		}
+	}, [text, thisConnection, connectionId, from, to]);
+
+	useEffect(() => {
+		const timer = setTimeout(search, 200);
+		return () => clearTimeout(timer);
+	}, [search]);
+
+	const handleOpen = async (id: string) => {
+		const response = await OpenHistoryEntry({ id });
+		if (response?.success && response.entry) {
+			onOpen(response.entry);
+		} else {
+			setMessage(response?.message || "Failed to open the history entry");
+		}
+	};
+
+	const handlePrune = async () => {
+		const response = await PruneHistory({ olderThanDays: pruneDays });
+		setMessage(response?.message);
+		if (response?.success) search();
+	};
+
+	return (
+		<div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+			<div className="flex max-h-[85vh] w-full max-w-3xl flex-col space-y-4 rounded-lg border border-gray-200 bg-white p-6 shadow-lg dark:border-gray-700 dark:bg-gray-800">
+				<div className="flex items-center space-x-2">
+					<History className="h-5 w-5 text-gray-700 dark:text-gray-300" />
+					<h2 className="font-semibold text-gray-900 text-lg dark:text-white">
+						Query history
+					</h2>
+				</div>
+
+				<div className="flex flex-wrap items-center gap-2">
+					<div className="relative flex-1">
+						<Search className="absolute top-2.5 left-2 h-4 w-4 text-gray-400" />
+						<Input
+							autoFocus
+							placeholder="Search SQL"
+							value={text}
+							onChange={(e) => setText(e.target.value)}
+							className="border-gray-300 pl-8 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+						/>
+					</div>
+					<Input
+						type="date"
+						title="From"
+						value={from}
+						onChange={(e) => setFrom(e.target.value)}
+						className="w-40 border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+					/>
+					<Input
+						type="date"
+						title="To"
+						value={to}
+						onChange={(e) => setTo(e.target.value)}
+						className="w-40 border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+					/>
+					{connectionId && (
+						<label className="flex items-center space-x-1 text-gray-600 text-sm dark:text-gray-300">
+							<input
+								type="checkbox"
+								checked={thisConnection}
+								onChange={(e) => setThisConnection(e.target.checked)}
+							/>
+							<span>This connection only</span>
+						</label>
+					)}
+				</div>
+
+				<div className="flex-1 divide-y divide-gray-100 overflow-y-auto rounded border border-gray-200 dark:divide-gray-700 dark:border-gray-700">
+					{entries.length === 0 && (
+						<div className="p-4 text-center text-gray-500 text-sm dark:text-gray-400">
+							No queries found
+						</div>
+					)}
+					{entries.map((entry) => (
+						<button
+							type="button"
+							key={entry.id}
+							onClick={() => handleOpen(entry.id)}
+							className="block w-full px-3 py-2 text-left hover:bg-gray-50 dark:hover:bg-gray-700"
+						>
+							<div className="flex items-center justify-between text-gray-500 text-xs dark:text-gray-400">
+								<span>
+									{new Date(entry.startedAt).toLocaleString()} ·{" "}
+									{entry.connectionName || "deleted connection"}
+									{entry.database && ` / ${entry.database}`}
+									{entry.dryRun && " · dry run"}
+								</span>
+								<span>
+									{entry.rowCount} rows · {entry.duration} ms
+								</span>
+							</div>
+							<pre className="truncate font-mono text-gray-900 text-xs dark:text-gray-100">
+								{entry.query}
+							</pre>
+							{entry.error && (
+								<div className="flex items-center truncate text-red-600 text-xs dark:text-red-400">
+									<XCircle className="mr-1 h-3 w-3 flex-shrink-0" />
+									{entry.error}
+								</div>
+							)}
+						</button>
+					))}
+				</div>
+
+				{message && (
+					<div className="text-gray-600 text-sm dark:text-gray-300">{message}</div>
+				)}
+
+				<div className="flex items-center justify-between pt-2">
+					<div className="flex items-center space-x-2 text-gray-600 text-sm dark:text-gray-300">
+						<span>Remove entries older than</span>
+						<Input
+							type="number"
+							min={1}
+							value={pruneDays}
+							onChange={(e) => setPruneDays(Number(e.target.value))}
+							className="w-20 border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+						/>
+						<span>days</span>
+						<Button
+							type="button"
+							variant="outline"
+							size="sm"
+							disabled={pruneDays < 1}
+							onClick={handlePrune}
+						>
+							Prune
+						</Button>
+					</div>
+					<Button
+						type="button"
+						variant="outline"
+						onClick={onClose}
+						className="border-gray-300 text-gray-700 hover:bg-gray-50 dark:border-gray-600 dark:text-gray-300 dark:hover:bg-gray-700"
+					>
+						Close
+					</Button>
+				</div>
+			</div>
+		</div>
+	);
+};
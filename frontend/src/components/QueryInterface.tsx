@@ -1,9 +1,26 @@
 import type React from "react";
-import { useState } from "react";
+import { useEffect, useRef, useState } from "react";
+import { BeginTransaction } from "../../wailsjs/go/handlers/BeginTransactionHandler";
+import { CancelQuery } from "../../wailsjs/go/handlers/CancelQueryHandler";
+import { CloseResult } from "../../wailsjs/go/handlers/CloseResultHandler";
+import { CommitTransaction } from "../../wailsjs/go/handlers/CommitTransactionHandler";
 import { ExecuteQuery } from "../../wailsjs/go/handlers/ExecuteQueryHandler";
+import { ExplainQuery } from "../../wailsjs/go/handlers/ExplainQueryHandler";
+import { FetchResultPage } from "../../wailsjs/go/handlers/FetchResultPageHandler";
+import { GetQueryParameters } from "../../wailsjs/go/handlers/GetQueryParametersHandler";
+import { RollbackTransaction } from "../../wailsjs/go/handlers/RollbackTransactionHandler";
+import { RunSnippet } from "../../wailsjs/go/handlers/RunSnippetHandler";
+import type { types } from "../../wailsjs/go/models";
 import { useActiveConnectionStore } from "../store/ActiveConnectionStore";
+import { DestructiveQueryConfirm } from "./DestructiveQueryConfirm";
+import { DryRunPreview } from "./DryRunPreview";
+import { QueryHistoryPanel } from "./QueryHistoryPanel";
+import { QueryParametersPrompt } from "./QueryParametersPrompt";
+import { QueryPlanView } from "./QueryPlanView";
 import { QueryResults } from "./QueryResults";
+import { SnippetsPanel } from "./SnippetsPanel";
 import { SqlEditor } from "./SqlEditor";
+import { TransactionBar } from "./TransactionBar";
 
 interface QueryResult {
 	columns: string[];
@@ -11,6 +28,9 @@ interface QueryResult {
 	rows: any[][];
 	rowsAffected: number;
 	duration: number;
+	resultId?: string;
+	hasMore?: boolean;
+	truncated?: boolean;
 }
 
 interface QueryInterfaceProps {
@@ -22,10 +42,72 @@ export const QueryInterface: React.FC<QueryInterfaceProps> = ({ database }) => {
 	const [query, setQuery] = useState("");
 	const [result, setResult] = useState<QueryResult>();
 	const [error, setError] = useState<string>();
+	// dryRun holds the statements of the last dry run, shown instead of a result
+	const [dryRun, setDryRun] = useState<types.StatementResult[]>();
+	// plan holds the plan of the last explained query, shown instead of a result
+	const [plan, setPlan] = useState<types.QueryPlan>();
+	const [continueOnError, setContinueOnError] = useState(false);
 	const [isExecuting, setIsExecuting] = useState(false);
 	const [lastExecutedQuery, setLastExecutedQuery] = useState<string>();
+	const executionId = useRef<string>();
+	const [isLoadingMore, setIsLoadingMore] = useState(false);
+	const [transaction, setTransaction] = useState<types.TransactionState>();
+	const [showHistory, setShowHistory] = useState(false);
+	const [showSnippets, setShowSnippets] = useState(false);
 
-	const handleExecuteQuery = async (queryToExecute: string) => {
+	// The transaction shown is the one of the selected database
+	// biome-ignore lint/correctness/useExhaustiveDependencies: reset on database change
+	useEffect(() => {
+		setTransaction(undefined);
+	}, [activeConnection.connectionId, database]);
+
+	// Open results hold a database session until read or closed
+	const resultId = result?.resultId;
+	useEffect(() => {
+		return () => {
+			if (resultId) CloseResult({ resultId });
+		};
+	}, [resultId]);
+	const [confirmation, setConfirmation] = useState<{
+		query: string;
+		parameters?: types.QueryParameterValue[];
+		dryRun?: boolean;
+		// explain is set when the confirmation is for analyzing the query
+		explain?: { analyze: boolean };
+		details: types.QueryConfirmation;
+	}>();
+	const [parameterPrompt, setParameterPrompt] = useState<{
+		query: string;
+		parameters: types.QueryParameter[];
+		dryRun: boolean;
+		// explain is set when the values are for explaining the query
+		explain?: { analyze: boolean };
+	}>();
+	// Values last given to parameters, offered again by name
+	const [parameterValues, setParameterValues] = useState<
+		Record<string, types.QueryParameterValue>
+	>({});
+
+	// findParameters returns the placeholders of the query, undefined when
+	// they cannot be read
+	const findParameters = async (connectionId: string, queryToRun: string) => {
+		const found = await GetQueryParameters({
+			id: connectionId,
+			query: queryToRun,
+		});
+		if (!found?.success) {
+			setError(found?.message || "Failed to read the query parameters");
+			return undefined;
+		}
+		return found.parameters ?? [];
+	};
+
+	const handleExecuteQuery = async (
+		queryToExecute: string,
+		confirmationToken?: string,
+		parameters?: types.QueryParameterValue[],
+		dryRun = false,
+	) => {
 		if (!queryToExecute.trim()) return;
 
 		if (!activeConnection.connectionId) {
@@ -33,20 +115,58 @@ export const QueryInterface: React.FC<QueryInterfaceProps> = ({ database }) => {
 			return;
 		}
 
+		// Queries with placeholders run once their values are given
+		if (!parameters) {
+			const found = await findParameters(
+				activeConnection.connectionId,
+				queryToExecute,
+			);
+			if (!found) return;
+			if (found.length > 0) {
+				setParameterPrompt({
+					query: queryToExecute,
+					parameters: found,
+					dryRun,
+				});
+				return;
+			}
+		}
+
 		setIsExecuting(true);
 		setError(undefined);
 		setResult(undefined);
+		setDryRun(undefined);
+		setPlan(undefined);
 		setLastExecutedQuery(queryToExecute);
+		executionId.current = crypto.randomUUID();
 
 		try {
 			const response = await ExecuteQuery({
 				id: activeConnection.connectionId,
 				database,
 				query: queryToExecute,
+				executionId: executionId.current,
+				confirmationToken,
+				continueOnError,
+				parameters,
+				dryRun,
 			});
+			if (response?.transaction) {
+				setTransaction(response.transaction);
+			}
 
-			if (response?.success && response?.result) {
+			if (dryRun && response?.statements?.length > 0) {
+				setDryRun(response.statements);
+			} else if (response?.success && response?.result) {
 				setResult(response.result);
+			} else if (response?.confirmation) {
+				setConfirmation({
+					query: queryToExecute,
+					parameters,
+					dryRun,
+					details: response.confirmation,
+				});
+				setError(response.message);
 			} else {
 				setError(response?.message || "Query execution failed");
 			}
@@ -55,14 +175,215 @@ export const QueryInterface: React.FC<QueryInterfaceProps> = ({ database }) => {
 				err instanceof Error ? err.message : "An unexpected error occurred",
 			);
 		} finally {
+			executionId.current = undefined;
+			setIsExecuting(false);
+		}
+	};
+
+	// handleRunSnippet runs a saved snippet with the values of its variables
+	// bound as parameters and puts the query that ran in the editor
+	const handleRunSnippet = async (
+		snippet: types.Snippet,
+		variables: Record<string, string>,
+	) => {
+		if (!activeConnection.connectionId) {
+			setError("No active connection available");
+			return;
+		}
+
+		setShowSnippets(false);
+		setIsExecuting(true);
+		setError(undefined);
+		setResult(undefined);
+		setDryRun(undefined);
+		setPlan(undefined);
+		executionId.current = crypto.randomUUID();
+		// The variables become :name placeholders of the query that ran
+		const parameters = Object.entries(variables).map(([name, value]) => ({
+			name,
+			value,
+		}));
+		setParameterValues({
+			...parameterValues,
+			...Object.fromEntries(parameters.map((p) => [p.name, p])),
+		});
+
+		try {
+			const response = await RunSnippet({
+				snippetId: snippet.id,
+				id: activeConnection.connectionId,
+				database,
+				variables,
+				executionId: executionId.current,
+				continueOnError,
+			});
+			if (response?.query) {
+				setQuery(response.query);
+				setLastExecutedQuery(response.query);
+			}
+			if (response?.transaction) {
+				setTransaction(response.transaction);
+			}
+
+			if (response?.success && response?.result) {
+				setResult(response.result);
+			} else if (response?.confirmation && response.query) {
+				// The token is bound to the query that ran, so confirming runs
+				// it again with the same values
+				setConfirmation({
+					query: response.query,
+					parameters,
+					details: response.confirmation,
+				});
+				setError(response.message);
+			} else {
+				setError(response?.message || "Snippet execution failed");
+			}
+		} catch (err) {
+			setError(
+				err instanceof Error ? err.message : "An unexpected error occurred",
+			);
+		} finally {
+			executionId.current = undefined;
+			setIsExecuting(false);
+		}
+	};
+
+	const handleExplainQuery = async (
+		queryToExplain: string,
+		analyze: boolean,
+		parameters?: types.QueryParameterValue[],
+		confirmationToken?: string,
+	) => {
+		if (!queryToExplain.trim()) return;
+
+		if (!activeConnection.connectionId) {
+			setError("No active connection available");
+			return;
+		}
+
+		if (!parameters) {
+			const found = await findParameters(
+				activeConnection.connectionId,
+				queryToExplain,
+			);
+			if (!found) return;
+			if (found.length > 0) {
+				setParameterPrompt({
+					query: queryToExplain,
+					parameters: found,
+					dryRun: false,
+					explain: { analyze },
+				});
+				return;
+			}
+		}
+
+		setIsExecuting(true);
+		setError(undefined);
+		setResult(undefined);
+		setDryRun(undefined);
+		setPlan(undefined);
+		setLastExecutedQuery(queryToExplain);
+		executionId.current = crypto.randomUUID();
+
+		try {
+			const response = await ExplainQuery({
+				id: activeConnection.connectionId,
+				database,
+				query: queryToExplain,
+				analyze,
+				// Only PostgreSQL counts buffers, the others ignore it
+				buffers: analyze,
+				parameters,
+				executionId: executionId.current,
+				confirmationToken,
+			});
+			if (response?.plan?.transaction) {
+				setTransaction(response.plan.transaction);
+			}
+
+			if (response?.success && response.plan) {
+				setPlan(response.plan);
+			} else if (response?.confirmation) {
+				setConfirmation({
+					query: queryToExplain,
+					parameters,
+					explain: { analyze },
+					details: response.confirmation,
+				});
+				setError(response.message);
+			} else {
+				setError(response?.message || "Failed to explain the query");
+			}
+		} catch (err) {
+			setError(
+				err instanceof Error ? err.message : "An unexpected error occurred",
+			);
+		} finally {
+			executionId.current = undefined;
 			setIsExecuting(false);
 		}
 	};
 
-	const handleStopQuery = () => {
-		// For now, we'll just set executing to false
-		// In a real implementation, you'd cancel the request
-		setIsExecuting(false);
+	const handleLoadMore = async () => {
+		if (!result?.resultId) return;
+
+		setIsLoadingMore(true);
+		try {
+			const response = await FetchResultPage({ resultId: result.resultId });
+			if (response?.success && response.result) {
+				const page = response.result;
+				setResult({
+					...result,
+					rows: [...result.rows, ...page.rows],
+					resultId: page.resultId,
+					hasMore: page.hasMore,
+					truncated: page.truncated,
+				});
+			} else {
+				setError(response?.message || "Failed to fetch more rows");
+			}
+		} finally {
+			setIsLoadingMore(false);
+		}
+	};
+
+	const handleTransaction = async (
+		action: typeof BeginTransaction,
+		failure: string,
+	) => {
+		if (!activeConnection.connectionId) return;
+
+		setError(undefined);
+		try {
+			const response = await action({
+				id: activeConnection.connectionId,
+				database,
+			});
+			if (response?.success) {
+				setTransaction(response.transaction);
+				// Rows left to fetch were read in the transaction that ended
+				if (result?.resultId) {
+					setResult({ ...result, resultId: undefined, hasMore: false });
+				}
+			} else {
+				setError(response?.message || failure);
+			}
+		} catch (err) {
+			setError(
+				err instanceof Error ? err.message : "An unexpected error occurred",
+			);
+		}
+	};
+
+	const handleStopQuery = async () => {
+		if (!executionId.current) return;
+
+		const response = await CancelQuery({ executionId: executionId.current });
+		if (!response?.success) {
+			setError(response?.message || "Failed to cancel the query");
+		}
 	};
 
 	return (
@@ -74,20 +395,136 @@ export const QueryInterface: React.FC<QueryInterfaceProps> = ({ database }) => {
 					onChange={setQuery}
 					onExecute={handleExecuteQuery}
 					onStop={handleStopQuery}
+					onDryRun={(queryToRun) =>
+						handleExecuteQuery(queryToRun, undefined, undefined, true)
+					}
+					onExplain={(queryToRun, analyze) =>
+						handleExplainQuery(queryToRun, analyze)
+					}
+					onShowHistory={() => setShowHistory(true)}
+					onShowSnippets={() => setShowSnippets(true)}
 					isExecuting={isExecuting}
 					database={database}
+					continueOnError={continueOnError}
+					onContinueOnErrorChange={setContinueOnError}
 				/>
 			</div>
 
+			<TransactionBar
+				transaction={transaction}
+				disabled={isExecuting}
+				onBegin={() =>
+					handleTransaction(BeginTransaction, "Failed to begin the transaction")
+				}
+				onCommit={() =>
+					handleTransaction(
+						CommitTransaction,
+						"Failed to commit the transaction",
+					)
+				}
+				onRollback={() =>
+					handleTransaction(
+						RollbackTransaction,
+						"Failed to roll back the transaction",
+					)
+				}
+			/>
+
 			{/* Query Results - takes up 60% of height */}
 			<div className="flex-1 overflow-hidden">
-				<QueryResults
-					result={result}
-					error={error}
-					isLoading={isExecuting}
-					query={lastExecutedQuery}
-				/>
+				{plan ? (
+					<QueryPlanView plan={plan} />
+				) : dryRun ? (
+					<DryRunPreview statements={dryRun} />
+				) : (
+					<QueryResults
+						result={result}
+						error={error}
+						isLoading={isExecuting}
+						query={lastExecutedQuery}
+						isLoadingMore={isLoadingMore}
+						onLoadMore={handleLoadMore}
+					/>
+				)}
 			</div>
+
+			{confirmation && (
+				<DestructiveQueryConfirm
+					confirmation={confirmation.details}
+					onConfirm={() => {
+						setConfirmation(undefined);
+						if (confirmation.explain) {
+							handleExplainQuery(
+								confirmation.query,
+								confirmation.explain.analyze,
+								confirmation.parameters ?? [],
+								confirmation.details.token,
+							);
+							return;
+						}
+						handleExecuteQuery(
+							confirmation.query,
+							confirmation.details.token,
+							confirmation.parameters ?? [],
+							confirmation.dryRun,
+						);
+					}}
+					onCancel={() => setConfirmation(undefined)}
+				/>
+			)}
+
+			{showHistory && (
+				<QueryHistoryPanel
+					connectionId={activeConnection.connectionId}
+					onOpen={(entry) => {
+						setShowHistory(false);
+						setQuery(entry.query);
+					}}
+					onClose={() => setShowHistory(false)}
+				/>
+			)}
+
+			{showSnippets && (
+				<SnippetsPanel
+					connectionId={activeConnection.connectionId}
+					query={query}
+					onOpen={(snippet) => {
+						setShowSnippets(false);
+						setQuery(snippet.query);
+					}}
+					onRun={handleRunSnippet}
+					onClose={() => setShowSnippets(false)}
+				/>
+			)}
+
+			{parameterPrompt && (
+				<QueryParametersPrompt
+					parameters={parameterPrompt.parameters}
+					initialValues={parameterValues}
+					onSubmit={(values) => {
+						setParameterPrompt(undefined);
+						setParameterValues({
+							...parameterValues,
+							...Object.fromEntries(values.map((v) => [v.name, v])),
+						});
+						if (parameterPrompt.explain) {
+							handleExplainQuery(
+								parameterPrompt.query,
+								parameterPrompt.explain.analyze,
+								values,
+							);
+							return;
+						}
+						handleExecuteQuery(
+							parameterPrompt.query,
+							undefined,
+							values,
+							parameterPrompt.dryRun,
+						);
+					}}
+					onCancel={() => setParameterPrompt(undefined)}
+				/>
+			)}
 		</div>
 	);
 };
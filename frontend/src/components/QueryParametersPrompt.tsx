@@ -0,0 +1,130 @@
+import { Variable } from "lucide-react";
+import type React from "react";
+import { useState } from "react";
+import type { types } from "../../wailsjs/go/models";
+import { Button } from "./ui/button";
+import { Input } from "./ui/input";
+
+const PARAMETER_TYPES = [
+	"text",
+	"integer",
+	"number",
+	"boolean",
+	"date",
+	"timestamp",
+	"null",
+];
+
+interface QueryParametersPromptProps {
+	parameters: types.QueryParameter[];
+	// initialValues are the values last given to parameters of the same name
+	initialValues: Record<string, types.QueryParameterValue>;
+	onSubmit: (values: types.QueryParameterValue[]) => void;
+	onCancel: () => void;
+}
+
+// inputType picks the input matching the type of a parameter value
+const inputType = (type?: string) => {
+	switch (type) {
+		case "integer":
+		case "number":
+			return "number";
+		case "date":
+			return "date";
+		case "timestamp":
+			return "datetime-local";
+		default:
+			return "text";
+	}
+};
+
+// QueryParametersPrompt asks the values of the placeholders of a query. The
+// values are bound by the driver, they are never written into the query.
+export const QueryParametersPrompt: React.FC<QueryParametersPromptProps> = ({
+	parameters,
+	initialValues,
+	onSubmit,
+	onCancel,
+}) => {
+	const [values, setValues] = useState(() =>
+		parameters.map(
+			(p) =>
+				initialValues[p.name] ?? {
+					name: p.name,
+					type: "text",
+					value: "",
+				},
+		),
+	);
+
+	const update = (index: number, change: Partial<types.QueryParameterValue>) => {
+		setValues(values.map((v, i) => (i === index ? { ...v, ...change } : v)));
+	};
+
+	const handleSubmit = (e: React.FormEvent) => {
+		e.preventDefault();
+		onSubmit(values);
+	};
+
+	return (
+		<div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+			<form
+				onSubmit={handleSubmit}
+				className="w-full max-w-lg space-y-4 rounded-lg border border-gray-200 bg-white p-6 shadow-lg dark:border-gray-700 dark:bg-gray-800"
+			>
+				<div className="flex items-center space-x-2">
+					<Variable className="h-5 w-5 text-gray-700 dark:text-gray-300" />
+					<h2 className="font-semibold text-gray-900 text-lg dark:text-white">
+						Query parameters
+					</h2>
+				</div>
+
+				<div className="max-h-96 space-y-3 overflow-y-auto">
+					{parameters.map((p, index) => (
+						<div key={p.name} className="flex items-center space-x-2">
+							<span className="w-24 truncate font-mono text-gray-700 text-sm dark:text-gray-300">
+								{p.placeholder === "?" ? `? #${p.name}` : p.placeholder}
+							</span>
+							<select
+								value={values[index].type}
+								onChange={(e) => update(index, { type: e.target.value })}
+								className="rounded-md border border-gray-300 bg-white px-2 py-2 text-sm dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+							>
+								{PARAMETER_TYPES.map((type) => (
+									<option key={type} value={type}>
+										{type}
+									</option>
+								))}
+							</select>
+							<Input
+								type={inputType(values[index].type)}
+								autoFocus={index === 0}
+								disabled={values[index].type === "null"}
+								value={values[index].value}
+								onChange={(e) => update(index, { value: e.target.value })}
+								className="flex-1 border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+							/>
+						</div>
+					))}
+				</div>
+
+				<div className="flex justify-end space-x-2 pt-2">
+					<Button
+						type="button"
+						variant="outline"
+						onClick={onCancel}
+						className="border-gray-300 text-gray-700 hover:bg-gray-50 dark:border-gray-600 dark:text-gray-300 dark:hover:bg-gray-700"
+					>
+						Cancel
+					</Button>
+					<Button
+						type="submit"
+						className="bg-blue-600 text-white hover:bg-blue-700 dark:bg-blue-700 dark:hover:bg-blue-800"
+					>
+						Run
+					</Button>
+				</div>
+			</form>
+		</div>
+	);
+};
@@ -0,0 +1,174 @@
+import { AlertTriangle, ListTree } from "lucide-react";
+import type React from "react";
+import { useState } from "react";
+import type { types } from "../../wailsjs/go/models";
+
+interface QueryPlanViewProps {
+	plan: types.QueryPlan;
+}
+
+const hotspotLabels: Record<string, string> = {
+	"self-time": "Slow step",
+	"row-estimate": "Row estimate miss",
+	"full-scan": "Full table scan",
+};
+
+const formatNumber = (value: number) =>
+	value.toLocaleString(undefined, { maximumFractionDigits: 2 });
+
+const formatTime = (value?: number) =>
+	value === undefined ? undefined : `${formatNumber(value)} ms`;
+
+const PlanNodeRow: React.FC<{
+	node: types.PlanNode;
+	depth: number;
+	hotspots: Set<number>;
+	selected?: number;
+}> = ({ node, depth, hotspots, selected }) => {
+	const facts = [
+		node.estimatedRows !== undefined &&
+			`est. ${formatNumber(node.estimatedRows)} rows`,
+		node.actualRows !== undefined &&
+			`actual ${formatNumber(node.actualRows)} rows${
+				node.loops !== undefined && node.loops !== 1
+					? ` × ${formatNumber(node.loops)} loops`
+					: ""
+			}`,
+		node.loops === 0 && "never executed",
+		node.totalCost !== undefined && `cost ${formatNumber(node.totalCost)}`,
+		node.actualTime !== undefined && `time ${formatTime(node.actualTime)}`,
+		node.selfTime !== undefined && `self ${formatTime(node.selfTime)}`,
+		node.buffers &&
+			`buffers hit ${node.buffers.sharedHit}, read ${node.buffers.sharedRead}`,
+	].filter(Boolean);
+
+	const highlight =
+		node.id === selected
+			? "bg-blue-50 dark:bg-blue-900/30"
+			: hotspots.has(node.id)
+				? "bg-amber-50 dark:bg-amber-900/20"
+				: "";
+
+	return (
+		<>
+			<div
+				className={`rounded px-2 py-1 ${highlight}`}
+				style={{ paddingLeft: `${depth * 1.25 + 0.5}rem` }}
+			>
+				<div className="text-gray-900 text-sm dark:text-white">
+					<span className="font-medium">{node.nodeType}</span>
+					{node.relation && (
+						<span className="text-gray-600 dark:text-gray-300">
+							{" "}
+							on {node.relation}
+						</span>
+					)}
+					{node.index && (
+						<span className="text-gray-600 dark:text-gray-300">
+							{" "}
+							using {node.index}
+						</span>
+					)}
+					{node.fullScan && (
+						<span className="ml-2 rounded bg-amber-100 px-1 text-amber-800 text-xs dark:bg-amber-900 dark:text-amber-200">
+							full scan
+						</span>
+					)}
+				</div>
+				<div className="text-gray-500 text-xs dark:text-gray-400">
+					{facts.join(" · ")}
+				</div>
+				{node.detail && (
+					<div className="truncate font-mono text-gray-500 text-xs dark:text-gray-400">
+						{node.detail}
+					</div>
+				)}
+			</div>
+			{node.children?.map((child) => (
+				<PlanNodeRow
+					key={child.id}
+					node={child}
+					depth={depth + 1}
+					hotspots={hotspots}
+					selected={selected}
+				/>
+			))}
+		</>
+	);
+};
+
+// QueryPlanView shows the plan of a statement as a tree, its hotspots first
+export const QueryPlanView: React.FC<QueryPlanViewProps> = ({ plan }) => {
+	const [selected, setSelected] = useState<number>();
+	const [showRaw, setShowRaw] = useState(false);
+	const hotspots = new Set(plan.hotspots.map((hotspot) => hotspot.nodeId));
+
+	const times = [
+		plan.planningTime !== undefined &&
+			`planning ${formatTime(plan.planningTime)}`,
+		plan.executionTime !== undefined &&
+			`execution ${formatTime(plan.executionTime)}`,
+	].filter(Boolean);
+
+	return (
+		<div className="h-full space-y-4 overflow-auto p-4">
+			<div className="flex items-center justify-between">
+				<div className="flex items-center space-x-2 text-gray-700 text-sm dark:text-gray-300">
+					<ListTree className="h-4 w-4" />
+					<span>
+						{plan.analyzed
+							? "Analyzed plan, every change was rolled back"
+							: "Estimated plan"}
+						{times.length > 0 && ` (${times.join(", ")})`}
+					</span>
+				</div>
+				<label className="flex items-center space-x-1 text-gray-600 text-sm dark:text-gray-300">
+					<input
+						type="checkbox"
+						checked={showRaw}
+						onChange={(e) => setShowRaw(e.target.checked)}
+					/>
+					<span>Raw</span>
+				</label>
+			</div>
+
+			{plan.hotspots.length > 0 && (
+				<div className="space-y-1 rounded-lg border border-amber-200 bg-amber-50 p-3 dark:border-amber-800 dark:bg-amber-900/20">
+					{plan.hotspots.map((hotspot) => (
+						<button
+							type="button"
+							key={`${hotspot.kind}-${hotspot.nodeId}`}
+							onClick={() => setSelected(hotspot.nodeId)}
+							className="flex w-full items-start text-left text-amber-800 text-sm dark:text-amber-200"
+						>
+							<AlertTriangle className="mt-0.5 mr-2 h-4 w-4 flex-shrink-0" />
+							<span>
+								<span className="font-medium">
+									{hotspotLabels[hotspot.kind] ?? hotspot.kind}:
+								</span>{" "}
+								{hotspot.message}
+							</span>
+						</button>
+					))}
+				</div>
+			)}
+
+			{showRaw ? (
+				<pre className="overflow-auto rounded border border-gray-200 p-3 font-mono text-gray-800 text-xs dark:border-gray-700 dark:text-gray-200">
+					{plan.raw}
+				</pre>
+			) : (
+				plan.root && (
+					<div className="rounded-lg border border-gray-200 py-1 dark:border-gray-700">
+						<PlanNodeRow
+							node={plan.root}
+							depth={0}
+							hotspots={hotspots}
+							selected={selected}
+						/>
+					</div>
+				)
+			)}
+		</div>
+	);
+};
@@ -2,19 +2,52 @@ import { CheckCircle, Clock, Database, XCircle } from "lucide-react";
 import type React from "react";
 import { useCallback, useRef, useState } from "react";
 
+interface ResultColumn {
+	name: string;
+	databaseType: string;
+}
+
 interface QueryResult {
 	columns: string[];
+	columnTypes?: ResultColumn[];
 	//biome-ignore lint/suspicious/noExplicitAny: database values can be of any type
 	rows: any[][];
 	rowsAffected: number;
 	duration: number;
+	hasMore?: boolean;
+	truncated?: boolean;
 }
 
+// formatCell renders a value of a result as text. Values JSON cannot carry as
+// is come tagged with their type: binary is shown in hex, JSON documents and
+// arrays in their usual notation, the others as the text they hold.
+//biome-ignore lint/suspicious/noExplicitAny: database values can be of any type
+export const formatCell = (cell: any): string => {
+	if (cell === null || cell === undefined) return "";
+	if (Array.isArray(cell)) return `{${cell.map(formatCell).join(",")}}`;
+	if (typeof cell !== "object" || !("$type" in cell)) return String(cell);
+
+	switch (cell.$type) {
+		case "binary":
+			return `\\x${Array.from(atob(cell.value), (c) =>
+				c.charCodeAt(0).toString(16).padStart(2, "0"),
+			).join("")}`;
+		case "json":
+			return JSON.stringify(cell.value);
+		case "array":
+			return formatCell(cell.value);
+		default:
+			return String(cell.value);
+	}
+};
+
 interface QueryResultsProps {
 	result?: QueryResult;
 	error?: string;
 	isLoading?: boolean;
 	query?: string;
+	isLoadingMore?: boolean;
+	onLoadMore?: () => void;
 }
 
 export const QueryResults: React.FC<QueryResultsProps> = ({
@@ -22,6 +55,8 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 	error,
 	isLoading,
 	query,
+	isLoadingMore,
+	onLoadMore,
 }) => {
 	// Column resizing state
 	const [columnWidths, setColumnWidths] = useState<Record<string, number>>({});
@@ -86,7 +121,7 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 		cellValue: any,
 	) => {
 		setEditingCell({ row: rowIndex, col: cellIndex });
-		setEditValue(String(cellValue ?? ""));
+		setEditValue(formatCell(cellValue));
 
 		// Focus the input after state update
 		setTimeout(() => {
@@ -162,7 +197,7 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 					</div>
 					<div>
 						{hasRows
-							? `${result.rows.length} rows`
+							? `${result.rows.length}${result.hasMore ? "+" : ""} rows`
 							: `${result.rowsAffected} rows affected`}
 					</div>
 				</div>
@@ -190,7 +225,7 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 									>
 										#
 									</th>
-									{result.columns.map((column) => (
+									{result.columns.map((column, columnIndex) => (
 										<th
 											key={`col-${column}`}
 											className="relative border border-gray-300 bg-gray-100 px-2 py-1 text-left font-medium text-gray-900 text-sm dark:border-gray-600 dark:bg-gray-700 dark:text-gray-200"
@@ -200,7 +235,14 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 												maxWidth: getColumnWidth(column),
 											}}
 										>
-											<div className="truncate" title={column}>
+											<div
+												className="truncate"
+												title={
+													result.columnTypes?.[columnIndex]?.databaseType
+														? `${column} (${result.columnTypes[columnIndex].databaseType})`
+														: column
+												}
+											>
 												{column}
 											</div>
 											{/* Resize handle */}
@@ -238,7 +280,7 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 
 											return (
 												<td
-													key={`cell-${rowIndex}-${cellIndex}-${String(cell).slice(0, 10)}`}
+													key={`cell-${rowIndex}-${cellIndex}-${formatCell(cell).slice(0, 10)}`}
 													className="relative border border-gray-300 px-2 py-1 font-mono text-gray-900 text-sm dark:border-gray-600 dark:text-gray-200"
 													style={{
 														width: getColumnWidth(columnName),
@@ -267,7 +309,7 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 													) : (
 														<div
 															className="flex h-full cursor-pointer items-center px-2 py-1 hover:bg-blue-50 dark:hover:bg-gray-600"
-															title={`Double-click to edit. Value: ${String(cell ?? "")}`}
+															title={`Double-click to edit. Value: ${formatCell(cell)}`}
 														>
 															<div className="w-full truncate">
 																{cell === null ? (
@@ -280,7 +322,7 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 																	</span>
 																) : (
 																	<span className="text-gray-900 dark:text-gray-200">
-																		{String(cell)}
+																		{formatCell(cell)}
 																	</span>
 																)}
 															</div>
@@ -312,10 +354,22 @@ export const QueryResults: React.FC<QueryResultsProps> = ({
 			{/* Footer Info */}
 			{hasRows && (
 				<div className="flex-shrink-0 border-gray-200 border-t bg-gray-50 px-3 py-2 text-gray-600 text-xs dark:border-gray-600 dark:bg-gray-700 dark:text-gray-400">
-					<div className="flex justify-between">
+					<div className="flex items-center justify-between">
 						<span>
-							Showing {result.rows.length} of {result.rows.length} rows
+							Showing {result.rows.length}
+							{result.hasMore ? " rows, more available" : " rows"}
+							{result.truncated && " (truncated at the result size limit)"}
 						</span>
+						{result.hasMore && onLoadMore && (
+							<button
+								type="button"
+								onClick={onLoadMore}
+								disabled={isLoadingMore}
+								className="rounded border border-gray-300 px-2 py-0.5 hover:bg-gray-100 disabled:opacity-50 dark:border-gray-600 dark:hover:bg-gray-600"
+							>
+								{isLoadingMore ? "Loading..." : "Load more"}
+							</button>
+						)}
 						<span>{result.columns.length} columns</span>
 					</div>
 				</div>
@@ -71,16 +71,20 @@ export const SavedConnections: React.FC<SavedConnectionsProps> = ({
 					<div
 						key={connection.id}
 						className="bg-white dark:bg-gray-800 border border-gray-200 dark:border-gray-700 rounded-lg p-4 hover:shadow-md transition-shadow min-w-0"
+						style={connection.color ? { borderLeftColor: connection.color, borderLeftWidth: 4 } : undefined}
 					>
 						<div className="flex items-start justify-between mb-3 min-w-0">
 							<div className="flex items-center min-w-0 flex-1 mr-2">
 								<Server className="h-5 w-5 text-blue-500 mr-2 flex-shrink-0" />
 								<div className="min-w-0 flex-1">
-									<h3 className="text-sm font-medium text-gray-900 dark:text-white truncate" title={connection.host}>
-										{connection.host}
+									<h3 className="text-sm font-medium text-gray-900 dark:text-white truncate" title={connection.name}>
+										{connection.name}
 									</h3>
-									<p className="text-xs text-gray-500 dark:text-gray-400">
-										Port {connection.port}
+									<p className="text-xs text-gray-500 dark:text-gray-400 truncate">
+										{connection.folder ? `${connection.folder} · ` : ""}{connection.host}:{connection.port}
+										{connection.environment ? ` · ${connection.environment}` : ""}
+										{connection.readOnly ? " · read-only" : ""}
+										{connection.manualCommit ? " · manual commit" : ""}
 									</p>
 								</div>
 							</div>
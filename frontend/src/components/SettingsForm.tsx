@@ -18,6 +18,9 @@ export const SettingsForm: React.FC<SettingsFormProps> = ({
 }) => {
 	const [openAIAPIKey, setOpenAIAPIKey] = useState("");
 	const [originalKey, setOriginalKey] = useState("");
+	const [maxResultRows, setMaxResultRows] = useState(0);
+	const [maxResultMB, setMaxResultMB] = useState(0);
+	const [originalLimits, setOriginalLimits] = useState({ rows: 0, mb: 0 });
 	const [loading, setLoading] = useState(false);
 	const [saving, setSaving] = useState(false);
 	const [message, setMessage] = useState("");
@@ -37,6 +40,12 @@ export const SettingsForm: React.FC<SettingsFormProps> = ({
 				const key = result.config.openAIAPIKey || "";
 				setOpenAIAPIKey(key);
 				setOriginalKey(key);
+
+				const rows = result.config.maxResultRows;
+				const mb = Math.round(result.config.maxResultBytes / (1024 * 1024));
+				setMaxResultRows(rows);
+				setMaxResultMB(mb);
+				setOriginalLimits({ rows, mb });
 			}
 		} catch (error) {
 			console.error("Failed to load config:", error);
@@ -53,11 +62,14 @@ export const SettingsForm: React.FC<SettingsFormProps> = ({
 
 			const result = await SetConfig({
 				openAIAPIKey: openAIAPIKey,
+				maxResultRows,
+				maxResultBytes: maxResultMB * 1024 * 1024,
 			});
 
 			if (result.success) {
 				setMessage("Configuration saved successfully!");
 				setOriginalKey(openAIAPIKey);
+				setOriginalLimits({ rows: maxResultRows, mb: maxResultMB });
 				setTimeout(() => {
 					setMessage("");
 					onClose();
@@ -75,11 +87,16 @@ export const SettingsForm: React.FC<SettingsFormProps> = ({
 
 	const handleCancel = () => {
 		setOpenAIAPIKey(originalKey);
+		setMaxResultRows(originalLimits.rows);
+		setMaxResultMB(originalLimits.mb);
 		setMessage("");
 		onClose();
 	};
 
-	const hasChanges = openAIAPIKey !== originalKey;
+	const hasChanges =
+		openAIAPIKey !== originalKey ||
+		maxResultRows !== originalLimits.rows ||
+		maxResultMB !== originalLimits.mb;
 
 	if (!isOpen) return null;
 
@@ -146,6 +163,44 @@ export const SettingsForm: React.FC<SettingsFormProps> = ({
 							</p>
 						</div>
 
+						<div className="grid grid-cols-2 gap-4">
+							<div className="space-y-2">
+								<Label
+									htmlFor="max-result-rows"
+									className="text-gray-700 dark:text-gray-300"
+								>
+									Max result rows
+								</Label>
+								<Input
+									id="max-result-rows"
+									type="number"
+									min={1}
+									value={maxResultRows}
+									onChange={(e) => setMaxResultRows(Number(e.target.value))}
+									className="border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+								/>
+							</div>
+							<div className="space-y-2">
+								<Label
+									htmlFor="max-result-mb"
+									className="text-gray-700 dark:text-gray-300"
+								>
+									Max result size (MB)
+								</Label>
+								<Input
+									id="max-result-mb"
+									type="number"
+									min={1}
+									value={maxResultMB}
+									onChange={(e) => setMaxResultMB(Number(e.target.value))}
+									className="border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+								/>
+							</div>
+						</div>
+						<p className="text-gray-500 text-sm dark:text-gray-400">
+							Query results are truncated past these limits
+						</p>
+
 						{message && (
 							<div
 								className={`rounded-md border p-3 text-sm ${
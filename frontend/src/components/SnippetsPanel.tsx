@@ -0,0 +1,355 @@
+import { Bookmark, Folder, Pencil, Play, Search, Trash2 } from "lucide-react";
+import type React from "react";
+import { useCallback, useEffect, useState } from "react";
+import { CreateSnippet } from "../../wailsjs/go/handlers/CreateSnippetHandler";
+import { DeleteSnippet } from "../../wailsjs/go/handlers/DeleteSnippetHandler";
+import { SearchSnippets } from "../../wailsjs/go/handlers/SearchSnippetsHandler";
+import { UpdateSnippet } from "../../wailsjs/go/handlers/UpdateSnippetHandler";
+import type { types } from "../../wailsjs/go/models";
+import { Button } from "./ui/button";
+import { Input } from "./ui/input";
+
+interface SnippetsPanelProps {
+	// connectionId lists the snippets of the active connection with the global ones
+	connectionId?: string | null;
+	// query is the text of the editor, offered when saving a new snippet
+	query: string;
+	onOpen: (snippet: types.Snippet) => void;
+	onRun: (snippet: types.Snippet, variables: Record<string, string>) => void;
+	onClose: () => void;
+}
+
+interface SnippetForm {
+	id?: string;
+	name: string;
+	description: string;
+	folder: string;
+	global: boolean;
+	query: string;
+}
+
+const inputClassName =
+	"border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white";
+
+// SnippetsPanel lists the saved snippets by folder, saves, edits and deletes
+// them and asks the values of their ${name} variables before running one
+export const SnippetsPanel: React.FC<SnippetsPanelProps> = ({
+	connectionId,
+	query,
+	onOpen,
+	onRun,
+	onClose,
+}) => {
+	const [text, setText] = useState("");
+	const [snippets, setSnippets] = useState<types.Snippet[]>([]);
+	const [form, setForm] = useState<SnippetForm>();
+	// running is the snippet whose variable values are being asked
+	const [running, setRunning] = useState<types.Snippet>();
+	const [variables, setVariables] = useState<Record<string, string>>({});
+	const [message, setMessage] = useState<string>();
+
+	const search = useCallback(async () => {
+		const response = await SearchSnippets({
+			text,
+			connectionId: connectionId ?? "",
+		});
+		if (response?.success) {
+			setSnippets(response.snippets);
+		} else {
+			setMessage(response?.message || "Failed to search the snippets");
+		}
+	}, [text, connectionId]);
+
+	useEffect(() => {
+		const timer = setTimeout(search, 200);
+		return () => clearTimeout(timer);
+	}, [search]);
+
+	const handleSave = async () => {
+		if (!form) return;
+		const input = {
+			name: form.name,
+			description: form.description,
+			folder: form.folder,
+			connectionId: form.global ? "" : (connectionId ?? ""),
+			query: form.query,
+		};
+		const response = form.id
+			? await UpdateSnippet({ id: form.id, ...input })
+			: await CreateSnippet(input);
+		setMessage(response?.message);
+		if (response?.success) {
+			setForm(undefined);
+			search();
+		}
+	};
+
+	const handleDelete = async (snippet: types.Snippet) => {
+		const response = await DeleteSnippet({ id: snippet.id });
+		setMessage(response?.message);
+		if (response?.success) search();
+	};
+
+	const handleRun = (snippet: types.Snippet) => {
+		if (!snippet.variables?.length) {
+			onRun(snippet, {});
+			return;
+		}
+		setVariables(
+			Object.fromEntries(
+				snippet.variables.map((name) => [name, variables[name] ?? ""]),
+			),
+		);
+		setRunning(snippet);
+	};
+
+	// The snippets come sorted by folder, so each folder is a run of the list
+	const folders: { folder: string; snippets: types.Snippet[] }[] = [];
+	for (const snippet of snippets) {
+		const last = folders[folders.length - 1];
+		if (last && last.folder === snippet.folder) {
+			last.snippets.push(snippet);
+		} else {
+			folders.push({ folder: snippet.folder, snippets: [snippet] });
+		}
+	}
+
+	return (
+		<div className="fixed inset-0 z-50 flex items-center justify-center bg-black/50">
+			<div className="flex max-h-[85vh] w-full max-w-3xl flex-col space-y-4 rounded-lg border border-gray-200 bg-white p-6 shadow-lg dark:border-gray-700 dark:bg-gray-800">
+				<div className="flex items-center space-x-2">
+					<Bookmark className="h-5 w-5 text-gray-700 dark:text-gray-300" />
+					<h2 className="font-semibold text-gray-900 text-lg dark:text-white">
+						Snippets
+					</h2>
+				</div>
+
+				{form ? (
+					<div className="flex flex-col space-y-2">
+						<Input
+							autoFocus
+							placeholder="Name"
+							value={form.name}
+							onChange={(e) => setForm({ ...form, name: e.target.value })}
+							className={inputClassName}
+						/>
+						<Input
+							placeholder="Folder, e.g. diagnostics/locks"
+							value={form.folder}
+							onChange={(e) => setForm({ ...form, folder: e.target.value })}
+							className={inputClassName}
+						/>
+						<Input
+							placeholder="Description"
+							value={form.description}
+							onChange={(e) =>
+								setForm({ ...form, description: e.target.value })
+							}
+							className={inputClassName}
+						/>
+						<textarea
+							rows={8}
+							placeholder="SELECT * FROM orders WHERE status = ${status}"
+							value={form.query}
+							onChange={(e) => setForm({ ...form, query: e.target.value })}
+							className="rounded border border-gray-300 p-2 font-mono text-sm dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+						/>
+						<label className="flex items-center space-x-1 text-gray-600 text-sm dark:text-gray-300">
+							<input
+								type="checkbox"
+								checked={form.global || !connectionId}
+								disabled={!connectionId}
+								onChange={(e) => setForm({ ...form, global: e.target.checked })}
+							/>
+							<span>Available on every connection</span>
+						</label>
+						<div className="flex justify-end space-x-2">
+							<Button
+								type="button"
+								variant="outline"
+								onClick={() => setForm(undefined)}
+							>
+								Cancel
+							</Button>
+							<Button
+								type="button"
+								disabled={!form.name.trim() || !form.query.trim()}
+								onClick={handleSave}
+							>
+								Save
+							</Button>
+						</div>
+					</div>
+				) : running ? (
+					<div className="flex flex-col space-y-2">
+						<div className="text-gray-700 text-sm dark:text-gray-300">
+							Values for <span className="font-medium">{running.name}</span>
+						</div>
+						{running.variables.map((name) => (
+							<label
+								key={name}
+								className="flex items-center space-x-2 text-gray-600 text-sm dark:text-gray-300"
+							>
+								<span className="w-40 truncate font-mono">{name}</span>
+								<Input
+									value={variables[name] ?? ""}
+									onChange={(e) =>
+										setVariables({ ...variables, [name]: e.target.value })
+									}
+									className={inputClassName}
+								/>
+							</label>
+						))}
+						<div className="flex justify-end space-x-2">
+							<Button
+								type="button"
+								variant="outline"
+								onClick={() => setRunning(undefined)}
+							>
+								Back
+							</Button>
+							<Button
+								type="button"
+								onClick={() => {
+									setRunning(undefined);
+									onRun(running, variables);
+								}}
+							>
+								<Play className="mr-2 h-4 w-4" />
+								Run
+							</Button>
+						</div>
+					</div>
+				) : (
+					<>
+						<div className="relative">
+							<Search className="absolute top-2.5 left-2 h-4 w-4 text-gray-400" />
+							<Input
+								autoFocus
+								placeholder="Search snippets"
+								value={text}
+								onChange={(e) => setText(e.target.value)}
+								className={`pl-8 ${inputClassName}`}
+							/>
+						</div>
+
+						<div className="flex-1 overflow-y-auto rounded border border-gray-200 dark:border-gray-700">
+							{folders.length === 0 && (
+								<div className="p-4 text-center text-gray-500 text-sm dark:text-gray-400">
+									No snippets found
+								</div>
+							)}
+							{folders.map(({ folder, snippets }) => (
+								<div key={folder}>
+									{folder && (
+										<div className="flex items-center bg-gray-50 px-3 py-1 text-gray-600 text-xs dark:bg-gray-900 dark:text-gray-400">
+											<Folder className="mr-1 h-3 w-3" />
+											{folder}
+										</div>
+									)}
+									{snippets.map((snippet) => (
+										<div
+											key={snippet.id}
+											className="flex items-center justify-between px-3 py-2 hover:bg-gray-50 dark:hover:bg-gray-700"
+										>
+											<button
+												type="button"
+												onClick={() => onOpen(snippet)}
+												className="min-w-0 flex-1 text-left"
+												title="Open in the editor"
+											>
+												<div className="truncate text-gray-900 text-sm dark:text-gray-100">
+													{snippet.name}
+													{!snippet.connectionId && (
+														<span className="ml-2 text-gray-400 text-xs">
+															global
+														</span>
+													)}
+												</div>
+												{snippet.description && (
+													<div className="truncate text-gray-500 text-xs dark:text-gray-400">
+														{snippet.description}
+													</div>
+												)}
+											</button>
+											<div className="flex items-center space-x-1">
+												<Button
+													type="button"
+													size="sm"
+													variant="ghost"
+													title="Run"
+													disabled={!connectionId}
+													onClick={() => handleRun(snippet)}
+												>
+													<Play className="h-4 w-4" />
+												</Button>
+												<Button
+													type="button"
+													size="sm"
+													variant="ghost"
+													title="Edit"
+													onClick={() =>
+														setForm({
+															id: snippet.id,
+															name: snippet.name,
+															description: snippet.description ?? "",
+															folder: snippet.folder,
+															global: !snippet.connectionId,
+															query: snippet.query,
+														})
+													}
+												>
+													<Pencil className="h-4 w-4" />
+												</Button>
+												<Button
+													type="button"
+													size="sm"
+													variant="ghost"
+													title="Delete"
+													onClick={() => handleDelete(snippet)}
+												>
+													<Trash2 className="h-4 w-4" />
+												</Button>
+											</div>
+										</div>
+									))}
+								</div>
+							))}
+						</div>
+					</>
+				)}
+
+				{message && (
+					<div className="text-gray-600 text-sm dark:text-gray-300">{message}</div>
+				)}
+
+				<div className="flex items-center justify-between pt-2">
+					<Button
+						type="button"
+						variant="outline"
+						disabled={Boolean(form || running)}
+						onClick={() =>
+							setForm({
+								name: "",
+								description: "",
+								folder: "",
+								global: !connectionId,
+								query,
+							})
+						}
+					>
+						Save editor as snippet
+					</Button>
+					<Button
+						type="button"
+						variant="outline"
+						onClick={onClose}
+						className="border-gray-300 text-gray-700 hover:bg-gray-50 dark:border-gray-600 dark:text-gray-300 dark:hover:bg-gray-700"
+					>
+						Close
+					</Button>
+				</div>
+			</div>
+		</div>
+	);
+};
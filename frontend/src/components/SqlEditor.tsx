@@ -1,4 +1,13 @@
-import { Loader2, Play, Square, Sparkles } from "lucide-react";
+import {
+	Bookmark,
+	FlaskConical,
+	History,
+	ListTree,
+	Loader2,
+	Play,
+	Square,
+	Sparkles,
+} from "lucide-react";
 import type React from "react";
 import { useEffect, useRef, useState } from "react";
 import Editor, { type OnMount } from "@monaco-editor/react";
@@ -13,8 +22,19 @@ interface SqlEditorProps {
 	onChange: (value: string) => void;
 	onExecute: (query: string) => void;
 	onStop?: () => void;
+	// onDryRun previews what the query changes and rolls it back
+	onDryRun?: (query: string) => void;
+	// onExplain shows the plan of the query, running it when analyzing
+	onExplain?: (query: string, analyze: boolean) => void;
+	// onShowHistory opens the queries that ran before
+	onShowHistory?: () => void;
+	// onShowSnippets opens the saved snippets
+	onShowSnippets?: () => void;
 	isExecuting?: boolean;
 	database?: string;
+	// continueOnError runs the rest of a script after a statement fails
+	continueOnError?: boolean;
+	onContinueOnErrorChange?: (continueOnError: boolean) => void;
 }
 
 export const SqlEditor: React.FC<SqlEditorProps> = ({
@@ -22,13 +42,20 @@ export const SqlEditor: React.FC<SqlEditorProps> = ({
 	onChange,
 	onExecute,
 	onStop,
+	onDryRun,
+	onExplain,
+	onShowHistory,
+	onShowSnippets,
 	isExecuting = false,
 	database,
+	continueOnError = false,
+	onContinueOnErrorChange,
 }) => {
 	const { state: activeConnection } = useActiveConnectionStore();
 	const { actualTheme } = useTheme();
 	const [selectedText, setSelectedText] = useState("");
 	const [isGenerating, setIsGenerating] = useState(false);
+	const [analyze, setAnalyze] = useState(false);
 	const editorRef = useRef<editor.IStandaloneCodeEditor | null>(null);
 	
 	
@@ -174,6 +201,75 @@ export const SqlEditor: React.FC<SqlEditorProps> = ({
 						)}
 					</Button>
 
+					{onDryRun && (
+						<Button
+							onClick={() => {
+								const queryToRun = (selectedText || value).trim();
+								if (queryToRun) onDryRun(queryToRun);
+							}}
+							disabled={isExecuting || isGenerating || !(selectedText || value).trim()}
+							size="sm"
+							variant="outline"
+							title="Run in a transaction that is rolled back and preview the changed rows"
+						>
+							<FlaskConical className="mr-2 h-4 w-4" />
+							Dry run
+						</Button>
+					)}
+
+					{onExplain && (
+						<>
+							<Button
+								onClick={() => {
+									const queryToRun = (selectedText || value).trim();
+									if (queryToRun) onExplain(queryToRun, analyze);
+								}}
+								disabled={isExecuting || isGenerating || !(selectedText || value).trim()}
+								size="sm"
+								variant="outline"
+								title="Show how the database runs the query"
+							>
+								<ListTree className="mr-2 h-4 w-4" />
+								Explain
+							</Button>
+							<label
+								className="flex items-center space-x-1 text-gray-600 text-sm dark:text-gray-300"
+								title="Run the query to measure its rows and time, the changes are rolled back"
+							>
+								<input
+									type="checkbox"
+									checked={analyze}
+									onChange={(e) => setAnalyze(e.target.checked)}
+								/>
+								<span>Analyze</span>
+							</label>
+						</>
+					)}
+
+					{onShowHistory && (
+						<Button
+							onClick={onShowHistory}
+							size="sm"
+							variant="outline"
+							title="Search the queries that ran before"
+						>
+							<History className="mr-2 h-4 w-4" />
+							History
+						</Button>
+					)}
+
+					{onShowSnippets && (
+						<Button
+							onClick={onShowSnippets}
+							size="sm"
+							variant="outline"
+							title="Save, search and run saved snippets"
+						>
+							<Bookmark className="mr-2 h-4 w-4" />
+							Snippets
+						</Button>
+					)}
+
 					{isExecuting && onStop && (
 						<Button onClick={onStop} size="sm" variant="destructive">
 							<Square className="mr-2 h-4 w-4" />
@@ -181,6 +277,17 @@ export const SqlEditor: React.FC<SqlEditorProps> = ({
 						</Button>
 					)}
 
+					{onContinueOnErrorChange && (
+						<label className="flex items-center space-x-1 text-gray-600 text-sm dark:text-gray-300">
+							<input
+								type="checkbox"
+								checked={continueOnError}
+								onChange={(e) => onContinueOnErrorChange(e.target.checked)}
+							/>
+							<span>Continue on error</span>
+						</label>
+					)}
+
 					<div className="text-gray-600 text-sm dark:text-gray-300">
 						{database && `Database: ${database}`}
 					</div>
@@ -0,0 +1,62 @@
+import { GitCommitHorizontal, Undo2 } from "lucide-react";
+import type React from "react";
+import type { types } from "../../wailsjs/go/models";
+import { Button } from "./ui/button";
+
+interface TransactionBarProps {
+	transaction?: types.TransactionState;
+	disabled?: boolean;
+	onBegin: () => void;
+	onCommit: () => void;
+	onRollback: () => void;
+}
+
+// TransactionBar shows the transaction open on the database, with its
+// uncommitted changes, and begins, commits or rolls it back
+export const TransactionBar: React.FC<TransactionBarProps> = ({
+	transaction,
+	disabled = false,
+	onBegin,
+	onCommit,
+	onRollback,
+}) => {
+	if (!transaction?.active) {
+		return (
+			<div className="flex items-center justify-between border-gray-200 border-b bg-gray-50 px-4 py-1 text-gray-600 text-xs dark:border-gray-600 dark:bg-gray-700 dark:text-gray-400">
+				<span>Autocommit</span>
+				<Button onClick={onBegin} disabled={disabled} size="sm" variant="ghost">
+					Begin transaction
+				</Button>
+			</div>
+		);
+	}
+
+	return (
+		<div className="flex items-center justify-between border-gray-200 border-b bg-amber-50 px-4 py-1 text-amber-800 text-xs dark:border-gray-600 dark:bg-amber-900/20 dark:text-amber-300">
+			<span>
+				Transaction open since{" "}
+				{new Date(transaction.startedAt).toLocaleTimeString()}
+				{transaction.dirty && (
+					<span className="ml-2 rounded bg-amber-200 px-1.5 py-0.5 font-medium dark:bg-amber-800">
+						Uncommitted changes
+					</span>
+				)}
+			</span>
+			<div className="flex items-center space-x-2">
+				<Button onClick={onCommit} disabled={disabled} size="sm" variant="ghost">
+					<GitCommitHorizontal className="mr-1 h-4 w-4" />
+					Commit
+				</Button>
+				<Button
+					onClick={onRollback}
+					disabled={disabled}
+					size="sm"
+					variant="ghost"
+				>
+					<Undo2 className="mr-1 h-4 w-4" />
+					Rollback
+				</Button>
+			</div>
+		</div>
+	);
+};
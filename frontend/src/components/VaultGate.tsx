@@ -0,0 +1,164 @@
+import { Lock } from "lucide-react";
+import type React from "react";
+import { useCallback, useEffect, useState } from "react";
+import { CreateVault } from "../../wailsjs/go/handlers/CreateVaultHandler";
+import { GetVaultStatus } from "../../wailsjs/go/handlers/GetVaultStatusHandler";
+import { UnlockVault } from "../../wailsjs/go/handlers/UnlockVaultHandler";
+import { Button } from "./ui/button";
+import { Input } from "./ui/input";
+import { Label } from "./ui/label";
+
+const STATUS_POLL_INTERVAL = 30 * 1000;
+
+interface VaultGateProps {
+	children: React.ReactNode;
+}
+
+// VaultGate asks for the master passphrase on start and whenever the vault
+// locks itself after being idle. Creating the vault can be put off, secrets
+// are saved in plaintext until it exists.
+export const VaultGate: React.FC<VaultGateProps> = ({ children }) => {
+	const [initialized, setInitialized] = useState(true);
+	const [locked, setLocked] = useState(false);
+	const [passphrase, setPassphrase] = useState("");
+	const [confirmation, setConfirmation] = useState("");
+	const [submitting, setSubmitting] = useState(false);
+	const [message, setMessage] = useState("");
+	const [postponed, setPostponed] = useState(false);
+
+	const refreshStatus = useCallback(async () => {
+		try {
+			const result = await GetVaultStatus();
+			if (result.success) {
+				setInitialized(result.status.initialized);
+				setLocked(result.status.locked);
+			}
+		} catch (error) {
+			console.error("Failed to get vault status:", error);
+		}
+	}, []);
+
+	useEffect(() => {
+		refreshStatus();
+		const interval = setInterval(refreshStatus, STATUS_POLL_INTERVAL);
+		return () => clearInterval(interval);
+	}, [refreshStatus]);
+
+	const handleSubmit = async (e: React.FormEvent) => {
+		e.preventDefault();
+		if (!initialized && passphrase !== confirmation) {
+			setMessage("Passphrases do not match");
+			return;
+		}
+
+		try {
+			setSubmitting(true);
+			setMessage("");
+			const result = initialized
+				? await UnlockVault({ passphrase })
+				: await CreateVault({ passphrase });
+			if (result.success) {
+				setPassphrase("");
+				setConfirmation("");
+				await refreshStatus();
+			} else {
+				setMessage(result.message || "Failed to unlock the vault");
+			}
+		} catch (error) {
+			console.error("Failed to unlock vault:", error);
+			setMessage("Failed to unlock the vault");
+		} finally {
+			setSubmitting(false);
+		}
+	};
+
+	return (
+		<>
+			{children}
+			{locked && (initialized || !postponed) && (
+				<div className="fixed inset-0 z-50 flex items-center justify-center bg-black/70">
+					<form
+						onSubmit={handleSubmit}
+						className="w-full max-w-md space-y-4 rounded-lg border border-gray-200 bg-white p-6 shadow-lg dark:border-gray-700 dark:bg-gray-800"
+					>
+						<div className="flex items-center space-x-2">
+							<Lock className="h-5 w-5 text-gray-700 dark:text-gray-300" />
+							<h2 className="font-semibold text-gray-900 text-lg dark:text-white">
+								{initialized ? "Unlock vault" : "Create vault"}
+							</h2>
+						</div>
+						<p className="text-gray-500 text-sm dark:text-gray-400">
+							{initialized
+								? "Enter the master passphrase to use saved passwords and API keys."
+								: "Choose a master passphrase. Saved passwords and API keys are encrypted with it, they stay in plaintext until then."}
+						</p>
+
+						<div className="space-y-2">
+							<Label
+								htmlFor="vault-passphrase"
+								className="text-gray-700 dark:text-gray-300"
+							>
+								Master passphrase
+							</Label>
+							<Input
+								id="vault-passphrase"
+								type="password"
+								autoFocus
+								value={passphrase}
+								onChange={(e) => setPassphrase(e.target.value)}
+								className="border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+							/>
+						</div>
+
+						{!initialized && (
+							<div className="space-y-2">
+								<Label
+									htmlFor="vault-confirmation"
+									className="text-gray-700 dark:text-gray-300"
+								>
+									Confirm passphrase
+								</Label>
+								<Input
+									id="vault-confirmation"
+									type="password"
+									value={confirmation}
+									onChange={(e) => setConfirmation(e.target.value)}
+									className="border-gray-300 dark:border-gray-600 dark:bg-gray-700 dark:text-white"
+								/>
+							</div>
+						)}
+
+						{message && (
+							<div className="rounded-md border border-red-200 bg-red-50 p-3 text-red-700 text-sm dark:border-red-700 dark:bg-red-900/20 dark:text-red-400">
+								{message}
+							</div>
+						)}
+
+						<div className="flex justify-end space-x-2 pt-2">
+							{!initialized && (
+								<Button
+									type="button"
+									variant="outline"
+									onClick={() => setPostponed(true)}
+								>
+									Not now
+								</Button>
+							)}
+							<Button
+								type="submit"
+								disabled={submitting || !passphrase}
+								className="bg-blue-600 text-white hover:bg-blue-700 dark:bg-blue-700 dark:hover:bg-blue-800"
+							>
+								{submitting
+									? "Unlocking..."
+									: initialized
+										? "Unlock"
+										: "Create"}
+							</Button>
+						</div>
+					</form>
+				</div>
+			)}
+		</>
+	);
+};
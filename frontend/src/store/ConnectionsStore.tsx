@@ -123,7 +123,7 @@ export const ConnectionsProvider: React.FC<ConnectionsProviderProps> = ({
 
 			try {
 				dispatch({ type: "SET_LOADING", payload: true });
-				const result = await ListConnections();
+				const result = await ListConnections({});
 				
 				if (result.success) {
 					dispatch({ 
@@ -2,4 +2,4 @@
 // This file is automatically generated. DO NOT EDIT
 import {handlers} from '../models';
 
-export function ListConnections():Promise<handlers.ListConnectionsOutput>;
+export function ListConnections(arg1:handlers.ListConnectionsInput):Promise<handlers.ListConnectionsOutput>;
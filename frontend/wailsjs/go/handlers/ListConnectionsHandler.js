@@ -2,6 +2,6 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function ListConnections() {
-  return window['go']['handlers']['ListConnectionsHandler']['ListConnections']();
+export function ListConnections(arg1) {
+  return window['go']['handlers']['ListConnectionsHandler']['ListConnections'](arg1);
 }
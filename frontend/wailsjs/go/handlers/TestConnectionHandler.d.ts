@@ -2,4 +2,4 @@
 // This file is automatically generated. DO NOT EDIT
 import {handlers} from '../models';
 
-export function TestConnection(arg1:handlers.TestConnectionInput):Promise<void>;
+export function TestConnection(arg1:handlers.TestConnectionInput):Promise<handlers.TestConnectionOutput>;
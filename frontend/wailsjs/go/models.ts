@@ -28,6 +28,8 @@ export namespace handlers {
 	}
 	export class AppConfig {
 	    openAIAPIKey: string;
+	    maxResultRows: number;
+	    maxResultBytes: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new AppConfig(source);
@@ -36,134 +38,138 @@ export namespace handlers {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.openAIAPIKey = source["openAIAPIKey"];
+	        this.maxResultRows = source["maxResultRows"];
+	        this.maxResultBytes = source["maxResultBytes"];
 	    }
 	}
-	export class ConnectByIDInput {
+	export class BeginTransactionInput {
 	    id: string;
+	    database: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectByIDInput(source);
+	        return new BeginTransactionInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
+	        this.database = source["database"];
 	    }
 	}
-	export class ConnectByIDOutput {
+	export class BeginTransactionOutput {
 	    success: boolean;
-	    message: string;
-	    databases?: string[];
+	    message?: string;
+	    transaction?: types.TransactionState;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectByIDOutput(source);
+	        return new BeginTransactionOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.databases = source["databases"];
+	        this.transaction = this.convertValues(source["transaction"], types.TransactionState);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class ConnectInput {
-	    host: string;
-	    port: number;
-	    database: string;
-	    username: string;
-	    password: string;
-	    sslmode: string;
-	    connectionString: string;
-	    useConnectionString: boolean;
+	export class CancelQueryInput {
+	    executionId: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectInput(source);
+	        return new CancelQueryInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.host = source["host"];
-	        this.port = source["port"];
-	        this.database = source["database"];
-	        this.username = source["username"];
-	        this.password = source["password"];
-	        this.sslmode = source["sslmode"];
-	        this.connectionString = source["connectionString"];
-	        this.useConnectionString = source["useConnectionString"];
+	        this.executionId = source["executionId"];
 	    }
 	}
-	export class ConnectOutput {
+	export class CancelQueryOutput {
 	    success: boolean;
 	    message?: string;
-	    databases?: string[];
-	    id: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectOutput(source);
+	        return new CancelQueryOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.databases = source["databases"];
-	        this.id = source["id"];
 	    }
 	}
-	export class DeleteConnectionInput {
-	    id: string;
+	export class CloseResultInput {
+	    resultId: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new DeleteConnectionInput(source);
+	        return new CloseResultInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
+	        this.resultId = source["resultId"];
 	    }
 	}
-	export class DisconnectInput {
-	    id: string;
+	export class CloseResultOutput {
+	    success: boolean;
+	    message?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new DisconnectInput(source);
+	        return new CloseResultOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
+	        this.success = source["success"];
+	        this.message = source["message"];
 	    }
 	}
-	export class ExecuteQueryInput {
+	export class CommitTransactionInput {
 	    id: string;
 	    database: string;
-	    query: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ExecuteQueryInput(source);
+	        return new CommitTransactionInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.database = source["database"];
-	        this.query = source["query"];
 	    }
 	}
-	export class ExecuteQueryOutput {
+	export class CommitTransactionOutput {
 	    success: boolean;
 	    message?: string;
-	    result?: types.QueryResult;
+	    transaction?: types.TransactionState;
 	
 	    static createFrom(source: any = {}) {
-	        return new ExecuteQueryOutput(source);
+	        return new CommitTransactionOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.result = this.convertValues(source["result"], types.QueryResult);
+	        this.transaction = this.convertValues(source["transaction"], types.TransactionState);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -184,36 +190,96 @@ export namespace handlers {
 		    return a;
 		}
 	}
-	export class GenerateQueryInput {
+	export class ConnectByIDInput {
 	    id: string;
-	    database: string;
-	    prompt: string;
+	    password?: string;
+	    rememberFor?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new GenerateQueryInput(source);
+	        return new ConnectByIDInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.database = source["database"];
-	        this.prompt = source["prompt"];
+	        this.password = source["password"];
+	        this.rememberFor = source["rememberFor"];
 	    }
 	}
-	export class GenerateQueryOutput {
+	export class ConnectByIDOutput {
 	    success: boolean;
-	    message?: string;
-	    result?: types.GenerateQueryResult;
+	    message: string;
+	    credentialsRequired?: boolean;
+	    databases?: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new GenerateQueryOutput(source);
+	        return new ConnectByIDOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.result = this.convertValues(source["result"], types.GenerateQueryResult);
+	        this.credentialsRequired = source["credentialsRequired"];
+	        this.databases = source["databases"];
+	    }
+	}
+	export class ConnectInput {
+	    vendor: string;
+	    host: string;
+	    port: number;
+	    database: string;
+	    username: string;
+	    password: string;
+	    passCommand?: string;
+	    passwordRef?: string;
+	    promptPassword?: boolean;
+	    readOnly?: boolean;
+	    manualCommit?: boolean;
+	    statementTimeout?: number;
+	    sslmode: string;
+	    arguments?: Record<string, string>;
+	    ssh?: types.SSHTunnelConfig;
+	    tls?: types.TLSConfig;
+	    failoverHosts?: types.Endpoint[];
+	    name?: string;
+	    folder?: string;
+	    tags?: string[];
+	    environment?: string;
+	    color?: string;
+	    connectionString: string;
+	    useConnectionString: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.vendor = source["vendor"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.database = source["database"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.passCommand = source["passCommand"];
+	        this.passwordRef = source["passwordRef"];
+	        this.promptPassword = source["promptPassword"];
+	        this.readOnly = source["readOnly"];
+	        this.manualCommit = source["manualCommit"];
+	        this.statementTimeout = source["statementTimeout"];
+	        this.sslmode = source["sslmode"];
+	        this.arguments = source["arguments"];
+	        this.ssh = this.convertValues(source["ssh"], types.SSHTunnelConfig);
+	        this.tls = this.convertValues(source["tls"], types.TLSConfig);
+	        this.failoverHosts = this.convertValues(source["failoverHosts"], types.Endpoint);
+	        this.name = source["name"];
+	        this.folder = source["folder"];
+	        this.tags = source["tags"];
+	        this.environment = source["environment"];
+	        this.color = source["color"];
+	        this.connectionString = source["connectionString"];
+	        this.useConnectionString = source["useConnectionString"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -234,70 +300,58 @@ export namespace handlers {
 		    return a;
 		}
 	}
-	export class GetConfigOutput {
+	export class ConnectOutput {
 	    success: boolean;
-	    message: string;
-	    config: AppConfig;
+	    message?: string;
+	    databases?: string[];
+	    id: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GetConfigOutput(source);
+	        return new ConnectOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.config = this.convertValues(source["config"], AppConfig);
+	        this.databases = source["databases"];
+	        this.id = source["id"];
 	    }
-	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
 	}
-	export class GetTableColumnsInput {
-	    id: string;
-	    database: string;
-	    table: string;
+	export class CreateSnippetInput {
+	    name: string;
+	    description?: string;
+	    folder?: string;
+	    connectionId?: string;
+	    query: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GetTableColumnsInput(source);
+	        return new CreateSnippetInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.database = source["database"];
-	        this.table = source["table"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.folder = source["folder"];
+	        this.connectionId = source["connectionId"];
+	        this.query = source["query"];
 	    }
 	}
-	export class GetTableColumnsOutput {
+	export class CreateSnippetOutput {
 	    success: boolean;
 	    message?: string;
-	    columns?: types.TableColumn[];
+	    snippet?: types.Snippet;
 	
 	    static createFrom(source: any = {}) {
-	        return new GetTableColumnsOutput(source);
+	        return new CreateSnippetOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.columns = this.convertValues(source["columns"], types.TableColumn);
+	        this.snippet = this.convertValues(source["snippet"], types.Snippet);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -318,50 +372,96 @@ export namespace handlers {
 		    return a;
 		}
 	}
-	export class GetTablesInput {
-	    id: string;
-	    database: string;
+	export class CreateVaultInput {
+	    passphrase: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new GetTablesInput(source);
+	        return new CreateVaultInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.database = source["database"];
+	        this.passphrase = source["passphrase"];
 	    }
 	}
-	export class GetTablesOutput {
+	export class CreateVaultOutput {
 	    success: boolean;
 	    message?: string;
-	    tables?: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new GetTablesOutput(source);
+	        return new CreateVaultOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.tables = source["tables"];
 	    }
 	}
-	export class ListConnectionsOutput {
+	export class DeleteConnectionInput {
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeleteConnectionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	    }
+	}
+	export class DeleteSnippetInput {
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeleteSnippetInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	    }
+	}
+	export class DeleteSnippetOutput {
 	    success: boolean;
-	    message: string;
-	    connections: types.ConnectionSummary[];
+	    message?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ListConnectionsOutput(source);
+	        return new DeleteSnippetOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
-	        this.connections = this.convertValues(source["connections"], types.ConnectionSummary);
+	    }
+	}
+	export class DisconnectInput {
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DisconnectInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	    }
+	}
+	export class DuplicateConnectionInput {
+	    id: string;
+	    config?: types.DatabaseConfig;
+	    test: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DuplicateConnectionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.config = this.convertValues(source["config"], types.DatabaseConfig);
+	        this.test = source["test"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -382,109 +482,2207 @@ export namespace handlers {
 		    return a;
 		}
 	}
-	export class SetConfigInput {
-	    openAIAPIKey: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new SetConfigInput(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.openAIAPIKey = source["openAIAPIKey"];
-	    }
-	}
-	export class SetConfigOutput {
+	export class DuplicateConnectionOutput {
 	    success: boolean;
-	    message: string;
+	    message?: string;
+	    id: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SetConfigOutput(source);
+	        return new DuplicateConnectionOutput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.success = source["success"];
 	        this.message = source["message"];
+	        this.id = source["id"];
 	    }
 	}
-	export class TestConnectionInput {
-	    host: string;
-	    port: number;
+	export class ExecuteQueryInput {
+	    id: string;
 	    database: string;
-	    username: string;
-	    password: string;
-	    sslmode: string;
-	    connectionString: string;
-	    useConnectionString: boolean;
+	    query: string;
+	    executionId?: string;
+	    pageSize?: number;
+	    confirmationToken?: string;
+	    continueOnError?: boolean;
+	    parameters?: types.QueryParameterValue[];
+	    dryRun?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new TestConnectionInput(source);
+	        return new ExecuteQueryInput(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.host = source["host"];
+	        this.id = source["id"];
+	        this.database = source["database"];
+	        this.query = source["query"];
+	        this.executionId = source["executionId"];
+	        this.pageSize = source["pageSize"];
+	        this.confirmationToken = source["confirmationToken"];
+	        this.continueOnError = source["continueOnError"];
+	        this.parameters = this.convertValues(source["parameters"], types.QueryParameterValue);
+	        this.dryRun = source["dryRun"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExecuteQueryOutput {
+	    success: boolean;
+	    message?: string;
+	    result?: types.QueryResult;
+	    statements?: types.StatementResult[];
+	    confirmation?: types.QueryConfirmation;
+	    transaction?: types.TransactionState;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExecuteQueryOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.result = this.convertValues(source["result"], types.QueryResult);
+	        this.statements = this.convertValues(source["statements"], types.StatementResult);
+	        this.confirmation = this.convertValues(source["confirmation"], types.QueryConfirmation);
+	        this.transaction = this.convertValues(source["transaction"], types.TransactionState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExplainQueryInput {
+	    id: string;
+	    database: string;
+	    query: string;
+	    analyze?: boolean;
+	    buffers?: boolean;
+	    parameters?: types.QueryParameterValue[];
+	    executionId?: string;
+	    confirmationToken?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExplainQueryInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.database = source["database"];
+	        this.query = source["query"];
+	        this.analyze = source["analyze"];
+	        this.buffers = source["buffers"];
+	        this.parameters = this.convertValues(source["parameters"], types.QueryParameterValue);
+	        this.executionId = source["executionId"];
+	        this.confirmationToken = source["confirmationToken"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExplainQueryOutput {
+	    success: boolean;
+	    message?: string;
+	    plan?: types.QueryPlan;
+	    confirmation?: types.QueryConfirmation;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExplainQueryOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.plan = this.convertValues(source["plan"], types.QueryPlan);
+	        this.confirmation = this.convertValues(source["confirmation"], types.QueryConfirmation);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FetchResultPageInput {
+	    resultId: string;
+	    pageSize?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchResultPageInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.resultId = source["resultId"];
+	        this.pageSize = source["pageSize"];
+	    }
+	}
+	export class FetchResultPageOutput {
+	    success: boolean;
+	    message?: string;
+	    result?: types.QueryResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchResultPageOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.result = this.convertValues(source["result"], types.QueryResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FormatConnectionStringInput {
+	    id: string;
+	    format: string;
+	    includePassword: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormatConnectionStringInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.format = source["format"];
+	        this.includePassword = source["includePassword"];
+	    }
+	}
+	export class FormatConnectionStringOutput {
+	    success: boolean;
+	    message?: string;
+	    connectionString?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormatConnectionStringOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.connectionString = source["connectionString"];
+	    }
+	}
+	export class GenerateQueryInput {
+	    id: string;
+	    database: string;
+	    prompt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerateQueryInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.database = source["database"];
+	        this.prompt = source["prompt"];
+	    }
+	}
+	export class GenerateQueryOutput {
+	    success: boolean;
+	    message?: string;
+	    result?: types.GenerateQueryResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerateQueryOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.result = this.convertValues(source["result"], types.GenerateQueryResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GetConfigOutput {
+	    success: boolean;
+	    message: string;
+	    config: AppConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetConfigOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.config = this.convertValues(source["config"], AppConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GetQueryParametersInput {
+	    id: string;
+	    query: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetQueryParametersInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.query = source["query"];
+	    }
+	}
+	export class GetQueryParametersOutput {
+	    success: boolean;
+	    message?: string;
+	    parameters: types.QueryParameter[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GetQueryParametersOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.parameters = this.convertValues(source["parameters"], types.QueryParameter);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GetTableColumnsInput {
+	    id: string;
+	    database: string;
+	    table: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetTableColumnsInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.database = source["database"];
+	        this.table = source["table"];
+	    }
+	}
+	export class GetTableColumnsOutput {
+	    success: boolean;
+	    message?: string;
+	    columns?: types.TableColumn[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GetTableColumnsOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.columns = this.convertValues(source["columns"], types.TableColumn);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GetTablesInput {
+	    id: string;
+	    database: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetTablesInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.database = source["database"];
+	    }
+	}
+	export class GetTablesOutput {
+	    success: boolean;
+	    message?: string;
+	    tables?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GetTablesOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.tables = source["tables"];
+	    }
+	}
+	export class GetVaultStatusOutput {
+	    success: boolean;
+	    message?: string;
+	    status: types.VaultStatus;
+	
+	    static createFrom(source: any = {}) {
+	        return new GetVaultStatusOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.status = this.convertValues(source["status"], types.VaultStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportConnectionsInput {
+	    sources: types.ImportRequest[];
+	    keys: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportConnectionsInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sources = this.convertValues(source["sources"], types.ImportRequest);
+	        this.keys = source["keys"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportConnectionsOutput {
+	    success: boolean;
+	    message?: string;
+	    connections: types.ConnectionSummary[];
+	    errors: types.ImportError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportConnectionsOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.connections = this.convertValues(source["connections"], types.ConnectionSummary);
+	        this.errors = this.convertValues(source["errors"], types.ImportError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ListConnectionsInput {
+	    folder?: string;
+	    tag?: string;
+	    environment?: string;
+	    search?: string;
+	    groupBy?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ListConnectionsInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.folder = source["folder"];
+	        this.tag = source["tag"];
+	        this.environment = source["environment"];
+	        this.search = source["search"];
+	        this.groupBy = source["groupBy"];
+	    }
+	}
+	export class ListConnectionsOutput {
+	    success: boolean;
+	    message: string;
+	    connections: types.ConnectionSummary[];
+	    groups?: types.ConnectionGroup[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ListConnectionsOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.connections = this.convertValues(source["connections"], types.ConnectionSummary);
+	        this.groups = this.convertValues(source["groups"], types.ConnectionGroup);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ListImportSourcesOutput {
+	    success: boolean;
+	    message?: string;
+	    sources: types.ImportSource[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ListImportSourcesOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.sources = this.convertValues(source["sources"], types.ImportSource);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ListVendorsOutput {
+	    success: boolean;
+	    message?: string;
+	    vendors: types.VendorCapabilities[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ListVendorsOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.vendors = this.convertValues(source["vendors"], types.VendorCapabilities);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LockVaultOutput {
+	    success: boolean;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LockVaultOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	    }
+	}
+	export class OpenHistoryEntryInput {
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OpenHistoryEntryInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	    }
+	}
+	export class OpenHistoryEntryOutput {
+	    success: boolean;
+	    message?: string;
+	    entry?: types.HistoryEntry;
+	
+	    static createFrom(source: any = {}) {
+	        return new OpenHistoryEntryOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.entry = this.convertValues(source["entry"], types.HistoryEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ParseConnectionStringInput {
+	    connectionString: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParseConnectionStringInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.connectionString = source["connectionString"];
+	    }
+	}
+	export class ParseConnectionStringOutput {
+	    success: boolean;
+	    message?: string;
+	    config?: types.DatabaseConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParseConnectionStringOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.config = this.convertValues(source["config"], types.DatabaseConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PreviewImportInput {
+	    sources: types.ImportRequest[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PreviewImportInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sources = this.convertValues(source["sources"], types.ImportRequest);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PreviewImportOutput {
+	    success: boolean;
+	    message?: string;
+	    candidates: types.ImportCandidate[];
+	    errors: types.ImportError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PreviewImportOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.candidates = this.convertValues(source["candidates"], types.ImportCandidate);
+	        this.errors = this.convertValues(source["errors"], types.ImportError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PruneHistoryInput {
+	    olderThanDays?: number;
+	    maxBytes?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PruneHistoryInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.olderThanDays = source["olderThanDays"];
+	        this.maxBytes = source["maxBytes"];
+	    }
+	}
+	export class PruneHistoryOutput {
+	    success: boolean;
+	    message?: string;
+	    removed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PruneHistoryOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.removed = source["removed"];
+	    }
+	}
+	export class RollbackTransactionInput {
+	    id: string;
+	    database: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RollbackTransactionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.database = source["database"];
+	    }
+	}
+	export class RollbackTransactionOutput {
+	    success: boolean;
+	    message?: string;
+	    transaction?: types.TransactionState;
+	
+	    static createFrom(source: any = {}) {
+	        return new RollbackTransactionOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.transaction = this.convertValues(source["transaction"], types.TransactionState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RunSnippetInput {
+	    snippetId: string;
+	    id: string;
+	    database: string;
+	    variables?: Record<string, string>;
+	    executionId?: string;
+	    pageSize?: number;
+	    confirmationToken?: string;
+	    continueOnError?: boolean;
+	    parameters?: types.QueryParameterValue[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RunSnippetInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.snippetId = source["snippetId"];
+	        this.id = source["id"];
+	        this.database = source["database"];
+	        this.variables = source["variables"];
+	        this.executionId = source["executionId"];
+	        this.pageSize = source["pageSize"];
+	        this.confirmationToken = source["confirmationToken"];
+	        this.continueOnError = source["continueOnError"];
+	        this.parameters = this.convertValues(source["parameters"], types.QueryParameterValue);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RunSnippetOutput {
+	    success: boolean;
+	    message?: string;
+	    result?: types.QueryResult;
+	    statements?: types.StatementResult[];
+	    confirmation?: types.QueryConfirmation;
+	    transaction?: types.TransactionState;
+	    query?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunSnippetOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.result = this.convertValues(source["result"], types.QueryResult);
+	        this.statements = this.convertValues(source["statements"], types.StatementResult);
+	        this.confirmation = this.convertValues(source["confirmation"], types.QueryConfirmation);
+	        this.transaction = this.convertValues(source["transaction"], types.TransactionState);
+	        this.query = source["query"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SearchHistoryInput {
+	    text?: string;
+	    connectionId?: string;
+	    from?: number;
+	    to?: number;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchHistoryInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.connectionId = source["connectionId"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class SearchHistoryOutput {
+	    success: boolean;
+	    message?: string;
+	    entries: types.HistoryEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchHistoryOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.entries = this.convertValues(source["entries"], types.HistoryEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SearchSnippetsInput {
+	    text?: string;
+	    connectionId?: string;
+	    folder?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchSnippetsInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.connectionId = source["connectionId"];
+	        this.folder = source["folder"];
+	    }
+	}
+	export class SearchSnippetsOutput {
+	    success: boolean;
+	    message?: string;
+	    snippets: types.Snippet[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchSnippetsOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.snippets = this.convertValues(source["snippets"], types.Snippet);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SetConfigInput {
+	    openAIAPIKey: string;
+	    maxResultRows?: number;
+	    maxResultBytes?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetConfigInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.openAIAPIKey = source["openAIAPIKey"];
+	        this.maxResultRows = source["maxResultRows"];
+	        this.maxResultBytes = source["maxResultBytes"];
+	    }
+	}
+	export class SetConfigOutput {
+	    success: boolean;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetConfigOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	    }
+	}
+	export class TestConnectionInput {
+	    vendor: string;
+	    host: string;
+	    port: number;
+	    database: string;
+	    username: string;
+	    password: string;
+	    passCommand?: string;
+	    passwordRef?: string;
+	    promptPassword?: boolean;
+	    readOnly?: boolean;
+	    manualCommit?: boolean;
+	    statementTimeout?: number;
+	    sslmode: string;
+	    arguments?: Record<string, string>;
+	    ssh?: types.SSHTunnelConfig;
+	    tls?: types.TLSConfig;
+	    failoverHosts?: types.Endpoint[];
+	    name?: string;
+	    folder?: string;
+	    tags?: string[];
+	    environment?: string;
+	    color?: string;
+	    connectionString: string;
+	    useConnectionString: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TestConnectionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.vendor = source["vendor"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.database = source["database"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.passCommand = source["passCommand"];
+	        this.passwordRef = source["passwordRef"];
+	        this.promptPassword = source["promptPassword"];
+	        this.readOnly = source["readOnly"];
+	        this.manualCommit = source["manualCommit"];
+	        this.statementTimeout = source["statementTimeout"];
+	        this.sslmode = source["sslmode"];
+	        this.arguments = source["arguments"];
+	        this.ssh = this.convertValues(source["ssh"], types.SSHTunnelConfig);
+	        this.tls = this.convertValues(source["tls"], types.TLSConfig);
+	        this.failoverHosts = this.convertValues(source["failoverHosts"], types.Endpoint);
+	        this.name = source["name"];
+	        this.folder = source["folder"];
+	        this.tags = source["tags"];
+	        this.environment = source["environment"];
+	        this.color = source["color"];
+	        this.connectionString = source["connectionString"];
+	        this.useConnectionString = source["useConnectionString"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TestConnectionOutput {
+	    success: boolean;
+	    message?: string;
+	    tls?: types.TLSStatus;
+	
+	    static createFrom(source: any = {}) {
+	        return new TestConnectionOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.tls = this.convertValues(source["tls"], types.TLSStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UnlockVaultInput {
+	    passphrase: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UnlockVaultInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.passphrase = source["passphrase"];
+	    }
+	}
+	export class UnlockVaultOutput {
+	    success: boolean;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UnlockVaultOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	    }
+	}
+	export class UpdateConnectionInput {
+	    id: string;
+	    config: types.DatabaseConfig;
+	    test: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateConnectionInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.config = this.convertValues(source["config"], types.DatabaseConfig);
+	        this.test = source["test"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateConnectionOutput {
+	    success: boolean;
+	    message?: string;
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateConnectionOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.id = source["id"];
+	    }
+	}
+	export class UpdateSnippetInput {
+	    id: string;
+	    name: string;
+	    description?: string;
+	    folder?: string;
+	    connectionId?: string;
+	    query: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateSnippetInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.folder = source["folder"];
+	        this.connectionId = source["connectionId"];
+	        this.query = source["query"];
+	    }
+	}
+	export class UpdateSnippetOutput {
+	    success: boolean;
+	    message?: string;
+	    snippet?: types.Snippet;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateSnippetOutput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.snippet = this.convertValues(source["snippet"], types.Snippet);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace types {
+	
+	export class ConnectionSummary {
+	    id: string;
+	    name: string;
+	    vendor: string;
+	    host: string;
+	    port: number;
+	    database: string;
+	    folder: string;
+	    tags: string[];
+	    environment: string;
+	    color: string;
+	    promptPassword: boolean;
+	    readOnly: boolean;
+	    manualCommit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.vendor = source["vendor"];
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.database = source["database"];
+	        this.folder = source["folder"];
+	        this.tags = source["tags"];
+	        this.environment = source["environment"];
+	        this.color = source["color"];
+	        this.promptPassword = source["promptPassword"];
+	        this.readOnly = source["readOnly"];
+	        this.manualCommit = source["manualCommit"];
+	    }
+	}
+	export class ConnectionGroup {
+	    key: string;
+	    connections: ConnectionSummary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.connections = this.convertValues(source["connections"], ConnectionSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Endpoint {
+	    host: string;
+	    port: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Endpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	    }
+	}
+	export class TLSConfig {
+	    mode?: string;
+	    caFile?: string;
+	    certFile?: string;
+	    keyFile?: string;
+	    serverName?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TLSConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.caFile = source["caFile"];
+	        this.certFile = source["certFile"];
+	        this.keyFile = source["keyFile"];
+	        this.serverName = source["serverName"];
+	    }
+	}
+	export class SSHTunnelConfig {
+	    host: string;
+	    port: number;
+	    user: string;
+	    password?: string;
+	    privateKeyFile?: string;
+	    passphrase?: string;
+	    passwordRef?: string;
+	    passphraseRef?: string;
+	    agentSocket?: string;
+	    knownHostsFile?: string;
+	    keepAliveInterval?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SSHTunnelConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.user = source["user"];
+	        this.password = source["password"];
+	        this.privateKeyFile = source["privateKeyFile"];
+	        this.passphrase = source["passphrase"];
+	        this.passwordRef = source["passwordRef"];
+	        this.passphraseRef = source["passphraseRef"];
+	        this.agentSocket = source["agentSocket"];
+	        this.knownHostsFile = source["knownHostsFile"];
+	        this.keepAliveInterval = source["keepAliveInterval"];
+	    }
+	}
+	export class DatabaseConfig {
+	    vendor: string;
+	    host: string;
+	    port: number;
+	    database: string;
+	    username: string;
+	    password: string;
+	    passCommand?: string;
+	    passwordRef?: string;
+	    promptPassword?: boolean;
+	    readOnly?: boolean;
+	    manualCommit?: boolean;
+	    statementTimeout?: number;
+	    sslmode: string;
+	    arguments?: Record<string, string>;
+	    ssh?: SSHTunnelConfig;
+	    tls?: TLSConfig;
+	    failoverHosts?: Endpoint[];
+	    name?: string;
+	    folder?: string;
+	    tags?: string[];
+	    environment?: string;
+	    color?: string;
+	    connectionString: string;
+	    useConnectionString: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.vendor = source["vendor"];
+	        this.host = source["host"];
 	        this.port = source["port"];
 	        this.database = source["database"];
 	        this.username = source["username"];
 	        this.password = source["password"];
+	        this.passCommand = source["passCommand"];
+	        this.passwordRef = source["passwordRef"];
+	        this.promptPassword = source["promptPassword"];
+	        this.readOnly = source["readOnly"];
+	        this.manualCommit = source["manualCommit"];
+	        this.statementTimeout = source["statementTimeout"];
 	        this.sslmode = source["sslmode"];
+	        this.arguments = source["arguments"];
+	        this.ssh = this.convertValues(source["ssh"], SSHTunnelConfig);
+	        this.tls = this.convertValues(source["tls"], TLSConfig);
+	        this.failoverHosts = this.convertValues(source["failoverHosts"], Endpoint);
+	        this.name = source["name"];
+	        this.folder = source["folder"];
+	        this.tags = source["tags"];
+	        this.environment = source["environment"];
+	        this.color = source["color"];
 	        this.connectionString = source["connectionString"];
 	        this.useConnectionString = source["useConnectionString"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DestructiveStatement {
+	    statement: string;
+	    kind: string;
+	    risk: string;
+	    estimatedRows?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DestructiveStatement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statement = source["statement"];
+	        this.kind = source["kind"];
+	        this.risk = source["risk"];
+	        this.estimatedRows = source["estimatedRows"];
+	    }
+	}
+	export class ResultColumn {
+	    name: string;
+	    databaseType: string;
+	    nullable?: boolean;
+	    length?: number;
+	    precision?: number;
+	    scale?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResultColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.databaseType = source["databaseType"];
+	        this.nullable = source["nullable"];
+	        this.length = source["length"];
+	        this.precision = source["precision"];
+	        this.scale = source["scale"];
+	    }
+	}
+	export class QueryResult {
+	    resultId?: string;
+	    columns: string[];
+	    columnTypes: ResultColumn[];
+	    rows: any[][];
+	    rowsAffected: number;
+	    duration: number;
+	    hasMore: boolean;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.resultId = source["resultId"];
+	        this.columns = source["columns"];
+	        this.columnTypes = this.convertValues(source["columnTypes"], ResultColumn);
+	        this.rows = source["rows"];
+	        this.rowsAffected = source["rowsAffected"];
+	        this.duration = source["duration"];
+	        this.hasMore = source["hasMore"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DryRunPreview {
+	    before?: QueryResult;
+	    after?: QueryResult;
+	    rowsAffected: number;
+	    note?: string;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DryRunPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.before = this.convertValues(source["before"], QueryResult);
+	        this.after = this.convertValues(source["after"], QueryResult);
+	        this.rowsAffected = source["rowsAffected"];
+	        this.note = source["note"];
+	        this.duration = source["duration"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class GenerateQueryResult {
+	    generatedQuery: string;
+	    originalPrompt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerateQueryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generatedQuery = source["generatedQuery"];
+	        this.originalPrompt = source["originalPrompt"];
+	    }
+	}
+	export class HistoryEntry {
+	    id: string;
+	    connectionId: string;
+	    connectionName?: string;
+	    database: string;
+	    query: string;
+	    startedAt: number;
+	    duration: number;
+	    rowCount: number;
+	    error?: string;
+	    dryRun?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.connectionId = source["connectionId"];
+	        this.connectionName = source["connectionName"];
+	        this.database = source["database"];
+	        this.query = source["query"];
+	        this.startedAt = source["startedAt"];
+	        this.duration = source["duration"];
+	        this.rowCount = source["rowCount"];
+	        this.error = source["error"];
+	        this.dryRun = source["dryRun"];
+	    }
+	}
+	export class ImportCandidate {
+	    key: string;
+	    kind: string;
+	    origin: string;
+	    config: DatabaseConfig;
+	    hasPassword: boolean;
+	    duplicate: boolean;
+	    duplicateOf?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.kind = source["kind"];
+	        this.origin = source["origin"];
+	        this.config = this.convertValues(source["config"], DatabaseConfig);
+	        this.hasPassword = source["hasPassword"];
+	        this.duplicate = source["duplicate"];
+	        this.duplicateOf = source["duplicateOf"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ImportError {
+	    kind: string;
+	    path: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.path = source["path"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ImportRequest {
+	    kind: string;
+	    path?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.path = source["path"];
+	    }
 	}
-
-}
-
-export namespace types {
+	export class ImportSource {
+	    kind: string;
+	    label: string;
+	    defaultPath: string;
 	
-	export class ConnectionSummary {
-	    id: string;
-	    host: string;
-	    port: number;
+	    static createFrom(source: any = {}) {
+	        return new ImportSource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.label = source["label"];
+	        this.defaultPath = source["defaultPath"];
+	    }
+	}
+	export class PlanBuffers {
+	    sharedHit: number;
+	    sharedRead: number;
+	    sharedDirtied: number;
+	    sharedWritten: number;
+	    tempRead: number;
+	    tempWritten: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ConnectionSummary(source);
+	        return new PlanBuffers(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sharedHit = source["sharedHit"];
+	        this.sharedRead = source["sharedRead"];
+	        this.sharedDirtied = source["sharedDirtied"];
+	        this.sharedWritten = source["sharedWritten"];
+	        this.tempRead = source["tempRead"];
+	        this.tempWritten = source["tempWritten"];
+	    }
+	}
+	export class PlanHotspot {
+	    kind: string;
+	    nodeId: number;
+	    value: number;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanHotspot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.nodeId = source["nodeId"];
+	        this.value = source["value"];
+	        this.message = source["message"];
+	    }
+	}
+	export class PlanNode {
+	    id: number;
+	    nodeType: string;
+	    relation?: string;
+	    index?: string;
+	    detail?: string;
+	    fullScan?: boolean;
+	    estimatedRows?: number;
+	    actualRows?: number;
+	    loops?: number;
+	    rowsScanned?: number;
+	    startupCost?: number;
+	    totalCost?: number;
+	    actualTime?: number;
+	    selfTime?: number;
+	    buffers?: PlanBuffers;
+	    children: PlanNode[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanNode(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.host = source["host"];
-	        this.port = source["port"];
+	        this.nodeType = source["nodeType"];
+	        this.relation = source["relation"];
+	        this.index = source["index"];
+	        this.detail = source["detail"];
+	        this.fullScan = source["fullScan"];
+	        this.estimatedRows = source["estimatedRows"];
+	        this.actualRows = source["actualRows"];
+	        this.loops = source["loops"];
+	        this.rowsScanned = source["rowsScanned"];
+	        this.startupCost = source["startupCost"];
+	        this.totalCost = source["totalCost"];
+	        this.actualTime = source["actualTime"];
+	        this.selfTime = source["selfTime"];
+	        this.buffers = this.convertValues(source["buffers"], PlanBuffers);
+	        this.children = this.convertValues(source["children"], PlanNode);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class GenerateQueryResult {
-	    generatedQuery: string;
-	    originalPrompt: string;
+	export class QueryConfirmation {
+	    token: string;
+	    expiresIn: number;
+	    statements: DestructiveStatement[];
 	
 	    static createFrom(source: any = {}) {
-	        return new GenerateQueryResult(source);
+	        return new QueryConfirmation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.generatedQuery = source["generatedQuery"];
-	        this.originalPrompt = source["originalPrompt"];
+	        this.token = source["token"];
+	        this.expiresIn = source["expiresIn"];
+	        this.statements = this.convertValues(source["statements"], DestructiveStatement);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class QueryParameter {
+	    name: string;
+	    placeholder: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryParameter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.placeholder = source["placeholder"];
 	    }
 	}
-	export class QueryResult {
-	    columns: string[];
-	    rows: any[][];
-	    rowsAffected: number;
+	export class QueryParameterValue {
+	    name: string;
+	    type?: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryParameterValue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	    }
+	}
+	export class TransactionState {
+	    active: boolean;
+	    dirty: boolean;
+	    database: string;
+	    startedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TransactionState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.active = source["active"];
+	        this.dirty = source["dirty"];
+	        this.database = source["database"];
+	        this.startedAt = source["startedAt"];
+	    }
+	}
+	export class QueryPlan {
+	    root?: PlanNode;
+	    analyzed: boolean;
+	    planningTime?: number;
+	    executionTime?: number;
+	    hotspots: PlanHotspot[];
+	    raw: string;
 	    duration: number;
+	    transaction?: TransactionState;
 	
 	    static createFrom(source: any = {}) {
-	        return new QueryResult(source);
+	        return new QueryPlan(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.columns = source["columns"];
-	        this.rows = source["rows"];
-	        this.rowsAffected = source["rowsAffected"];
+	        this.root = this.convertValues(source["root"], PlanNode);
+	        this.analyzed = source["analyzed"];
+	        this.planningTime = source["planningTime"];
+	        this.executionTime = source["executionTime"];
+	        this.hotspots = this.convertValues(source["hotspots"], PlanHotspot);
+	        this.raw = source["raw"];
 	        this.duration = source["duration"];
+	        this.transaction = this.convertValues(source["transaction"], TransactionState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class Snippet {
+	    id: string;
+	    name: string;
+	    description?: string;
+	    folder: string;
+	    connectionId?: string;
+	    query: string;
+	    variables: string[];
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Snippet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.folder = source["folder"];
+	        this.connectionId = source["connectionId"];
+	        this.query = source["query"];
+	        this.variables = source["variables"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class StatementResult {
+	    statement: string;
+	    start: number;
+	    end: number;
+	    result?: QueryResult;
+	    error?: string;
+	    preview?: DryRunPreview;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatementResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statement = source["statement"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.result = this.convertValues(source["result"], QueryResult);
+	        this.error = source["error"];
+	        this.preview = this.convertValues(source["preview"], DryRunPreview);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TLSStatus {
+	    enabled: boolean;
+	    version?: string;
+	    cipher?: string;
+	    subject?: string;
+	    issuer?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TLSStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.version = source["version"];
+	        this.cipher = source["cipher"];
+	        this.subject = source["subject"];
+	        this.issuer = source["issuer"];
 	    }
 	}
 	export class TableColumn {
@@ -505,6 +2703,126 @@ export namespace types {
 	        this.defaultValue = source["defaultValue"];
 	    }
 	}
+	
+	export class VaultStatus {
+	    initialized: boolean;
+	    locked: boolean;
+	    relockAfter: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new VaultStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.initialized = source["initialized"];
+	        this.locked = source["locked"];
+	        this.relockAfter = source["relockAfter"];
+	    }
+	}
+	export class VendorArgument {
+	    name: string;
+	    label: string;
+	    description?: string;
+	    type: string;
+	    options?: string[];
+	    default?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VendorArgument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	        this.description = source["description"];
+	        this.type = source["type"];
+	        this.options = source["options"];
+	        this.default = source["default"];
+	    }
+	}
+	export class VendorFormat {
+	    name: string;
+	    label: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VendorFormat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	    }
+	}
+	export class VendorField {
+	    name: string;
+	    label: string;
+	    type: string;
+	    required: boolean;
+	    placeholder?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VendorField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	        this.type = source["type"];
+	        this.required = source["required"];
+	        this.placeholder = source["placeholder"];
+	    }
+	}
+	export class VendorCapabilities {
+	    name: string;
+	    displayName: string;
+	    schemes: string[];
+	    defaultPort: number;
+	    fields: VendorField[];
+	    sslModes: string[];
+	    arguments: VendorArgument[];
+	    supportsSSH: boolean;
+	    formats: VendorFormat[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VendorCapabilities(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.displayName = source["displayName"];
+	        this.schemes = source["schemes"];
+	        this.defaultPort = source["defaultPort"];
+	        this.fields = this.convertValues(source["fields"], VendorField);
+	        this.sslModes = source["sslModes"];
+	        this.arguments = this.convertValues(source["arguments"], VendorArgument);
+	        this.supportsSSH = source["supportsSSH"];
+	        this.formats = this.convertValues(source["formats"], VendorFormat);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 
 }
 
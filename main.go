@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"time"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 
 	"seagle/core/domain"
+	_ "seagle/core/domain/vendors"
 	"seagle/core/infra/handlers"
+	"seagle/core/infra/importers"
 	"seagle/core/infra/persistence"
 	"seagle/core/services"
 )
@@ -19,17 +23,35 @@ var assets embed.FS
 func main() {
 	app := NewApp()
 
-	serviceFactory := domain.NewServiceFactory()
+	vault := persistence.NewVault(persistence.FileAtHomeDir(".seagle", "data", "vault.json"), 15*time.Minute)
+
+	sessionManager := domain.NewSessionManager(domain.DefaultSessionOptions(), vault)
+	serviceFactory := domain.NewServiceFactory(sessionManager)
 	metadataFactory := domain.NewMetadataFactory(serviceFactory)
 
-	connectionRepo := persistence.NewConnection(persistence.FileAtHomeDir(".seagle", "data", "connections.json"))
+	connectionRepo := persistence.NewConnection(persistence.FileAtHomeDir(".seagle", "data", "connections.json"), vault)
 	metadataRepo := persistence.NewMetadataRepository(persistence.FileAtHomeDir(".seagle", "data", "metadata.json"))
-	configRepo := persistence.NewConfigRepo(persistence.FileAtHomeDir(".seagle", "data", "config.json"))
+	configRepo := persistence.NewConfigRepo(persistence.FileAtHomeDir(".seagle", "data", "config.json"), vault)
+	historyRepo := persistence.NewQueryHistoryRepo(persistence.FileAtHomeDir(".seagle", "data", "history.jsonl"), 16<<20)
+	snippetRepo := persistence.NewSnippetRepo(persistence.FileAtHomeDir(".seagle", "data", "snippets.json"))
 
-	openaiClient := services.NewOpenAIClient(configRepo)
+	openaiClient := services.NewOpenAIClient(configRepo, vault)
 
-	connectionService := services.NewConnectionService(connectionRepo, metadataRepo, serviceFactory, metadataFactory, openaiClient)
+	connectionService := services.NewConnectionService(connectionRepo, metadataRepo, serviceFactory, metadataFactory, openaiClient, vault, configRepo, historyRepo, snippetRepo)
 	configService := services.NewConfigService(configRepo)
+	historyService := services.NewHistoryService(historyRepo, connectionRepo)
+	snippetService := services.NewSnippetService(snippetRepo, connectionRepo, connectionService)
+	vendorService := services.NewVendorService()
+	vaultService := services.NewVaultService(vault, connectionRepo, configRepo)
+	importService := services.NewImportService(
+		connectionRepo,
+		importers.NewPgPass(),
+		importers.NewPgService(),
+		importers.NewMyCnf(),
+		importers.NewDBeaver(),
+		importers.NewDataGrip(),
+		importers.NewDotEnv(),
+	)
 
 	connectHnd := handlers.NewConnectHandler(connectionService)
 	testConnHnd := handlers.NewTestConnectionHandler(connectionService)
@@ -37,6 +59,22 @@ func main() {
 	getTablesHnd := handlers.NewGetTablesHandler(connectionService)
 	getTableColumnsHnd := handlers.NewGetTableColumnsHandler(connectionService)
 	executeQueryHnd := handlers.NewExecuteQueryHandler(connectionService)
+	cancelQueryHnd := handlers.NewCancelQueryHandler(connectionService)
+	fetchResultPageHnd := handlers.NewFetchResultPageHandler(connectionService)
+	closeResultHnd := handlers.NewCloseResultHandler(connectionService)
+	getQueryParametersHnd := handlers.NewGetQueryParametersHandler(connectionService)
+	beginTransactionHnd := handlers.NewBeginTransactionHandler(connectionService)
+	commitTransactionHnd := handlers.NewCommitTransactionHandler(connectionService)
+	rollbackTransactionHnd := handlers.NewRollbackTransactionHandler(connectionService)
+	explainQueryHnd := handlers.NewExplainQueryHandler(connectionService)
+	searchHistoryHnd := handlers.NewSearchHistoryHandler(historyService)
+	openHistoryEntryHnd := handlers.NewOpenHistoryEntryHandler(historyService)
+	pruneHistoryHnd := handlers.NewPruneHistoryHandler(historyService)
+	createSnippetHnd := handlers.NewCreateSnippetHandler(snippetService)
+	updateSnippetHnd := handlers.NewUpdateSnippetHandler(snippetService)
+	deleteSnippetHnd := handlers.NewDeleteSnippetHandler(snippetService)
+	searchSnippetsHnd := handlers.NewSearchSnippetsHandler(snippetService)
+	runSnippetHnd := handlers.NewRunSnippetHandler(snippetService)
 	listConnHnd := handlers.NewListConnectionsHandler(connectionService)
 	connectByIDHnd := handlers.NewConnectByIDHandler(connectionService)
 	analyzeMetadataHnd := handlers.NewAnalyzeMetadataHandler(connectionService)
@@ -44,6 +82,18 @@ func main() {
 	setConfigHnd := handlers.NewSetConfigHandler(configService)
 	getConfigHnd := handlers.NewGetConfigHandler(configService)
 	deleteConnectionHnd := handlers.NewDeleteConnectionHandler(connectionService)
+	listVendorsHnd := handlers.NewListVendorsHandler(vendorService)
+	parseConnStrHnd := handlers.NewParseConnectionStringHandler(connectionService)
+	formatConnStrHnd := handlers.NewFormatConnectionStringHandler(connectionService)
+	updateConnectionHnd := handlers.NewUpdateConnectionHandler(connectionService)
+	duplicateConnectionHnd := handlers.NewDuplicateConnectionHandler(connectionService)
+	listImportSourcesHnd := handlers.NewListImportSourcesHandler(importService)
+	previewImportHnd := handlers.NewPreviewImportHandler(importService)
+	importConnectionsHnd := handlers.NewImportConnectionsHandler(importService)
+	getVaultStatusHnd := handlers.NewGetVaultStatusHandler(vaultService)
+	createVaultHnd := handlers.NewCreateVaultHandler(vaultService)
+	unlockVaultHnd := handlers.NewUnlockVaultHandler(vaultService)
+	lockVaultHnd := handlers.NewLockVaultHandler(vaultService)
 
 	// Create application with options
 	err := wails.Run(&options.App{
@@ -56,6 +106,10 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.startup,
+		OnShutdown: func(ctx context.Context) {
+			sessionManager.Shutdown()
+			vault.Lock()
+		},
 		Bind: []any{
 			app,
 			connectHnd,
@@ -64,6 +118,22 @@ func main() {
 			getTablesHnd,
 			getTableColumnsHnd,
 			executeQueryHnd,
+			cancelQueryHnd,
+			fetchResultPageHnd,
+			closeResultHnd,
+			getQueryParametersHnd,
+			beginTransactionHnd,
+			commitTransactionHnd,
+			rollbackTransactionHnd,
+			explainQueryHnd,
+			searchHistoryHnd,
+			openHistoryEntryHnd,
+			pruneHistoryHnd,
+			createSnippetHnd,
+			updateSnippetHnd,
+			deleteSnippetHnd,
+			searchSnippetsHnd,
+			runSnippetHnd,
 			listConnHnd,
 			connectByIDHnd,
 			analyzeMetadataHnd,
@@ -71,6 +141,18 @@ func main() {
 			setConfigHnd,
 			getConfigHnd,
 			deleteConnectionHnd,
+			listVendorsHnd,
+			parseConnStrHnd,
+			formatConnStrHnd,
+			updateConnectionHnd,
+			duplicateConnectionHnd,
+			listImportSourcesHnd,
+			previewImportHnd,
+			importConnectionsHnd,
+			getVaultStatusHnd,
+			createVaultHnd,
+			unlockVaultHnd,
+			lockVaultHnd,
 		},
 	})
 